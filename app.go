@@ -5,6 +5,7 @@ package guigui
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -19,6 +20,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/guigui-gui/guigui/internal/debugmode"
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 type invalidatedRegionsForDebugItem struct {
@@ -109,6 +112,10 @@ type app struct {
 	lastColorMode      ebiten.ColorMode
 	lastFocused        bool
 
+	windowState          WindowState
+	windowClosingHandled bool
+	closeWindowRequested bool
+
 	inputState inputState
 
 	focusedWidget Widget
@@ -138,6 +145,10 @@ type app struct {
 	// repaint anything, so callers that also need pixels refreshed must call [RequestRedraw].
 	treeRebuildRequested bool
 
+	// rebuildRequestedAt records the distinct call sites of RequestRebuild since the last rebuild,
+	// for diagnostics only.
+	rebuildRequestedAt []string
+
 	// stateKeyCheckPending is true when widget state may have changed since the last
 	// [app.checkStateKeys] run. It is set by widget phases (Build, Layout, Tick, HandleInput)
 	// and [Context] setters that affect state tracked in [Widget.WriteStateKey] or internalStateKey.
@@ -148,6 +159,38 @@ type app struct {
 
 	stateKeyWriter StateKeyWriter
 
+	// postedFuncs is a queue of functions posted by [Post] from arbitrary goroutines.
+	postedFuncs postedFuncs
+
+	// postedFuncsRan is set when posted functions ran in this tick, to force a rebuild in
+	// settleRebuildAndRedrawState.
+	postedFuncsRan bool
+
+	// tickCount is the number of ticks since the application started.
+	tickCount int64
+
+	// timers are timers scheduled by [Context.AfterFunc] and [Context.Every].
+	timers []*Timer
+
+	// buildingWidget is the widget whose [Widget.Build] is running.
+	// It is used to track [Observable] reads, and to find the failed widget for an [ErrorBoundary].
+	buildingWidget Widget
+
+	// propagatingEventHandlerCount is the number of capturing and bubbling event handlers registered
+	// since the last build. A replaced or removed handler is not counted. When this is 0, dispatching an event skips walking the ancestors.
+	propagatingEventHandlerCount int
+
+	inspector inspector
+
+	// profiler records the timings of the phases. profiler is nil unless profiling is enabled.
+	profiler *profiler
+
+	// inputRecorder records the input. inputRecorder is nil unless recording is enabled.
+	inputRecorder *inputRecorder
+
+	// inputReplayer replays recorded input. inputReplayer is nil unless replaying is enabled.
+	inputReplayer *inputReplayer
+
 	offscreen   *ebiten.Image
 	debugScreen *ebiten.Image
 }
@@ -157,7 +200,7 @@ type app struct {
 func (a *app) widgetStateKey(widget Widget) uint64 {
 	a.stateKeyWriter.reset()
 	widget.WriteStateKey(&a.context, &a.stateKeyWriter)
-	return a.stateKeyWriter.sum64()
+	return a.stateKeyWriter.Sum64()
 }
 
 var theApp app
@@ -168,7 +211,21 @@ type RunOptions struct {
 	WindowMinSize  image.Point
 	WindowMaxSize  image.Point
 	WindowFloating bool
-	AppScale       float64
+
+	// AppScale is the initial application scale factor. See [Context.SetAppScale].
+	// If AppScale is 0, the text scaling factor of GNOME or Cinnamon is used on Linux, or 1 otherwise.
+	AppScale float64
+
+	// WindowState is the window state to restore, which is usually saved by the previous run
+	// from [Context.WindowState]. WindowState overrides WindowSize.
+	// If WindowState is nil, the window is placed by the system.
+	WindowState *WindowState
+
+	// Fullscreen indicates whether the window starts in fullscreen.
+	Fullscreen bool
+
+	// WindowIcon is the window icon. See [Context.SetWindowIcon].
+	WindowIcon []image.Image
 
 	// ApplePressAndHoldDelegated indicates whether RunGameOptions.ApplePressAndHoldEnabled controls
 	// the macOS press-and-hold feature. When false, the feature is enabled regardless of
@@ -211,6 +268,7 @@ func RunWithCustomFunc(root Widget, options *RunOptions, f func(game ebiten.Game
 	}
 	ebiten.SetWindowSizeLimits(minW, minH, maxW, maxH)
 	ebiten.SetWindowFloating(options.WindowFloating)
+	applyWindowOptions(options)
 
 	a := &theApp
 	a.root = root
@@ -218,8 +276,15 @@ func RunWithCustomFunc(root Widget, options *RunOptions, f func(game ebiten.Game
 	a.deviceScale = deviceScaleFactor()
 	a.root.widgetState().root = true
 	a.context.app = a
+	a.profiler = newProfiler()
+	defer a.profiler.close()
+	a.inputRecorder = newInputRecorder()
+	defer a.inputRecorder.close()
+	a.inputReplayer = newInputReplayer()
 	if options.AppScale > 0 {
 		a.context.appScaleMinus1 = options.AppScale - 1
+	} else if f := desktopsettings.Get().TextScalingFactor; f > 0 {
+		a.context.appScaleMinus1 = f - 1
 	}
 
 	var eop ebiten.RunGameOptions
@@ -257,11 +322,11 @@ func (a *app) focusWidget(widget Widget) {
 	}
 	a.clearFocusAncestorFlags()
 	if a.focusedWidget != nil {
-		DispatchEvent(a.focusedWidget, widgetEventFocusChanged, false)
+		widgetEventFocusChanged.Dispatch(a.focusedWidget, false)
 	}
 	a.focusedWidget = widget
 	if a.focusedWidget != nil {
-		DispatchEvent(a.focusedWidget, widgetEventFocusChanged, true)
+		widgetEventFocusChanged.Dispatch(a.focusedWidget, true)
 	}
 	a.setFocusAncestorFlags()
 
@@ -333,27 +398,31 @@ func (a *app) settleRebuildAndRedrawState(inputHandledWidget Widget) {
 	a.requiredPhases = requiredPhasesNone
 	if dispatchedWidget != nil {
 		a.requiredPhases = a.requiredPhases.addBuild()
-		if debugmode.ShowBuildLogs() {
-			slog.Info("rebuilding tree next time: event dispatched", "widget", fmt.Sprintf("%T", dispatchedWidget))
-		}
+		logRebuild(rebuildReasonEventDispatched, dispatchedWidget)
 	}
 	if inputHandledWidget != nil {
 		a.requiredPhases = a.requiredPhases.addBuild()
-		if debugmode.ShowBuildLogs() {
-			slog.Info("rebuilding tree next time: input handled", "widget", fmt.Sprintf("%T", inputHandledWidget))
-		}
+		logRebuild(rebuildReasonInputHandled, inputHandledWidget)
+	}
+	if a.postedFuncsRan {
+		a.postedFuncsRan = false
+		a.requiredPhases = a.requiredPhases.addBuild()
+		logRebuild(rebuildReasonPostedFuncsRan, nil)
 	}
 	if !a.rebuildAndRedrawRequestedRegions.empty() {
 		a.requiredPhases = a.requiredPhases.addBuild()
-		if debugmode.ShowBuildLogs() {
-			slog.Info("rebuilding tree next time: region redraw requested", "region", a.rebuildAndRedrawRequestedRegions)
-		}
+		logRebuild(rebuildReasonRedrawRequested, nil, slog.String("region", a.rebuildAndRedrawRequestedRegions.region.String()))
 	}
 	if a.treeRebuildRequested {
 		a.treeRebuildRequested = false
 		a.requiredPhases = a.requiredPhases.addBuild()
-		if debugmode.ShowBuildLogs() {
-			slog.Info("rebuilding tree next time: rebuild requested")
+		if len(a.rebuildRequestedAt) > 0 {
+			for _, at := range a.rebuildRequestedAt {
+				logRebuild(rebuildReasonRebuildRequested, nil, slog.String("at", at))
+			}
+			a.rebuildRequestedAt = a.rebuildRequestedAt[:0]
+		} else {
+			logRebuild(rebuildReasonRebuildRequested, nil)
 		}
 	}
 
@@ -364,9 +433,22 @@ func (a *app) settleRebuildAndRedrawState(inputHandledWidget Widget) {
 	a.rebuildAndRedrawRequestedRegions.reset()
 }
 
+// runPostedFuncs runs the functions posted by [Post], and marks the state to be checked and the tree
+// to be rebuilt when any function ran.
+func (a *app) runPostedFuncs() {
+	if a.postedFuncs.run(&a.context) {
+		a.stateKeyCheckPending = true
+		a.postedFuncsRan = true
+	}
+}
+
 func (a *app) Update() error {
+	defer a.profiler.span("Update", a.profiler.now())
+
 	var layoutChangedInUpdate bool
 
+	a.tickCount++
+
 	if a.focusedWidget == nil {
 		a.focusWidget(a.root)
 	}
@@ -376,19 +458,20 @@ func (a *app) Update() error {
 		a.requestRebuildAndRedrawScreen(requestRedrawReasonScreenDeviceScale)
 	}
 
+	// Replay the recorded input before any input is read in this tick.
+	a.inputReplayer.step(a.deviceScale)
+
 	if a.context.ColorMode() != a.lastColorMode {
 		a.lastColorMode = a.context.ColorMode()
 		a.requestRebuildAndRedrawScreen(requestRedrawReasonColorMode)
 	}
 
-	if focused := ebiten.IsFocused(); focused != a.lastFocused {
-		a.lastFocused = focused
-		// On regaining focus, redraw the entire screen: the screen is not cleared
-		// every frame, so regions changed while unfocused (e.g. a popup fading out
-		// off-screen) would otherwise never be repainted.
-		if focused {
-			a.requestRebuildAndRedrawScreen(requestRedrawReasonAppFocus)
-		}
+	focusChanged := a.updateFocused()
+	// On regaining focus, redraw the entire screen: the screen is not cleared
+	// every frame, so regions changed while unfocused (e.g. a popup fading out
+	// off-screen) would otherwise never be repainted.
+	if focusChanged && a.lastFocused {
+		a.requestRebuildAndRedrawScreen(requestRedrawReasonAppFocus)
 	}
 
 	rootState := a.root.widgetState()
@@ -419,11 +502,17 @@ func (a *app) Update() error {
 		layoutChangedInUpdate = true
 	}
 
+	// Run functions posted from other goroutines after the first build, as input handlers run, so they
+	// see the event handlers set in this tick's Build. The second build reflects their state changes.
+	a.runPostedFuncs()
+
 	// Handle user inputs.
 	// TODO: Handle this in Ebitengine's HandleInput in the future (hajimehoshi/ebiten#1704)
 	a.inputState.update()
+	a.inputRecorder.record(a.tickCount, a.screenWidth, a.screenHeight, a.deviceScale)
+	inspecting := a.updateInspector()
 	var inputHandledWidget Widget
-	if a.inputState.isPointingActive(layoutChangedInUpdate) {
+	if !inspecting && a.inputState.isPointingActive(layoutChangedInUpdate) {
 		if r := a.handleInputWidget(handleInputTypePointing); r.widget != nil {
 			if !r.aborted {
 				inputHandledWidget = r.widget
@@ -441,7 +530,7 @@ func (a *app) Update() error {
 	// TODO: Run handleInputWidget on IME activity too (e.g. once Ebitengine
 	// exposes pending-IME-input state) so the composer is driven here uniformly
 	// and host widgets need no per-tick pump.
-	if a.inputState.isButtonActive() {
+	if !inspecting && a.inputState.isButtonActive() {
 		a.setButtonInputReceptiveAncestorFlags()
 		if r := a.handleInputWidget(handleInputTypeButton); r.widget != nil {
 			if !r.aborted {
@@ -466,10 +555,22 @@ func (a *app) Update() error {
 		ebiten.SetCursorShape(ebiten.CursorShapeDefault)
 	}
 
+	// Dispatch the window events after building, as the handlers are set in Build.
+	if a.handleWindowEvents(focusChanged) {
+		return ebiten.Termination
+	}
+
 	// Tick
+	tickStart := a.profiler.now()
 	if err := a.tickWidgets(); err != nil {
 		return err
 	}
+	a.profiler.span("Tick", tickStart)
+
+	// Timers are driven after Tick, so a timer sees the same widget states as Tick does.
+	// A fired timer does not rebuild the tree by itself: the state key check below rebuilds
+	// only when a callback changed a widget's state.
+	a.runTimers()
 
 	if layoutChangedInUpdate {
 		// Invalidate regions if a widget's children state is changed.
@@ -479,6 +580,10 @@ func (a *app) Update() error {
 
 	a.settleRebuildAndRedrawState(nil)
 
+	if a.closeWindowRequested {
+		return ebiten.Termination
+	}
+
 	if debugmode.ShowRenderingRegions() {
 		// Update the regions in the reversed order to remove items.
 		for idx := len(a.invalidatedRegionsForDebug) - 1; idx >= 0; idx-- {
@@ -508,9 +613,16 @@ func (a *app) Update() error {
 }
 
 func (a *app) Draw(screen *ebiten.Image) {
+	start := a.profiler.now()
+	defer func() {
+		a.profiler.span("Draw", start)
+		a.profiler.flush()
+	}()
+
 	origScreen := screen
-	if debugmode.ShowRenderingRegions() {
+	if debugmode.ShowRenderingRegions() || debugmode.Inspector() {
 		// As the screen is not cleered every frame, create offscreen here to keep the previous contents.
+		// The debug overlays are drawn on the screen after copying the offscreen.
 		if a.offscreen != nil {
 			if a.offscreen.Bounds().Dx() != screen.Bounds().Dx() || a.offscreen.Bounds().Dy() != screen.Bounds().Dy() {
 				a.offscreen.Deallocate()
@@ -528,6 +640,7 @@ func (a *app) Draw(screen *ebiten.Image) {
 		op.Blend = ebiten.BlendCopy
 		origScreen.DrawImage(a.offscreen, op)
 		a.drawDebugIfNeeded(origScreen)
+		a.drawInspector(origScreen)
 	}
 	a.regionsToDraw = image.Rectangle{}
 }
@@ -544,10 +657,12 @@ func (a *app) LayoutF(outsideWidth, outsideHeight float64) (float64, float64) {
 }
 
 func (a *app) enqueueRedrawRegion(region image.Rectangle, reasons requestRedrawReasons, widget Widget) {
+	a.profiler.redrawRequested(region, reasons, widget)
+	logRedraw(region, reasons, widget)
 	if reasons.triggersRebuild() {
-		a.rebuildAndRedrawRequestedRegions.add(region, reasons, widget)
+		a.rebuildAndRedrawRequestedRegions.add(region)
 	} else {
-		a.redrawRequestedRegions.add(region, reasons, widget)
+		a.redrawRequestedRegions.add(region)
 	}
 }
 
@@ -633,6 +748,9 @@ func (a *app) putBounds3Ds(s []widgetStateAndBounds) {
 }
 
 func (a *app) buildWidgets() error {
+	defer a.profiler.span("Build", a.profiler.now())
+	a.profiler.rebuilt()
+
 	a.buildCount++
 	a.stateKeyCheckPending = true
 
@@ -640,6 +758,7 @@ func (a *app) buildWidgets() error {
 
 	// Clear event handlers to prevent unexpected handlings.
 	// An event handler is often a closure capturing variables, and this might cause unexpected behaviors.
+	a.propagatingEventHandlerCount = 0
 	for _, widget := range a.widgetList {
 		widgetState := widget.widgetState()
 		widgetState.eventHandlers = slices.Delete(widgetState.eventHandlers, 0, len(widgetState.eventHandlers))
@@ -652,6 +771,8 @@ func (a *app) buildWidgets() error {
 		widgetState.enabledCacheValid = false
 		widgetState.passthroughCacheValid = false
 		widgetState.passthroughCache = false
+		widgetState.readingDirectionCache = ReadingDirectionAuto
+		widgetState.readingDirectionCacheValid = false
 		widgetState.focusedOrHasFocusedDescendant = false
 		widgetState.buttonInputReceptiveOrHasReceptiveDescendant = false
 		// Do not reset bounds an zs here, as they are used to determine whether redraw is needed.
@@ -664,17 +785,8 @@ func (a *app) buildWidgets() error {
 	a.widgetList = slices.Delete(a.widgetList, 0, len(a.widgetList))
 
 	var adder ChildAdder
-	if err := traverseWidget(a.root, func(widget Widget) error {
-		widgetState := widget.widgetState()
-		widgetState.children = slices.Delete(widgetState.children, 0, len(widgetState.children))
-		adder.app = a
-		adder.widget = widget
-		if err := widget.Build(&a.context, &adder); err != nil {
-			return err
-		}
-		a.widgetList = append(a.widgetList, widget)
-		return nil
-	}); err != nil {
+	if err := a.buildWidget(a.root, &adder, nil); err != nil {
+		a.buildingWidget = nil
 		return err
 	}
 
@@ -690,6 +802,8 @@ func (a *app) buildWidgets() error {
 		a.putBounds3Ds(ws.prev.currentBounds3D)
 		ws.prev.bounds3Ds = nil
 		ws.prev.currentBounds3D = nil
+		// Do not keep the observed values reachable from a widget out of the tree.
+		ws.observedValues = nil
 		a.maybeHitWidgetsInvalidated = true
 	}
 
@@ -718,6 +832,7 @@ func (a *app) buildWidgets() error {
 
 // checkStateKeys compares each widget's current [Widget.WriteStateKey] against the snapshot
 // captured after the last [Widget.Build]. If the key has changed, it requests a rebuild.
+// Likewise, it requests a rebuild if any [Observable] read in the last [Widget.Build] has changed.
 // The snapshot is not refreshed here; it is only refreshed when [Widget.Build] runs again.
 //
 // The check is gated on stateKeyCheckPending: if no widget phase or [Context] setter has
@@ -736,6 +851,10 @@ func (a *app) checkStateKeys() {
 			a.requestRedraw(ws, requestRedrawReasonStateKeyChangedForBuild)
 			continue
 		}
+		if ws.observedValuesChanged() {
+			a.requestRedraw(ws, requestRedrawReasonObservableChanged)
+			continue
+		}
 		if a.widgetStateKey(widget) == ws.capturedStateKey {
 			continue
 		}
@@ -744,6 +863,8 @@ func (a *app) checkStateKeys() {
 }
 
 func (a *app) layoutWidgets() {
+	defer a.profiler.span("Layout", a.profiler.now())
+
 	a.stateKeyCheckPending = true
 	clear(a.visitedLayers)
 	if a.visitedLayers == nil {
@@ -766,7 +887,20 @@ func (a *app) layoutWidgets() {
 		// Call Layout.
 		// Even if widgetState.bounds.Empty(), call Layout to allow widgets to set up child states.
 		bounds := widgetBoundsFromWidget(&a.context, widget)
-		widget.Layout(&a.context, bounds, &layouter)
+		start := a.profiler.now()
+		a.context.callingWidget = widget
+		if b := widgetState.errorBoundary; b != nil && b.errorBoundaryState().recoverPanics {
+			if err := callRecoveringPanic(true, func() error {
+				widget.Layout(&a.context, bounds, &layouter)
+				return nil
+			}); err != nil {
+				a.catchWidgetError(b, widget, "Layout", err)
+			}
+		} else {
+			widget.Layout(&a.context, bounds, &layouter)
+		}
+		a.context.callingWidget = nil
+		a.profiler.widgetSpan("Layout", widget, start)
 
 		a.visitedLayers[widgetState.actualLayer()] = struct{}{}
 	}
@@ -777,7 +911,7 @@ func (a *app) layoutWidgets() {
 }
 
 func (a *app) updateHitWidgets(layoutChanged bool) {
-	pt := image.Pt(ebiten.CursorPosition())
+	pt := image.Pt(inputsource.CursorPosition())
 	if !layoutChanged && !a.maybeHitWidgetsInvalidated && pt == a.lastCursorPosition {
 		return
 	}
@@ -799,6 +933,8 @@ const (
 )
 
 func (a *app) handleInputWidget(typ handleInputType) HandleInputResult {
+	defer a.profiler.span("HandleInput", a.profiler.now())
+
 	for _, layer := range slices.Backward(a.layers) {
 
 		if r := a.doHandleInputWidget(typ, a.root, layer, false); r.IsHandled() {
@@ -850,10 +986,16 @@ func (a *app) doHandleInputWidget(typ handleInputType, widget Widget, layerToHan
 	bounds := widgetBoundsFromWidget(&a.context, widget)
 
 	a.stateKeyCheckPending = true
+	a.context.callingWidget = widget
+	defer func() {
+		a.context.callingWidget = nil
+	}()
 	switch typ {
 	case handleInputTypePointing:
+		defer a.profiler.widgetSpan("HandlePointingInput", widget, a.profiler.now())
 		return widget.HandlePointingInput(&a.context, bounds)
 	case handleInputTypeButton:
+		defer a.profiler.widgetSpan("HandleButtonInput", widget, a.profiler.now())
 		return widget.HandleButtonInput(&a.context, bounds)
 	default:
 		panic(fmt.Sprintf("guigui: unknown handleInputType: %d", typ))
@@ -900,9 +1042,24 @@ func (a *app) tickWidgets() error {
 			a.context.resetDefaultTickMethodCalled()
 		}
 		a.stateKeyCheckPending = true
-		if err := widget.Tick(&a.context, bounds); err != nil {
+		start := a.profiler.now()
+		a.context.callingWidget = widget
+		if b := ws.errorBoundary; b != nil {
+			if err := callRecoveringPanic(b.errorBoundaryState().recoverPanics, func() error {
+				return widget.Tick(&a.context, bounds)
+			}); err != nil {
+				if errors.Is(err, ebiten.Termination) {
+					a.context.callingWidget = nil
+					return err
+				}
+				a.catchWidgetError(b, widget, "Tick", err)
+			}
+		} else if err := widget.Tick(&a.context, bounds); err != nil {
+			a.context.callingWidget = nil
 			return err
 		}
+		a.context.callingWidget = nil
+		a.profiler.widgetSpan("Tick", widget, start)
 		if !ws.hasCustomTickChecked {
 			ws.hasCustomTickChecked = true
 			ws.hasCustomTick = !a.context.isDefaultTickMethodCalled()
@@ -916,14 +1073,14 @@ func (a *app) requestRedrawIfTreeChanged() {
 		widgetState := widget.widgetState()
 		// If the children and/or children's bounds are changed, request redraw.
 		if !widgetState.prev.equals(&a.context, widgetState.children) {
-			a.enqueueRedrawRegion(a.context.visibleBounds(widgetState), redrawReasonsOf(requestRedrawReasonTreeChanged), nil)
+			a.enqueueRedrawRegion(a.context.visibleBounds(widgetState), redrawReasonsOf(requestRedrawReasonTreeChanged), widget)
 
 			widgetState.prev.requestRedraw(a)
 
 			// If the widget is a clipping widget, all the children are included in the visible bounds.
 			if !widgetState.clipChildren {
 				for _, child := range widgetState.children {
-					a.enqueueRedrawRegion(a.context.visibleBounds(child.widgetState()), redrawReasonsOf(requestRedrawReasonTreeChanged), nil)
+					a.enqueueRedrawRegion(a.context.visibleBounds(child.widgetState()), redrawReasonsOf(requestRedrawReasonTreeChanged), child)
 				}
 			}
 		}
@@ -989,7 +1146,20 @@ func (a *app) doDrawWidget(dst *ebiten.Image, widget Widget, layerToRender int64
 		if dst.Bounds() != vb {
 			subDst = dst.RecyclableSubImage(vb)
 		}
-		widget.Draw(&a.context, widgetBounds, subDst)
+		start := a.profiler.now()
+		a.context.callingWidget = widget
+		if b := widgetState.errorBoundary; b != nil && b.errorBoundaryState().recoverPanics {
+			if err := callRecoveringPanic(true, func() error {
+				widget.Draw(&a.context, widgetBounds, subDst)
+				return nil
+			}); err != nil {
+				a.catchWidgetError(b, widget, "Draw", err)
+			}
+		} else {
+			widget.Draw(&a.context, widgetBounds, subDst)
+		}
+		a.context.callingWidget = nil
+		a.profiler.widgetSpan("Draw", widget, start)
 		if subDst != dst {
 			subDst.Recycle()
 			subDst = nil
@@ -1130,12 +1300,15 @@ func (a *app) appendWidgetsAt(widgets []widgetAndLayer, point image.Point, widge
 // A rebuild re-runs [Widget.Build] across the tree but does not by itself repaint anything;
 // to refresh a widget's pixels, call [RequestRedraw].
 func RequestRebuild() {
-	if debugmode.ShowBuildLogs() {
+	if rebuildLogger() != nil {
 		if _, file, line, ok := runtime.Caller(1); ok {
-			slog.Info("rebuild requested", "at", fmt.Sprintf("%s:%d", file, line))
+			a := currentApp()
+			if at := fmt.Sprintf("%s:%d", file, line); !slices.Contains(a.rebuildRequestedAt, at) {
+				a.rebuildRequestedAt = append(a.rebuildRequestedAt, at)
+			}
 		}
 	}
-	theApp.requestRebuild()
+	currentApp().requestRebuild()
 }
 
 // requestRebuild rebuilds the whole tree without seeding any redraw; the repaint, if any,
@@ -1159,12 +1332,12 @@ func (a *app) requestRebuildAndRedrawScreen(redrawReason requestRedrawReason) {
 // If the tree, content, or layout changed, call [RequestRebuild] as well.
 func RequestRedraw(widget Widget) {
 	widgetState := widget.widgetState()
-	if debugmode.ShowRenderingRegions() {
+	if redrawLogger() != nil {
 		if _, file, line, ok := runtime.Caller(1); ok {
 			widgetState.redrawRequestedAt = fmt.Sprintf("%s:%d", file, line)
 		}
 	}
-	theApp.requestRedraw(widgetState, requestRedrawReasonExplicitRequest)
+	currentApp().requestRedraw(widgetState, requestRedrawReasonExplicitRequest)
 }
 
 func (a *app) requestRedraw(widgetState *widgetState, reason requestRedrawReason) {
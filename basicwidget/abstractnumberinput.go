@@ -6,7 +6,6 @@ package basicwidget
 import (
 	"math"
 	"math/big"
-	"strings"
 
 	"github.com/guigui-gui/guigui"
 )
@@ -20,6 +19,8 @@ type abstractNumberInput struct {
 	step    big.Int
 	stepSet bool
 
+	format NumberFormat
+
 	onValueChanged       func(value int, committed bool)
 	onValueChangedString func(value string, force bool)
 	onValueChangedBigInt func(value *big.Int, committed bool)
@@ -43,6 +44,19 @@ func (a *abstractNumberInput) writeStateKey(w *guigui.StateKeyWriter) {
 	}
 }
 
+// setNumberFormat sets the format to convert the value to a text and back.
+// A nil format means [defaultNumberFormat].
+func (a *abstractNumberInput) setNumberFormat(format NumberFormat) {
+	a.format = format
+}
+
+func (a *abstractNumberInput) numberFormat() NumberFormat {
+	if a.format == nil {
+		return defaultNumberFormat
+	}
+	return a.format
+}
+
 func (a *abstractNumberInput) OnValueChanged(f func(value int, committed bool)) {
 	a.onValueChanged = f
 }
@@ -68,7 +82,7 @@ func (a *abstractNumberInput) dispatchValueChangeEvents(force bool, committed bo
 		a.onValueChanged(a.Value(), committed)
 	}
 	if a.onValueChangedString != nil {
-		a.onValueChangedString(a.value.String(), force)
+		a.onValueChangedString(a.ValueString(), force)
 	}
 	if a.onValueChangedBigInt != nil {
 		a.onValueChangedBigInt(a.ValueBigInt(), committed)
@@ -95,7 +109,7 @@ func (a *abstractNumberInput) Value() int {
 }
 
 func (a *abstractNumberInput) ValueString() string {
-	return a.value.String()
+	return a.numberFormat().FormatNumber(&a.value)
 }
 
 func (a *abstractNumberInput) ValueBigInt() *big.Int {
@@ -295,33 +309,12 @@ func (a *abstractNumberInput) Rate() float64 {
 func (a *abstractNumberInput) SetRate(rate float64) {
 }
 
-var numberTextReplacer = strings.NewReplacer(
-	"\u2212", "-",
-	"\ufe62", "+",
-	"\ufe63", "-",
-	"\uff0b", "+",
-	"\uff0d", "-",
-	"\uff10", "0",
-	"\uff11", "1",
-	"\uff12", "2",
-	"\uff13", "3",
-	"\uff14", "4",
-	"\uff15", "5",
-	"\uff16", "6",
-	"\uff17", "7",
-	"\uff18", "8",
-	"\uff19", "9",
-)
-
 func (a *abstractNumberInput) SetString(text string, force bool, committed bool) {
-	text = strings.TrimSpace(text)
-	text = numberTextReplacer.Replace(text)
-
-	var v big.Int
-	if _, ok := v.SetString(text, 10); !ok {
+	v, ok := a.numberFormat().ParseNumber(text)
+	if !ok {
 		return
 	}
-	a.setValue(&v, force, committed)
+	a.setValue(v, force, committed)
 }
 
 func (n *abstractNumberInput) Increment() {
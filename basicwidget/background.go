@@ -29,5 +29,5 @@ func (b *Background) SetTintColor(tint color.Color) {
 }
 
 func (b *Background) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
-	dst.Fill(draw.BackgroundColorFromTint(context.ColorMode(), b.tint))
+	dst.Fill(draw.BackgroundColorFromTint(colorScheme(context, b), b.tint))
 }
@@ -12,61 +12,61 @@ import (
 )
 
 func BorderColors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	return draw.BorderColors(colorMode, draw.RoundedRectBorderType(borderType))
+	return draw.BorderColors(draw.NewColorScheme(colorMode, nil), draw.RoundedRectBorderType(borderType))
 }
 
 func BorderAccentColors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	return draw.BorderAccentColors(colorMode, draw.RoundedRectBorderType(borderType))
+	return draw.BorderAccentColors(draw.NewColorScheme(colorMode, nil), draw.RoundedRectBorderType(borderType))
 }
 
 func BorderAccentSecondaryColors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	return draw.BorderAccentSecondaryColors(colorMode, draw.RoundedRectBorderType(borderType))
+	return draw.BorderAccentSecondaryColors(draw.NewColorScheme(colorMode, nil), draw.RoundedRectBorderType(borderType))
 }
 
 func BorderDangerColors(colorMode ebiten.ColorMode) (color.Color, color.Color) {
-	return draw.BorderDangerColors(colorMode)
+	return draw.BorderDangerColors(draw.NewColorScheme(colorMode, nil))
 }
 
 func TextColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
-	return draw.TextColor(colorMode, enabled)
+	return draw.TextColor(draw.NewColorScheme(colorMode, nil), enabled)
 }
 
 func TextColorFromTint(colorMode ebiten.ColorMode, tint color.Color) color.Color {
-	return draw.TextColorFromTint(colorMode, tint)
+	return draw.TextColorFromTint(draw.NewColorScheme(colorMode, nil), tint)
 }
 
 func TextSelectionColor(colorMode ebiten.ColorMode) color.Color {
-	return draw.TextSelectionColor(colorMode)
+	return draw.TextSelectionColor(draw.NewColorScheme(colorMode, nil))
 }
 
 func TextActiveCompositionColor(colorMode ebiten.ColorMode) color.Color {
-	return draw.TextActiveCompositionColor(colorMode)
+	return draw.TextActiveCompositionColor(draw.NewColorScheme(colorMode, nil))
 }
 
 func TextInactiveCompositionColor(colorMode ebiten.ColorMode) color.Color {
-	return draw.TextInactiveCompositionColor(colorMode)
+	return draw.TextInactiveCompositionColor(draw.NewColorScheme(colorMode, nil))
 }
 
 func ControlColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
-	return draw.ControlColor(colorMode, enabled)
+	return draw.ControlColor(draw.NewColorScheme(colorMode, nil), enabled)
 }
 
 func ControlSecondaryColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
-	return draw.ControlSecondaryColor(colorMode, enabled)
+	return draw.ControlSecondaryColor(draw.NewColorScheme(colorMode, nil), enabled)
 }
 
 func ThumbColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
-	return draw.ThumbColor(colorMode, enabled)
+	return draw.ThumbColor(draw.NewColorScheme(colorMode, nil), enabled)
 }
 
 func BackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return draw.BackgroundColor(colorMode)
+	return draw.BackgroundColor(draw.NewColorScheme(colorMode, nil))
 }
 
 func BackgroundSecondaryColor(colorMode ebiten.ColorMode) color.Color {
-	return draw.BackgroundSecondaryColor(colorMode)
+	return draw.BackgroundSecondaryColor(draw.NewColorScheme(colorMode, nil))
 }
 
 func PopupBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return draw.PopupBackgroundColor(colorMode)
+	return draw.PopupBackgroundColor(draw.NewColorScheme(colorMode, nil))
 }
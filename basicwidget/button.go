@@ -9,16 +9,22 @@ import (
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	buttonEventDown   guigui.EventKey = guigui.GenerateEventKey()
-	buttonEventUp     guigui.EventKey = guigui.GenerateEventKey()
-	buttonEventRepeat guigui.EventKey = guigui.GenerateEventKey()
+	// ButtonEventDown is the event dispatched when a [Button] is pressed.
+	// An ancestor can observe the event with a capturing or bubbling handler.
+	ButtonEventDown = guigui.GenerateEventKey0()
+
+	// ButtonEventUp is the event dispatched when a [Button] is released.
+	// An ancestor can observe the event with a capturing or bubbling handler.
+	ButtonEventUp = guigui.GenerateEventKey0()
+
+	buttonEventRepeat = guigui.GenerateEventKey0()
 )
 
 type Corners struct {
@@ -69,15 +75,15 @@ type Button struct {
 }
 
 func (b *Button) OnDown(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(b, buttonEventDown, f)
+	ButtonEventDown.SetHandler(b, f)
 }
 
 func (b *Button) OnUp(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(b, buttonEventUp, f)
+	ButtonEventUp.SetHandler(b, f)
 }
 
 func (b *Button) setOnRepeat(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(b, buttonEventRepeat, f)
+	buttonEventRepeat.SetHandler(b, f)
 }
 
 func (b *Button) setPairedButton(pair *Button) {
@@ -158,14 +164,13 @@ func (b *Button) Build(context *guigui.Context, adder *guigui.ChildAdder) error
 	var style TextStyle
 	switch {
 	case !context.IsEnabled(b):
-		style.SetColor(draw.TextColor(context.ColorMode(), false))
+		style.SetColor(draw.TextColor(colorScheme(context, b), false))
 	case b.typ == ButtonTypePrimary:
-		style.SetColor(draw.TextOnAccentColor(context.ColorMode()))
+		style.SetColor(draw.TextOnAccentColor(colorScheme(context, b)))
 	case b.tint != nil:
-		style.SetColor(draw.TextColorFromTint(context.ColorMode(), b.tint))
-	default:
-		style.SetColor(draw.TextColor(context.ColorMode(), true))
+		style.SetColor(draw.TextColorFromTint(colorScheme(context, b), b.tint))
 	}
+	// Otherwise, the text is drawn in the inherited color or the theme's.
 	style.SetBold(b.textBold || b.typ == ButtonTypePrimary || b.showsPressedState())
 	b.text.SetBaseStyle(&style)
 	b.text.SetHorizontalAlign(HorizontalAlignCenter)
@@ -241,7 +246,7 @@ func (b *Button) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBoun
 		if b.text.Value() != "" {
 			b.layoutItems = append(b.layoutItems,
 				guigui.LinearLayoutItem{
-					Size: guigui.FixedSize(buttonTextAndImagePadding(context)),
+					Size: guigui.FixedSize(buttonTextAndImagePadding(context, b)),
 				})
 		}
 	}
@@ -255,7 +260,7 @@ func (b *Button) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBoun
 		if b.text.Value() != "" {
 			b.layoutItems = append(b.layoutItems,
 				guigui.LinearLayoutItem{
-					Size: guigui.FixedSize(buttonTextAndImagePadding(context)),
+					Size: guigui.FixedSize(buttonTextAndImagePadding(context, b)),
 				})
 		}
 		b.layoutItems = append(b.layoutItems, iconLayoutItem)
@@ -273,24 +278,24 @@ func (b *Button) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBoun
 }
 
 func (b *Button) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
-	h := defaultButtonSize(context).Y
+	h := defaultButtonSize(context, b).Y
 	var w int
 	if b.text.Value() != "" {
 		// Measure the text as bold so that the size doesn't depend on whether the button is pressed.
-		w += buttonEdgeAndTextPadding(context)
+		w += buttonEdgeAndTextPadding(context, b)
 		w += b.text.boldTextSize(context, guigui.Constraints{}).X
 	}
 	if b.icon.HasImage() {
 		if w == 0 {
-			w += buttonEdgeAndImagePadding(context)
+			w += buttonEdgeAndImagePadding(context, b)
 		}
 		if b.text.Value() != "" {
-			w += buttonTextAndImagePadding(context)
+			w += buttonTextAndImagePadding(context, b)
 		}
 		w += defaultIconSize(context)
-		w += buttonEdgeAndImagePadding(context)
+		w += buttonEdgeAndImagePadding(context, b)
 	} else {
-		w += buttonEdgeAndTextPadding(context)
+		w += buttonEdgeAndTextPadding(context, b)
 	}
 
 	if b.content != nil {
@@ -320,35 +325,35 @@ func (b *Button) HandlePointingInput(context *guigui.Context, widgetBounds *guig
 		// IsMouseButtonJustPressed and IsMouseButtonJustReleased can be true at the same time as of Ebitengine v2.9.
 		// Check both.
 		var justPressedOrReleased bool
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 			if b.pressedByMethod && !b.toggleable {
 				return guigui.AbortHandlingInputByWidget(b)
 			}
 			context.SetFocused(b, true)
 			b.pressedByInput = true
-			guigui.DispatchEvent(b, buttonEventDown)
+			ButtonEventDown.Dispatch(b)
 			if isMouseButtonRepeating(ebiten.MouseButtonLeft) {
-				guigui.DispatchEvent(b, buttonEventRepeat)
+				buttonEventRepeat.Dispatch(b)
 			}
 			justPressedOrReleased = true
 		}
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && b.pressedByInput {
+		if inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && b.pressedByInput {
 			b.pressedByInput = false
 			if b.pressedByMethod && !b.toggleable {
 				return guigui.AbortHandlingInputByWidget(b)
 			}
-			guigui.DispatchEvent(b, buttonEventUp)
+			ButtonEventUp.Dispatch(b)
 			justPressedOrReleased = true
 		}
 		if justPressedOrReleased {
 			return guigui.HandleInputByWidget(b)
 		}
 		if (b.pressedByInput || b.pairedButton != nil && b.pairedButton.pressedByInput) && isMouseButtonRepeating(ebiten.MouseButtonLeft) {
-			guigui.DispatchEvent(b, buttonEventRepeat)
+			buttonEventRepeat.Dispatch(b)
 			return guigui.HandleInputByWidget(b)
 		}
 	}
-	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		b.pressedByInput = false
 	}
 	return guigui.HandleInputResult{}
@@ -379,18 +384,18 @@ func (b *Button) radius(context *guigui.Context, widgetBounds *guigui.WidgetBoun
 }
 
 func (b *Button) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
-	cm := context.ColorMode()
-	backgroundColor := draw.ControlColor(context.ColorMode(), context.IsEnabled(b))
+	cs := colorScheme(context, b)
+	backgroundColor := draw.ControlColor(colorScheme(context, b), context.IsEnabled(b))
 	if context.IsEnabled(b) {
 		switch {
 		case b.typ == ButtonTypePrimary:
-			backgroundColor = draw.PrimaryButtonBackgroundColor(cm, b.isPressed(context, widgetBounds), b.canPress(context, widgetBounds))
+			backgroundColor = draw.PrimaryButtonBackgroundColor(cs, b.isPressed(context, widgetBounds), b.canPress(context, widgetBounds))
 		case b.showsPressedState():
 			// Keep the hovered color while the button is being pressed, so that pressing it never lightens it.
 			hovered := b.canPress(context, widgetBounds) || b.isBeingPressed(context, widgetBounds)
-			backgroundColor = draw.PressedButtonBackgroundColor(cm, hovered)
+			backgroundColor = draw.PressedButtonBackgroundColor(cs, hovered)
 		default:
-			backgroundColor = draw.ButtonBackgroundColorFromTint(cm, b.tint, b.isPressed(context, widgetBounds), b.canPress(context, widgetBounds))
+			backgroundColor = draw.ButtonBackgroundColorFromTint(cs, b.tint, b.isPressed(context, widgetBounds), b.canPress(context, widgetBounds))
 		}
 	}
 
@@ -409,17 +414,17 @@ func (b *Button) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 		if b.isPressed(context, widgetBounds) {
 			borderType = draw.RoundedRectBorderTypeInset
 		}
-		clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderType(borderType))
+		clr1, clr2 := draw.BorderColors(colorScheme(context, b), draw.RoundedRectBorderType(borderType))
 		if context.IsEnabled(b) {
 			switch {
 			case b.typ == ButtonTypePrimary:
-				clr1, clr2 = draw.BorderAccentColors(context.ColorMode(), draw.RoundedRectBorderType(borderType))
+				clr1, clr2 = draw.BorderAccentColors(colorScheme(context, b), draw.RoundedRectBorderType(borderType))
 			case b.showsPressedState():
-				clr1, clr2 = draw.BorderAccentSecondaryColors(context.ColorMode(), draw.RoundedRectBorderType(borderType))
+				clr1, clr2 = draw.BorderAccentSecondaryColors(colorScheme(context, b), draw.RoundedRectBorderType(borderType))
 			}
 		}
 
-		borderWidth := float32(1 * context.Scale())
+		borderWidth := borderWidth(context)
 		if b.isDeeplyPressed(context, widgetBounds) {
 			borderWidth = float32(1.5 * context.Scale())
 		}
@@ -428,7 +433,7 @@ func (b *Button) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 }
 
 func (b *Button) canPress(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(b) && widgetBounds.IsHitAtCursor() && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && (!b.pressedByMethod || b.toggleable)
+	return context.IsEnabled(b) && widgetBounds.IsHitAtCursor() && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && (!b.pressedByMethod || b.toggleable)
 }
 
 // isDeeplyPressed reports whether the button should look deeper pressed than its pressed state alone.
@@ -444,25 +449,26 @@ func (b *Button) showsPressedState() bool {
 }
 
 func (b *Button) isBeingPressed(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(b) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && widgetBounds.IsHitAtCursor() && (b.pressedByInput || b.pairedButton != nil && b.pairedButton.pressedByInput)
+	return context.IsEnabled(b) && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && widgetBounds.IsHitAtCursor() && (b.pressedByInput || b.pairedButton != nil && b.pairedButton.pressedByInput)
 }
 
 func (b *Button) isPressed(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
 	return context.IsEnabled(b) && b.isBeingPressed(context, widgetBounds) || b.pressedByMethod
 }
 
-func defaultButtonSize(context *guigui.Context) image.Point {
-	return image.Pt(6*UnitSize(context), UnitSize(context))
+func defaultButtonSize(context *guigui.Context, widget guigui.Widget) image.Point {
+	u := controlUnitSize(context, widget)
+	return image.Pt(6*u, u)
 }
 
-func buttonTextAndImagePadding(context *guigui.Context) int {
-	return UnitSize(context) / 4
+func buttonTextAndImagePadding(context *guigui.Context, widget guigui.Widget) int {
+	return controlUnitSize(context, widget) / 4
 }
 
-func buttonEdgeAndTextPadding(context *guigui.Context) int {
-	return UnitSize(context) / 2
+func buttonEdgeAndTextPadding(context *guigui.Context, widget guigui.Widget) int {
+	return controlUnitSize(context, widget) / 2
 }
 
-func buttonEdgeAndImagePadding(context *guigui.Context) int {
-	return UnitSize(context) / 4
+func buttonEdgeAndImagePadding(context *guigui.Context, widget guigui.Widget) int {
+	return controlUnitSize(context, widget) / 4
 }
@@ -8,14 +8,16 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	checkboxEventValueChanged guigui.EventKey = guigui.GenerateEventKey()
+	// CheckboxEventValueChanged is the event dispatched when a [Checkbox]'s value is changed.
+	// An ancestor can observe the event with a capturing or bubbling handler.
+	CheckboxEventValueChanged = guigui.GenerateEventKey1[bool]()
 )
 
 type Checkbox struct {
@@ -29,7 +31,7 @@ type Checkbox struct {
 }
 
 func (c *Checkbox) OnValueChanged(f func(context *guigui.Context, value bool)) {
-	guigui.SetEventHandler(c, checkboxEventValueChanged, f)
+	CheckboxEventValueChanged.SetHandler(c, f)
 }
 
 func (c *Checkbox) Value() bool {
@@ -46,7 +48,7 @@ func (c *Checkbox) SetValue(value bool) {
 	}
 
 	c.value = value
-	guigui.DispatchEvent(c, checkboxEventValueChanged, value)
+	CheckboxEventValueChanged.Dispatch(c, value)
 }
 
 func (c *Checkbox) setPressed(pressed bool) {
@@ -59,18 +61,18 @@ func (c *Checkbox) setPressed(pressed bool) {
 
 func (c *Checkbox) HandlePointingInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
 	if context.IsEnabled(c) && widgetBounds.IsHitAtCursor() {
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 			c.SetValue(!c.value)
 			c.setPressed(false)
 			return guigui.HandleInputByWidget(c)
 		}
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 			context.SetFocused(c, true)
 			c.setPressed(true)
 			return guigui.HandleInputByWidget(c)
 		}
 	}
-	if !context.IsEnabled(c) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !context.IsEnabled(c) || !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		c.setPressed(false)
 	}
 	return guigui.HandleInputResult{}
@@ -92,11 +94,11 @@ func (c *Checkbox) CursorShape(context *guigui.Context, widgetBounds *guigui.Wid
 }
 
 func (c *Checkbox) canPress(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(c) && widgetBounds.IsHitAtCursor() && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return context.IsEnabled(c) && widgetBounds.IsHitAtCursor() && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft)
 }
 
 func (c *Checkbox) isActive(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(c) && widgetBounds.IsHitAtCursor() && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && c.pressed
+	return context.IsEnabled(c) && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && c.pressed
 }
 
 func (c *Checkbox) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -139,19 +141,19 @@ func (c *Checkbox) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBoun
 		Min: pt,
 		Max: pt.Add(image.Pt(LineHeight(context), LineHeight(context))),
 	}
-	cm := context.ColorMode()
+	cs := colorScheme(context, c)
 	r := UnitSize(context) / 8
 
-	backgroundColor := draw.CheckableControlBackgroundColor(cm, c.value, c.isActive(context, widgetBounds), context.IsEnabled(c))
+	backgroundColor := draw.CheckableControlBackgroundColor(cs, c.value, c.isActive(context, widgetBounds), context.IsEnabled(c))
 	draw.DrawRoundedRect(context, dst, bounds, backgroundColor, r)
 
 	// Border
-	strokeWidth := float32(1 * context.Scale())
+	strokeWidth := borderWidth(context)
 	var borderClr1, borderClr2 color.Color
 	if c.value && context.IsEnabled(c) {
-		borderClr1, borderClr2 = draw.BorderAccentSecondaryColors(cm, draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 = draw.BorderAccentSecondaryColors(cs, draw.RoundedRectBorderTypeInset)
 	} else {
-		borderClr1, borderClr2 = draw.BorderColors(cm, draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 = draw.BorderColors(cs, draw.RoundedRectBorderTypeInset)
 	}
 	draw.DrawRoundedRectBorder(context, dst, bounds, borderClr1, borderClr2, r, strokeWidth, draw.RoundedRectBorderTypeInset)
 }
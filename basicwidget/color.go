@@ -13,35 +13,39 @@ import (
 // A tint is the color a widget derives the colors it draws from, adjusting
 // the lightness for the color mode and for states such as hovering and
 // pressing. Any color works as a tint; the functions below return the tints
-// of the theme.
+// of the theme set by [SetTheme].
 
 // AccentTintColor returns the tint marking the primary action of the theme.
 func AccentTintColor() color.Color {
-	return draw.AccentTintColor()
+	return appTintColor(draw.TintAccent)
 }
 
 // InfoTintColor returns the tint marking neutral information.
 func InfoTintColor() color.Color {
-	return draw.InfoTintColor()
+	return appTintColor(draw.TintInfo)
 }
 
 // SuccessTintColor returns the tint marking a successful result.
 func SuccessTintColor() color.Color {
-	return draw.SuccessTintColor()
+	return appTintColor(draw.TintSuccess)
 }
 
 // WarningTintColor returns the tint marking a condition that needs attention.
 func WarningTintColor() color.Color {
-	return draw.WarningTintColor()
+	return appTintColor(draw.TintWarning)
 }
 
 // DangerTintColor returns the tint marking a destructive action or an error.
 func DangerTintColor() color.Color {
-	return draw.DangerTintColor()
+	return appTintColor(draw.TintDanger)
 }
 
 // TextColorFromTint returns the color to draw text tinted with tint, for use
 // with [TextStyle.SetColor]. A nil tint returns the color for enabled text.
 func TextColorFromTint(context *guigui.Context, tint color.Color) color.Color {
-	return draw.TextColorFromTint(context.ColorMode(), tint)
+	return draw.TextColorFromTint(appColorScheme(context), tint)
+}
+
+func appTintColor(tint draw.Tint) color.Color {
+	return draw.AppTheme().TintColor(tint)
 }
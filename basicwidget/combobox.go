@@ -12,7 +12,7 @@ import (
 )
 
 var (
-	comboboxEventValueChanged guigui.EventKey = guigui.GenerateEventKey()
+	comboboxEventValueChanged = guigui.GenerateEventKey2[string, bool]()
 )
 
 // Combobox is a composite widget that combines a [TextInput] with a [PopupMenu].
@@ -106,7 +106,7 @@ func (c *Combobox) SetValue(value string) {
 // OnValueChanged sets the event handler that is called when the combobox value changes.
 // The handler receives the current text and whether the change is committed.
 func (c *Combobox) OnValueChanged(f func(context *guigui.Context, value string, committed bool)) {
-	guigui.SetEventHandler(c, comboboxEventValueChanged, f)
+	comboboxEventValueChanged.SetHandler(c, f)
 }
 
 func (c *Combobox) updateFilteredItems() {
@@ -146,25 +146,25 @@ func (c *Combobox) highlightClosestCandidate(input string) {
 func (c *Combobox) handleCommit(context *guigui.Context, text string) {
 	if c.allowFreeInput {
 		c.lastValidValue = text
-		guigui.DispatchEvent(c, comboboxEventValueChanged, text, true)
+		comboboxEventValueChanged.Dispatch(c, text, true)
 		return
 	}
 
 	// Accept empty text or an exact item match.
 	if text == "" {
 		c.lastValidValue = text
-		guigui.DispatchEvent(c, comboboxEventValueChanged, text, true)
+		comboboxEventValueChanged.Dispatch(c, text, true)
 		return
 	}
 	if slices.Contains(c.items, text) {
 		c.lastValidValue = text
-		guigui.DispatchEvent(c, comboboxEventValueChanged, text, true)
+		comboboxEventValueChanged.Dispatch(c, text, true)
 		return
 	}
 
 	// Revert to the last valid value.
 	c.textInput.ForceSetValue(c.lastValidValue)
-	guigui.DispatchEvent(c, comboboxEventValueChanged, c.lastValidValue, true)
+	comboboxEventValueChanged.Dispatch(c, c.lastValidValue, true)
 }
 
 func (c *Combobox) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -194,7 +194,7 @@ func (c *Combobox) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 			} else if !c.popupMenu.IsOpen() && context.IsFocusedOrHasFocusedDescendant(&c.textInput) {
 				c.popupMenu.SetOpen(true)
 			}
-			guigui.DispatchEvent(c, comboboxEventValueChanged, text, false)
+			comboboxEventValueChanged.Dispatch(c, text, false)
 		}
 	}
 	c.textInput.OnValueChanged(c.onTextInputValueChanged)
@@ -205,7 +205,7 @@ func (c *Combobox) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 				c.textInput.ForceSetValue(item.Text)
 				c.textInput.setSelection(len(item.Text), len(item.Text))
 				c.lastValidValue = item.Text
-				guigui.DispatchEvent(c, comboboxEventValueChanged, item.Text, true)
+				comboboxEventValueChanged.Dispatch(c, item.Text, true)
 			}
 		}
 	}
@@ -7,9 +7,9 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // ContextMenuArea is a standalone widget that shows a popup menu when the user
@@ -62,9 +62,9 @@ func (c *ContextMenuArea[T]) Measure(context *guigui.Context, constraints guigui
 
 // HandlePointingInput implements [guigui.Widget.HandlePointingInput].
 func (c *ContextMenuArea[T]) HandlePointingInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+	if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 		if widgetBounds.IsHitAtCursor() {
-			c.menuPosition = image.Pt(ebiten.CursorPosition())
+			c.menuPosition = image.Pt(inputsource.CursorPosition())
 			c.popupMenu.SetOpen(true)
 			return guigui.HandleInputByWidget(c)
 		}
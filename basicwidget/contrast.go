@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget
+
+import (
+	"cmp"
+	"fmt"
+	"image"
+	"image/color"
+	"log/slog"
+	"math"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+const (
+	// minContrastRatio is the minimum contrast ratio of normal text by WCAG 2 success criterion 1.4.3 (AA).
+	minContrastRatio = 4.5
+
+	// minContrastRatioLarge is the minimum contrast ratio of large text by WCAG 2 success criterion 1.4.3 (AA).
+	minContrastRatioLarge = 3
+)
+
+// ContrastRatio returns the WCAG 2 contrast ratio of the foreground color over the background color,
+// in the range [1, 21]. WCAG requires 4.5 for normal text and 3 for large text.
+func ContrastRatio(foreground, background color.Color) float64 {
+	return draw.ContrastRatio(foreground, background)
+}
+
+// HighContrastTheme returns a new theme that strengthens the contrast of text, borders and focus rings
+// in both the light and the dark color modes.
+// Apply it with [SetTheme]. The returned theme can be adjusted before it is used.
+func HighContrastTheme() *Theme {
+	neutral := func(light, dark float64) ThemeColor {
+		return ThemeColor{Tint: "neutral", Light: light, Dark: dark}
+	}
+	accent := func(light, dark float64) ThemeColor {
+		return ThemeColor{Tint: "accent", Light: light, Dark: dark}
+	}
+	return &Theme{
+		Colors: map[string]ThemeColor{
+			"text":           neutral(0, 1),
+			"text-disabled":  neutral(0.35, 0.7),
+			"text-on-accent": neutral(1, 0),
+			"text-selection": accent(0.75, 0.35),
+			"text-caret":     accent(0.3, 0.8),
+
+			"background":           neutral(1, 0),
+			"background-secondary": neutral(1, 0),
+			"content-background":   neutral(1, 0),
+			"control":              neutral(1, 0),
+			"menu-background":      neutral(1, 0),
+			"popup-background":     neutral(1, 0),
+			"control-secondary":    neutral(0.9, 0.15),
+
+			"border":          neutral(0.2, 0.85),
+			"border-inset-1":  neutral(0.2, 0.85),
+			"border-inset-2":  neutral(0.2, 0.85),
+			"border-outset-1": neutral(0.2, 0.85),
+			"border-outset-2": neutral(0.2, 0.85),
+
+			"border-accent":          accent(0.3, 0.75),
+			"border-accent-inset-1":  accent(0.3, 0.75),
+			"border-accent-inset-2":  accent(0.3, 0.75),
+			"border-accent-outset-1": accent(0.3, 0.75),
+			"border-accent-outset-2": accent(0.3, 0.75),
+
+			"border-accent-secondary":          accent(0.3, 0.75),
+			"border-accent-secondary-inset-1":  accent(0.3, 0.75),
+			"border-accent-secondary-inset-2":  accent(0.3, 0.75),
+			"border-accent-secondary-outset-1": accent(0.3, 0.75),
+			"border-accent-secondary-outset-2": accent(0.3, 0.75),
+
+			"focus-border":   accent(0.3, 0.8),
+			"accent":         accent(0.35, 0.75),
+			"accent-hovered": accent(0.3, 0.8),
+			"accent-pressed": accent(0.25, 0.85),
+
+			"item-highlighted-text":       neutral(1, 0),
+			"item-highlighted-background": accent(0.35, 0.75),
+
+			"divider":          neutral(0.3, 0.75),
+			"header-separator": neutral(0.3, 0.75),
+			"slider-tick":      neutral(0.3, 0.75),
+			"track-off":        neutral(0.6, 0.4),
+		},
+		BorderWidth: 2,
+	}
+}
+
+// checkTextContrast logs the text if its color does not contrast enough with the background
+// already drawn on dst. checkTextContrast is for debugging, as reading pixels is slow.
+func (t *Text) checkTextContrast(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	// WCAG exempts inactive user interface components.
+	if !context.IsEnabled(t) || !t.HasValue() {
+		return
+	}
+	vb := widgetBounds.VisibleBounds().Intersect(dst.Bounds())
+	if vb.Empty() {
+		return
+	}
+
+	fg := t.resolveTextColor(context)
+	bg := sampleBackground(dst, vb)
+	ratio := ContrastRatio(fg, bg)
+
+	required := minContrastRatio
+	fontSize, _ := t.resolveFontSize(context)
+	// Large text is 18pt, which is 24 CSS pixels.
+	if fontSize >= 24*context.Scale() {
+		required = minContrastRatioLarge
+	}
+	if ratio >= required {
+		t.reportedContrastRatio = 0
+		return
+	}
+	// Report once until the ratio changes, as the text is drawn every time its region is redrawn.
+	if math.Abs(t.reportedContrastRatio-ratio) < 0.01 {
+		return
+	}
+	t.reportedContrastRatio = ratio
+
+	slog.Warn("insufficient text contrast",
+		"ratio", math.Round(ratio*100)/100,
+		"required", required,
+		"foreground", hexColor(fg),
+		"background", hexColor(bg),
+		"text", truncateForLog(t.Value(), 32),
+		"bounds", widgetBounds.Bounds())
+}
+
+// truncateForLog returns s truncated to at most maxBytes bytes with an ellipsis.
+// s is truncated on a rune boundary, so that a multi-byte character is not split.
+func truncateForLog(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	n := maxBytes
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+const (
+	backgroundSampleColumns = 5
+	backgroundSampleRows    = 3
+)
+
+// sampleBackground returns the background color of img in bounds.
+//
+// A single pixel might be on a border or a gradient, so a grid of points inside bounds is sampled,
+// and the most frequent color is taken. If every sampled color differs, the color of the median
+// luminance is taken.
+func sampleBackground(img image.Image, bounds image.Rectangle) color.Color {
+	var samples [backgroundSampleColumns * backgroundSampleRows]color.RGBA
+	for j := range backgroundSampleRows {
+		y := bounds.Min.Y + (2*j+1)*bounds.Dy()/(2*backgroundSampleRows)
+		for i := range backgroundSampleColumns {
+			x := bounds.Min.X + (2*i+1)*bounds.Dx()/(2*backgroundSampleColumns)
+			samples[j*backgroundSampleColumns+i] = color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+		}
+	}
+
+	var mode color.RGBA
+	var modeCount int
+	for i, c := range samples {
+		var n int
+		for _, c2 := range samples[i:] {
+			if c2 == c {
+				n++
+			}
+		}
+		if n > modeCount {
+			mode = c
+			modeCount = n
+		}
+	}
+	if modeCount > 1 {
+		return mode
+	}
+
+	slices.SortFunc(samples[:], func(a, b color.RGBA) int {
+		return cmp.Compare(draw.RelativeLuminance(a), draw.RelativeLuminance(b))
+	})
+	return samples[len(samples)/2]
+}
+
+func hexColor(clr color.Color) string {
+	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestContrastRatio(t *testing.T) {
+	testCases := []struct {
+		fg   color.Color
+		bg   color.Color
+		want float64
+	}{
+		{fg: color.Black, bg: color.White, want: 21},
+		{fg: color.White, bg: color.Black, want: 21},
+		{fg: color.White, bg: color.White, want: 1},
+		{fg: color.NRGBA{0x76, 0x76, 0x76, 0xff}, bg: color.White, want: 4.54},
+		// A transparent foreground is invisible.
+		{fg: color.Transparent, bg: color.White, want: 1},
+	}
+	for _, tc := range testCases {
+		if got := basicwidget.ContrastRatio(tc.fg, tc.bg); math.Abs(got-tc.want) > 0.01 {
+			t.Errorf("ContrastRatio(%v, %v): got: %v, want: %v", tc.fg, tc.bg, got, tc.want)
+		}
+	}
+}
+
+func TestHighContrastTheme(t *testing.T) {
+	theme := basicwidget.HighContrastTheme()
+	for name := range theme.Colors {
+		if _, ok := basicwidget.DefaultThemeColor(name); !ok {
+			t.Errorf("HighContrastTheme has an unknown color token %q", name)
+		}
+	}
+	if theme.BorderWidth <= 1 {
+		t.Errorf("HighContrastTheme().BorderWidth: got: %v, want: > 1", theme.BorderWidth)
+	}
+}
+
+func TestTruncateForLog(t *testing.T) {
+	testCases := []struct {
+		s        string
+		maxBytes int
+		want     string
+	}{
+		{s: "hello", maxBytes: 5, want: "hello"},
+		{s: "hello, world", maxBytes: 5, want: "hello..."},
+		// "שלום" is 8 bytes. A two-byte character is not split.
+		{s: "שלום", maxBytes: 5, want: "של..."},
+		// "日本語" is 9 bytes. A three-byte character is not split.
+		{s: "日本語", maxBytes: 4, want: "日..."},
+		{s: "日本語", maxBytes: 2, want: "..."},
+	}
+	for _, tc := range testCases {
+		got := basicwidget.TruncateForLog(tc.s, tc.maxBytes)
+		if got != tc.want {
+			t.Errorf("TruncateForLog(%q, %d): got: %q, want: %q", tc.s, tc.maxBytes, got, tc.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("TruncateForLog(%q, %d): got an invalid UTF-8 string %q", tc.s, tc.maxBytes, got)
+		}
+	}
+}
+
+func TestSampleBackground(t *testing.T) {
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gray := color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	black := color.RGBA{A: 0xff}
+
+	// A border at the edges does not affect the sample.
+	bordered := image.NewRGBA(image.Rect(0, 0, 100, 30))
+	for j := range 30 {
+		for i := range 100 {
+			c := white
+			if i == 0 || j == 0 || i == 99 || j == 29 {
+				c = black
+			}
+			bordered.SetRGBA(i, j, c)
+		}
+	}
+	if got := basicwidget.SampleBackground(bordered, bordered.Bounds()); got != white {
+		t.Errorf("SampleBackground of a bordered image: got: %v, want: %v", got, white)
+	}
+
+	// The most frequent color wins.
+	split := image.NewRGBA(image.Rect(0, 0, 100, 30))
+	for j := range 30 {
+		for i := range 100 {
+			c := gray
+			if i >= 70 {
+				c = black
+			}
+			split.SetRGBA(i, j, c)
+		}
+	}
+	if got := basicwidget.SampleBackground(split, split.Bounds()); got != gray {
+		t.Errorf("SampleBackground of a split image: got: %v, want: %v", got, gray)
+	}
+
+	// In a gradient, the median luminance wins.
+	gradient := image.NewRGBA(image.Rect(0, 0, 150, 30))
+	for j := range 30 {
+		for i := range 150 {
+			v := uint8(i + j)
+			gradient.SetRGBA(i, j, color.RGBA{R: v, G: v, B: v, A: 0xff})
+		}
+	}
+	if got, want := basicwidget.SampleBackground(gradient, gradient.Bounds()), (color.RGBA{R: 90, G: 90, B: 90, A: 0xff}); got != want {
+		t.Errorf("SampleBackground of a gradient: got: %v, want: %v", got, want)
+	}
+
+	// The sample is taken inside the bounds.
+	if got := basicwidget.SampleBackground(split, image.Rect(70, 0, 100, 30)); got != black {
+		t.Errorf("SampleBackground in a sub-rectangle: got: %v, want: %v", got, black)
+	}
+}
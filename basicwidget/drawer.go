@@ -10,15 +10,45 @@ import (
 	"github.com/guigui-gui/guigui"
 )
 
+// DrawerEdge is the edge of the screen that a [Drawer] slides in from.
 type DrawerEdge int
 
 const (
+	// DrawerEdgeStart is the left edge, or the right edge in a right-to-left reading direction.
 	DrawerEdgeStart DrawerEdge = iota
+
+	// DrawerEdgeTop is the top edge.
 	DrawerEdgeTop
+
+	// DrawerEdgeEnd is the right edge, or the left edge in a right-to-left reading direction.
 	DrawerEdgeEnd
+
+	// DrawerEdgeBottom is the bottom edge.
 	DrawerEdgeBottom
 )
 
+// isLeft reports whether the edge is the left edge in the widget's reading direction.
+func (e DrawerEdge) isLeft(context *guigui.Context, widget guigui.Widget) bool {
+	switch e {
+	case DrawerEdgeStart:
+		return !context.IsRightToLeft(widget)
+	case DrawerEdgeEnd:
+		return context.IsRightToLeft(widget)
+	}
+	return false
+}
+
+// isRight reports whether the edge is the right edge in the widget's reading direction.
+func (e DrawerEdge) isRight(context *guigui.Context, widget guigui.Widget) bool {
+	switch e {
+	case DrawerEdgeStart:
+		return context.IsRightToLeft(widget)
+	case DrawerEdgeEnd:
+		return !context.IsRightToLeft(widget)
+	}
+	return false
+}
+
 type Drawer struct {
 	guigui.DefaultWidget
 
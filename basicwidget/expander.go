@@ -8,13 +8,14 @@ import (
 	"slices"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 var (
-	expanderEventExpansionChanged guigui.EventKey = guigui.GenerateEventKey()
-	expanderHeaderEventDown       guigui.EventKey = guigui.GenerateEventKey()
+	expanderEventExpansionChanged = guigui.GenerateEventKey1[bool]()
+	expanderHeaderEventDown       = guigui.GenerateEventKey0()
 )
 
 type Expander struct {
@@ -33,7 +34,7 @@ type Expander struct {
 }
 
 func (e *Expander) OnExpansionChanged(callback func(context *guigui.Context, expanded bool)) {
-	guigui.SetEventHandler(e, expanderEventExpansionChanged, callback)
+	expanderEventExpansionChanged.SetHandler(e, callback)
 }
 
 func (e *Expander) SetHeaderWidget(w guigui.Widget) {
@@ -47,6 +48,10 @@ func (e *Expander) SetContentWidget(w guigui.Widget) {
 // expandCollapseMaxCount returns the number of ticks for an expand/collapse animation.
 // This is shared between Expander and List.
 func expandCollapseMaxCount() int {
+	// Finish the animation in one tick when the desktop asks to reduce motion.
+	if desktopsettings.Get().AnimationsDisabled {
+		return 1
+	}
 	return ebiten.TPS() / 20
 }
 
@@ -66,7 +71,7 @@ func (e *Expander) SetExpanded(expanded bool) {
 	if e.onceDraw {
 		e.count = expandCollapseMaxCount() - e.count
 	}
-	guigui.DispatchEvent(e, expanderEventExpansionChanged, e.expanded)
+	expanderEventExpansionChanged.Dispatch(e, e.expanded)
 }
 
 func (e *Expander) isContentVisible() bool {
@@ -172,7 +177,7 @@ type expanderHeader struct {
 }
 
 func (e *expanderHeader) setOnDown(callback func(context *guigui.Context)) {
-	guigui.SetEventHandler(e, expanderHeaderEventDown, callback)
+	expanderHeaderEventDown.SetHandler(e, callback)
 }
 
 func (e *expanderHeader) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
@@ -260,8 +265,8 @@ func (e *expanderHeader) Measure(context *guigui.Context, constraints guigui.Con
 
 func (e *expanderHeader) HandlePointingInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
 	if widgetBounds.IsHitAtCursor() {
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			guigui.DispatchEvent(e, expanderHeaderEventDown)
+		if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			expanderHeaderEventDown.Dispatch(e)
 			return guigui.HandleInputByWidget(e)
 		}
 	}
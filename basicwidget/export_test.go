@@ -3,7 +3,12 @@
 
 package basicwidget
 
-import "image"
+import (
+	"image"
+	"image/color"
+
+	"github.com/guigui-gui/guigui"
+)
 
 func TopItemAfterPixelScroll(measure func(index int) int, totalCount, startIndex, startOffset, deltaPx int) (int, int) {
 	return topItemAfterPixelScroll(measure, totalCount, startIndex, startOffset, deltaPx)
@@ -109,3 +114,30 @@ func (a AbstractListTestItem[T]) selectable() bool {
 func (a AbstractListTestItem[T]) visible() bool {
 	return a.Visible
 }
+
+var (
+	EnvKeyFontFamily = envKeyFontFamily
+	EnvKeyFontSize   = envKeyFontSize
+	EnvKeyTextColor  = envKeyTextColor
+	EnvKeyDensity    = envKeyDensity
+)
+
+// WriteListItemStateKey writes the state of item into w as a list does.
+func WriteListItemStateKey[T comparable](w *guigui.StateKeyWriter, item *ListItem[T]) {
+	item.writeStateKey(w)
+}
+
+// ListItemEquals reports whether the items are equal as a list compares them.
+func ListItemEquals[T comparable](item1, item2 *ListItem[T]) bool {
+	return item1.equals(item2)
+}
+
+// SampleBackground returns the background color of img in bounds, as the contrast check does.
+func SampleBackground(img image.Image, bounds image.Rectangle) color.Color {
+	return sampleBackground(img, bounds)
+}
+
+// TruncateForLog returns s truncated as the contrast check does for the log.
+func TruncateForLog(s string, maxBytes int) string {
+	return truncateForLog(s, maxBytes)
+}
@@ -149,8 +149,8 @@ func (f *Form) appendItemBounds(context *guigui.Context, itemBounds []image.Rect
 }
 
 func (f *Form) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
-	bgClr := draw.OverlayBackgroundColor(context.ColorMode())
-	borderClr := draw.OverlayBorderColor(context.ColorMode())
+	bgClr := draw.OverlayBackgroundColor(colorScheme(context, f))
+	borderClr := draw.OverlayBorderColor(colorScheme(context, f))
 
 	bounds := widgetBounds.Bounds()
 	draw.DrawRoundedRect(context, dst, bounds, bgClr, RoundedCornerRadius(context))
@@ -167,7 +167,7 @@ func (f *Form) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds,
 		}
 	}
 
-	draw.DrawRoundedRectBorder(context, dst, bounds, borderClr, borderClr, RoundedCornerRadius(context), 1*float32(context.Scale()), draw.RoundedRectBorderTypeRegular)
+	draw.DrawRoundedRectBorder(context, dst, bounds, borderClr, borderClr, RoundedCornerRadius(context), borderWidth(context), draw.RoundedRectBorderTypeRegular)
 }
 
 func (f *Form) measureWithoutConstraints(context *guigui.Context) image.Point {
@@ -36,39 +36,6 @@ var (
 	gray  = iro.ColorFromOklch(0.6, 0, 0, 1)
 )
 
-var (
-	accentTintColor  = blue.SRGBColor()
-	infoTintColor    = blue.SRGBColor()
-	successTintColor = green.SRGBColor()
-	warningTintColor = orange.SRGBColor()
-	dangerTintColor  = red.SRGBColor()
-)
-
-// AccentTintColor returns the tint marking the primary action of the theme.
-func AccentTintColor() color.Color {
-	return accentTintColor
-}
-
-// InfoTintColor returns the tint marking neutral information.
-func InfoTintColor() color.Color {
-	return infoTintColor
-}
-
-// SuccessTintColor returns the tint marking a successful result.
-func SuccessTintColor() color.Color {
-	return successTintColor
-}
-
-// WarningTintColor returns the tint marking a condition that needs attention.
-func WarningTintColor() color.Color {
-	return warningTintColor
-}
-
-// DangerTintColor returns the tint marking a destructive action or an error.
-func DangerTintColor() color.Color {
-	return dangerTintColor
-}
-
 // tintColor derives a color from tint at the given lightness for each color
 // mode. lightnessInLightMode and lightnessInDarkMode are in the range [0, 1].
 func tintColor(colorMode ebiten.ColorMode, tint iro.Color, lightnessInLightMode, lightnessInDarkMode float64) color.Color {
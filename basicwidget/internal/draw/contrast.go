@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package draw
+
+import (
+	"image/color"
+	"math"
+)
+
+// relativeLuminance returns the relative luminance of an opaque color as defined by WCAG 2.
+func relativeLuminance(r, g, b float64) float64 {
+	linear := func(c float64) float64 {
+		if c <= 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
+
+// RelativeLuminance returns the relative luminance of the color as defined by WCAG 2, in the range [0, 1].
+// A translucent color is regarded as composited over black.
+func RelativeLuminance(clr color.Color) float64 {
+	r, g, b, _ := clr.RGBA()
+	const m = 0xffff
+	return relativeLuminance(float64(r)/m, float64(g)/m, float64(b)/m)
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio of the foreground color over the background color,
+// in the range [1, 21].
+// A translucent foreground is composited over the background, and a translucent background over black.
+func ContrastRatio(foreground, background color.Color) float64 {
+	// The components of color.Color are premultiplied by alpha.
+	fr, fg, fb, fa := foreground.RGBA()
+	br, bg, bb, _ := background.RGBA()
+	const m = 0xffff
+	blend := func(f, b uint32) float64 {
+		return (float64(f) + float64(b)*float64(m-fa)/m) / m
+	}
+	l0 := relativeLuminance(blend(fr, br), blend(fg, bg), blend(fb, bb))
+	l1 := relativeLuminance(float64(br)/m, float64(bg)/m, float64(bb)/m)
+	if l0 < l1 {
+		l0, l1 = l1, l0
+	}
+	return (l0 + 0.05) / (l1 + 0.05)
+}
@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package draw
+
+import (
+	"fmt"
+	"image/color"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/iro"
+
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+)
+
+// Tint is a tint of a theme, which color tokens derive from.
+type Tint int
+
+const (
+	TintNeutral Tint = iota
+	TintAccent
+	TintInfo
+	TintSuccess
+	TintWarning
+	TintDanger
+
+	tintCount
+)
+
+// TintNames are the names of the tints, indexed by Tint.
+var TintNames = [tintCount]string{
+	TintNeutral: "neutral",
+	TintAccent:  "accent",
+	TintInfo:    "info",
+	TintSuccess: "success",
+	TintWarning: "warning",
+	TintDanger:  "danger",
+}
+
+// defaultTints are the tints of the default theme.
+var defaultTints = [tintCount]iro.Color{
+	TintNeutral: gray,
+	TintAccent:  blue,
+	TintInfo:    blue,
+	TintSuccess: green,
+	TintWarning: orange,
+	TintDanger:  red,
+}
+
+// DesktopTints returns the tints that the desktop settings override, indexed by Tint, where a nil color
+// means no override. DesktopTints returns nil if the desktop overrides no tint.
+//
+// The desktop's accent color overrides the accent tint.
+func DesktopTints() []color.Color {
+	clr := desktopsettings.Get().AccentColor
+	if clr == nil {
+		return nil
+	}
+	tints := make([]color.Color, tintCount)
+	tints[TintAccent] = clr
+	return tints
+}
+
+// tokenID identifies a color token.
+type tokenID int
+
+// TokenSpec specifies a color token as a tint and a lightness for each color mode.
+type TokenSpec struct {
+	// Tint is the tint of the theme the token derives from.
+	Tint Tint
+
+	// Color is the color the token derives from instead of Tint, if not nil.
+	Color color.Color
+
+	// Light is the lightness in the light color mode, in the range [0, 1].
+	Light float64
+
+	// Dark is the lightness in the dark color mode, in the range [0, 1].
+	Dark float64
+}
+
+var (
+	tokenNames        []string
+	defaultTokenSpecs []TokenSpec
+)
+
+// defineToken defines a color token with the name and the default specification.
+func defineToken(name string, tint Tint, light, dark float64) tokenID {
+	tokenNames = append(tokenNames, name)
+	defaultTokenSpecs = append(defaultTokenSpecs, TokenSpec{
+		Tint:  tint,
+		Light: light,
+		Dark:  dark,
+	})
+	return tokenID(len(tokenNames) - 1)
+}
+
+// TokenNames returns the names of all the color tokens.
+func TokenNames() []string {
+	return tokenNames
+}
+
+// DefaultTokenSpec returns the default specification of the named color token.
+func DefaultTokenSpec(name string) (TokenSpec, bool) {
+	for i, n := range tokenNames {
+		if n == name {
+			return defaultTokenSpecs[i], true
+		}
+	}
+	return TokenSpec{}, false
+}
+
+// colorToken is a resolved color token.
+type colorToken struct {
+	// tint is the color the token is derived from.
+	tint iro.Color
+
+	// light is the lightness in the light color mode, in the range [0, 1].
+	light float64
+
+	// dark is the lightness in the dark color mode, in the range [0, 1].
+	dark float64
+}
+
+// Theme is a set of tints and color tokens.
+type Theme struct {
+	tints      [tintCount]iro.Color
+	tintColors [tintCount]color.Color
+	tokens     []colorToken
+}
+
+var defaultTheme = sync.OnceValue(func() *Theme {
+	t, err := NewTheme(nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	return t
+})
+
+// desktopTheme is resolved lazily rather than at init, as reading the desktop settings might launch processes.
+var desktopTheme = sync.OnceValue(func() *Theme {
+	t, err := NewTheme(DesktopTints(), nil)
+	if err != nil {
+		panic(err)
+	}
+	return t
+})
+
+// appTheme is the theme of the application. nil means the desktop theme.
+var appTheme *Theme
+
+// DefaultTheme returns the default theme. The default theme does not depend on the desktop settings.
+func DefaultTheme() *Theme {
+	return defaultTheme()
+}
+
+// DesktopTheme returns the default theme with the tints that the desktop settings override.
+// See [DesktopTints].
+func DesktopTheme() *Theme {
+	return desktopTheme()
+}
+
+// AppTheme returns the theme of the application.
+func AppTheme() *Theme {
+	if appTheme == nil {
+		return desktopTheme()
+	}
+	return appTheme
+}
+
+// SetAppTheme sets the theme of the application.
+func SetAppTheme(theme *Theme) {
+	appTheme = theme
+}
+
+// NewTheme creates a theme from the default one.
+// tints overrides the tints by Tint, where a nil color means the default tint.
+// tokens overrides the color tokens by name.
+func NewTheme(tints []color.Color, tokens map[string]TokenSpec) (*Theme, error) {
+	t := &Theme{
+		tints:  defaultTints(),
+		tokens: make([]colorToken, len(tokenNames)),
+	}
+	for i, c := range tints {
+		if i >= int(tintCount) {
+			return nil, fmt.Errorf("draw: too many tints: %d", len(tints))
+		}
+		if c != nil {
+			t.tints[i] = iro.ColorFromSRGBColor(c)
+		}
+	}
+	for i := range t.tints {
+		t.tintColors[i] = t.tints[i].SRGBColor()
+	}
+
+	for name := range tokens {
+		if _, ok := DefaultTokenSpec(name); !ok {
+			return nil, fmt.Errorf("draw: unknown color token: %q", name)
+		}
+	}
+	for i, name := range tokenNames {
+		spec := defaultTokenSpecs[i]
+		if s, ok := tokens[name]; ok {
+			spec = s
+		}
+		if spec.Tint < 0 || spec.Tint >= tintCount {
+			return nil, fmt.Errorf("draw: invalid tint for %q: %d", name, spec.Tint)
+		}
+		tint := t.tints[spec.Tint]
+		if spec.Color != nil {
+			tint = iro.ColorFromSRGBColor(spec.Color)
+		}
+		t.tokens[i] = colorToken{
+			tint:  tint,
+			light: spec.Light,
+			dark:  spec.Dark,
+		}
+	}
+	return t, nil
+}
+
+// TintColor returns the color of the tint.
+func (t *Theme) TintColor(tint Tint) color.Color {
+	return t.tintColors[tint]
+}
+
+// ColorScheme is a theme in a color mode, which determines the colors to draw widgets with.
+type ColorScheme struct {
+	colorMode ebiten.ColorMode
+	theme     *Theme
+}
+
+// NewColorScheme returns a ColorScheme of the theme in the color mode.
+// If theme is nil, the theme of the application is used.
+func NewColorScheme(colorMode ebiten.ColorMode, theme *Theme) ColorScheme {
+	if theme == nil {
+		theme = AppTheme()
+	}
+	return ColorScheme{
+		colorMode: colorMode,
+		theme:     theme,
+	}
+}
+
+// ColorMode returns the color mode of the scheme.
+func (s ColorScheme) ColorMode() ebiten.ColorMode {
+	return s.colorMode
+}
+
+// TintColor returns the color of the tint of the scheme's theme.
+func (s ColorScheme) TintColor(tint Tint) color.Color {
+	return s.theme.TintColor(tint)
+}
+
+func (s ColorScheme) color(id tokenID) color.Color {
+	t := &s.theme.tokens[id]
+	return tintColor(s.colorMode, t.tint, t.light, t.dark)
+}
+
+// tintedColor returns a color deriving from tint, or from the neutral tint when tint is nil.
+func (s ColorScheme) tintedColor(tint color.Color, light, dark float64) color.Color {
+	if tint == nil {
+		return tintColor(s.colorMode, s.theme.tints[TintNeutral], light, dark)
+	}
+	return tintColor(s.colorMode, iro.ColorFromSRGBColor(tint), light, dark)
+}
+
+// borderColorTokens is a set of color tokens for a rounded-rect border, one pair per border type.
+type borderColorTokens struct {
+	// regular is the color for both edges of a regular border.
+	regular tokenID
+
+	// inset1 is the upper-edge color of an inset border.
+	inset1 tokenID
+
+	// inset2 is the lower-edge color of an inset border.
+	inset2 tokenID
+
+	// outset1 is the upper-edge color of an outset border.
+	outset1 tokenID
+
+	// outset2 is the lower-edge color of an outset border.
+	outset2 tokenID
+}
+
+func (s ColorScheme) borderColors(b borderColorTokens, borderType RoundedRectBorderType) (color.Color, color.Color) {
+	switch borderType {
+	case RoundedRectBorderTypeRegular:
+		c := s.color(b.regular)
+		return c, c
+	case RoundedRectBorderTypeInset:
+		return s.color(b.inset1), s.color(b.inset2)
+	case RoundedRectBorderTypeOutset:
+		return s.color(b.outset1), s.color(b.outset2)
+	default:
+		panic(fmt.Sprintf("draw: invalid border type: %d", borderType))
+	}
+}
@@ -4,681 +4,367 @@
 package draw
 
 import (
-	"fmt"
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/iro"
 )
 
-// colorToken represents a themable color as a tint and a lightness for each color mode.
-type colorToken struct {
-	// tint is the color the token is derived from.
-	tint iro.Color
-
-	// light is the lightness in the light color mode, in the range [0, 1].
-	light float64
-
-	// dark is the lightness in the dark color mode, in the range [0, 1].
-	dark float64
-}
-
-func (c colorToken) color(colorMode ebiten.ColorMode) color.Color {
-	return tintColor(colorMode, c.tint, c.light, c.dark)
-}
-
-// tokenFromTint returns a token deriving from tint, or fallback when tint is nil.
-func tokenFromTint(tint color.Color, light, dark float64) colorToken {
-	if tint == nil {
-		return colorToken{
-			tint:  gray,
-			light: light,
-			dark:  dark,
-		}
-	}
-	return colorToken{
-		tint:  iro.ColorFromSRGBColor(tint),
-		light: light,
-		dark:  dark,
-	}
-}
-
-// borderColorTokens is a set of color tokens for a rounded-rect border, one pair per border type.
-type borderColorTokens struct {
-	// regular is the color for both edges of a regular border.
-	regular colorToken
-
-	// inset1 is the upper-edge color of an inset border.
-	inset1 colorToken
-
-	// inset2 is the lower-edge color of an inset border.
-	inset2 colorToken
-
-	// outset1 is the upper-edge color of an outset border.
-	outset1 colorToken
-
-	// outset2 is the lower-edge color of an outset border.
-	outset2 colorToken
-}
-
-func (b borderColorTokens) colors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	switch borderType {
-	case RoundedRectBorderTypeRegular:
-		c := b.regular.color(colorMode)
-		return c, c
-	case RoundedRectBorderTypeInset:
-		return b.inset1.color(colorMode), b.inset2.color(colorMode)
-	case RoundedRectBorderTypeOutset:
-		return b.outset1.color(colorMode), b.outset2.color(colorMode)
-	default:
-		panic(fmt.Sprintf("draw: invalid border type: %d", borderType))
-	}
-}
-
 var (
 	borderTokens = borderColorTokens{
-		regular: colorToken{
-			tint:  gray,
-			light: 0.8,
-			dark:  0.1,
-		},
-		inset1: colorToken{
-			tint:  gray,
-			light: 0.7,
-			dark:  0,
-		},
-		inset2: colorToken{
-			tint:  gray,
-			light: 0.85,
-			dark:  0.15,
-		},
-		outset1: colorToken{
-			tint:  gray,
-			light: 0.85,
-			dark:  0.5,
-		},
-		outset2: colorToken{
-			tint:  gray,
-			light: 0.7,
-			dark:  0.2,
-		},
+		regular: defineToken("border", TintNeutral, 0.8, 0.1),
+		inset1:  defineToken("border-inset-1", TintNeutral, 0.7, 0),
+		inset2:  defineToken("border-inset-2", TintNeutral, 0.85, 0.15),
+		outset1: defineToken("border-outset-1", TintNeutral, 0.85, 0.5),
+		outset2: defineToken("border-outset-2", TintNeutral, 0.7, 0.2),
 	}
 	borderAccentTokens = borderColorTokens{
-		regular: colorToken{
-			tint:  blue,
-			light: 0.35,
-			dark:  0.35,
-		},
-		inset1: colorToken{
-			tint:  blue,
-			light: 0.325,
-			dark:  0.2,
-		},
-		inset2: colorToken{
-			tint:  blue,
-			light: 0.35,
-			dark:  0.35,
-		},
-		outset1: colorToken{
-			tint:  blue,
-			light: 0.6,
-			dark:  0.8,
-		},
-		outset2: colorToken{
-			tint:  blue,
-			light: 0.35,
-			dark:  0.35,
-		},
+		regular: defineToken("border-accent", TintAccent, 0.35, 0.35),
+		inset1:  defineToken("border-accent-inset-1", TintAccent, 0.325, 0.2),
+		inset2:  defineToken("border-accent-inset-2", TintAccent, 0.35, 0.35),
+		outset1: defineToken("border-accent-outset-1", TintAccent, 0.6, 0.8),
+		outset2: defineToken("border-accent-outset-2", TintAccent, 0.35, 0.35),
 	}
 	borderAccentSecondaryTokens = borderColorTokens{
-		regular: colorToken{
-			tint:  blue,
-			light: 0.8,
-			dark:  0.1,
-		},
-		inset1: colorToken{
-			tint:  blue,
-			light: 0.7,
-			dark:  0.2,
-		},
-		inset2: colorToken{
-			tint:  blue,
-			light: 0.85,
-			dark:  0.05,
-		},
-		outset1: colorToken{
-			tint:  blue,
-			light: 0.85,
-			dark:  0.05,
-		},
-		outset2: colorToken{
-			tint:  blue,
-			light: 0.7,
-			dark:  0.2,
-		},
-	}
-	borderDangerToken = colorToken{
-		tint:  red,
-		light: 0.4,
-		dark:  0.7,
+		regular: defineToken("border-accent-secondary", TintAccent, 0.8, 0.1),
+		inset1:  defineToken("border-accent-secondary-inset-1", TintAccent, 0.7, 0.2),
+		inset2:  defineToken("border-accent-secondary-inset-2", TintAccent, 0.85, 0.05),
+		outset1: defineToken("border-accent-secondary-outset-1", TintAccent, 0.85, 0.05),
+		outset2: defineToken("border-accent-secondary-outset-2", TintAccent, 0.7, 0.2),
 	}
+	borderDangerToken = defineToken("border-danger", TintDanger, 0.4, 0.7)
 )
 
-func BorderColors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	return borderTokens.colors(colorMode, borderType)
+func BorderColors(s ColorScheme, borderType RoundedRectBorderType) (color.Color, color.Color) {
+	return s.borderColors(borderTokens, borderType)
 }
 
-func BorderAccentColors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	return borderAccentTokens.colors(colorMode, borderType)
+func BorderAccentColors(s ColorScheme, borderType RoundedRectBorderType) (color.Color, color.Color) {
+	return s.borderColors(borderAccentTokens, borderType)
 }
 
-func BorderAccentSecondaryColors(colorMode ebiten.ColorMode, borderType RoundedRectBorderType) (color.Color, color.Color) {
-	return borderAccentSecondaryTokens.colors(colorMode, borderType)
+func BorderAccentSecondaryColors(s ColorScheme, borderType RoundedRectBorderType) (color.Color, color.Color) {
+	return s.borderColors(borderAccentSecondaryTokens, borderType)
 }
 
-func BorderDangerColors(colorMode ebiten.ColorMode) (color.Color, color.Color) {
-	c := borderDangerToken.color(colorMode)
+func BorderDangerColors(s ColorScheme) (color.Color, color.Color) {
+	c := s.color(borderDangerToken)
 	return c, c
 }
 
 var (
-	textEnabledToken = colorToken{
-		tint:  gray,
-		light: 0.1,
-		dark:  0.9,
-	}
-	textDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.5,
-		dark:  0.5,
-	}
-	textSelectionToken = colorToken{
-		tint:  blue,
-		light: 0.8,
-		dark:  0.35,
-	}
-	textActiveCompositionToken = colorToken{
-		tint:  blue,
-		light: 0.4,
-		dark:  0.6,
-	}
-	textInactiveCompositionToken = colorToken{
-		tint:  blue,
-		light: 0.8,
-		dark:  0.2,
-	}
-	controlEnabledToken = colorToken{
-		tint:  gray,
-		light: 1,
-		dark:  0.25,
-	}
-	controlDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.15,
-	}
-	contentBackgroundEnabledToken = colorToken{
-		tint:  gray,
-		light: 1,
-		dark:  0.15,
-	}
-	contentBackgroundDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.1,
-	}
-	menuBackgroundEnabledToken = colorToken{
-		tint:  gray,
-		light: 0.95,
-		dark:  0.3,
-	}
-	menuBackgroundDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.85,
-		dark:  0.25,
-	}
-	controlSecondaryEnabledToken = colorToken{
-		tint:  gray,
-		light: 0.95,
-		dark:  0.3,
-	}
-	controlSecondaryDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.85,
-		dark:  0.25,
-	}
-	buttonPressedToken = colorToken{
-		tint:  gray,
-		light: 0.95,
-		dark:  0.3,
-	}
-	buttonHoveredToken = colorToken{
-		tint:  gray,
-		light: 0.975,
-		dark:  0.275,
-	}
-	thumbEnabledToken = colorToken{
-		tint:  gray,
-		light: 1,
-		dark:  0.6,
-	}
-	thumbDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.55,
-	}
-	backgroundToken = colorToken{
-		tint:  gray,
-		light: 0.95,
-		dark:  0.05,
-	}
-	backgroundSecondaryToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.1,
-	}
-	popupBackgroundToken = colorToken{
-		tint:  gray,
-		light: 1,
-		dark:  0.05,
-	}
-	accentToken = colorToken{
-		tint:  blue,
-		light: 0.5,
-		dark:  0.5,
-	}
-	textOnAccentToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.9,
-	}
-	itemHighlightedTextToken = colorToken{
-		tint:  gray,
-		light: 1,
-		dark:  1,
-	}
-	itemHighlightedBackgroundToken = colorToken{
-		tint:  blue,
-		light: 0.6,
-		dark:  0.35,
-	}
-	itemSelectedUnfocusedBackgroundToken = colorToken{
-		tint:  gray,
-		light: 0.85,
-		dark:  0.35,
-	}
-	itemSelectedDisabledBackgroundToken = colorToken{
-		tint:  gray,
-		light: 0.8,
-		dark:  0.35,
-	}
-	itemHoveredBackgroundToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.35,
-	}
-	dividerToken = colorToken{
-		tint:  gray,
-		light: 0.8,
-		dark:  0.2,
-	}
-	focusBorderToken = colorToken{
-		tint:  blue,
-		light: 0.8,
-		dark:  0.2,
-	}
-	textCaretToken = colorToken{
-		tint:  blue,
-		light: 0.5,
-		dark:  0.6,
-	}
-	trackOffToken = colorToken{
-		tint:  gray,
-		light: 0.8,
-		dark:  0.2,
-	}
-	trackOffPressedToken = colorToken{
-		tint:  gray,
-		light: 0.75,
-		dark:  0.25,
-	}
-	thumbHoveredToken = colorToken{
-		tint:  gray,
-		light: 0.975,
-		dark:  0.575,
-	}
-	thumbPressedToken = colorToken{
-		tint:  gray,
-		light: 0.95,
-		dark:  0.55,
-	}
-	accentPressedToken = colorToken{
-		tint:  blue,
-		light: 0.45,
-		dark:  0.55,
-	}
-	accentHoveredToken = colorToken{
-		tint:  blue,
-		light: 0.475,
-		dark:  0.525,
-	}
-	pressedButtonToken = colorToken{
-		tint:  blue,
-		light: 0.875,
-		dark:  0.5,
-	}
-	pressedButtonHoveredToken = colorToken{
-		tint:  blue,
-		light: 0.85,
-		dark:  0.525,
-	}
-	headerSeparatorToken = colorToken{
-		tint:  gray,
-		light: 0.9,
-		dark:  0.4,
-	}
-	headerSeparatorDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.8,
-		dark:  0.3,
-	}
-	sliderTickToken = colorToken{
-		tint:  gray,
-		light: 0.7,
-		dark:  0.3,
-	}
-	trackOnDisabledToken = colorToken{
-		tint:  gray,
-		light: 0.6,
-		dark:  0.4,
-	}
-	popupDarkBackgroundToken = colorToken{
-		tint:  gray,
-		light: 0.1,
-		dark:  0,
-	}
-	shadowToken = colorToken{
-		tint:  gray,
-		light: 0,
-		dark:  0,
-	}
-	foregroundToken = colorToken{
-		tint:  gray,
-		light: 0,
-		dark:  1,
-	}
-	radioButtonMarkToken = colorToken{
-		tint:  gray,
-		light: 1,
-		dark:  1,
-	}
+	textEnabledToken                     = defineToken("text", TintNeutral, 0.1, 0.9)
+	textDisabledToken                    = defineToken("text-disabled", TintNeutral, 0.5, 0.5)
+	textSelectionToken                   = defineToken("text-selection", TintAccent, 0.8, 0.35)
+	textActiveCompositionToken           = defineToken("text-active-composition", TintAccent, 0.4, 0.6)
+	textInactiveCompositionToken         = defineToken("text-inactive-composition", TintAccent, 0.8, 0.2)
+	controlEnabledToken                  = defineToken("control", TintNeutral, 1, 0.25)
+	controlDisabledToken                 = defineToken("control-disabled", TintNeutral, 0.9, 0.15)
+	contentBackgroundEnabledToken        = defineToken("content-background", TintNeutral, 1, 0.15)
+	contentBackgroundDisabledToken       = defineToken("content-background-disabled", TintNeutral, 0.9, 0.1)
+	menuBackgroundEnabledToken           = defineToken("menu-background", TintNeutral, 0.95, 0.3)
+	menuBackgroundDisabledToken          = defineToken("menu-background-disabled", TintNeutral, 0.85, 0.25)
+	controlSecondaryEnabledToken         = defineToken("control-secondary", TintNeutral, 0.95, 0.3)
+	controlSecondaryDisabledToken        = defineToken("control-secondary-disabled", TintNeutral, 0.85, 0.25)
+	buttonPressedToken                   = defineToken("button-pressed", TintNeutral, 0.95, 0.3)
+	buttonHoveredToken                   = defineToken("button-hovered", TintNeutral, 0.975, 0.275)
+	thumbEnabledToken                    = defineToken("thumb", TintNeutral, 1, 0.6)
+	thumbDisabledToken                   = defineToken("thumb-disabled", TintNeutral, 0.9, 0.55)
+	backgroundToken                      = defineToken("background", TintNeutral, 0.95, 0.05)
+	backgroundSecondaryToken             = defineToken("background-secondary", TintNeutral, 0.9, 0.1)
+	popupBackgroundToken                 = defineToken("popup-background", TintNeutral, 1, 0.05)
+	accentToken                          = defineToken("accent", TintAccent, 0.5, 0.5)
+	textOnAccentToken                    = defineToken("text-on-accent", TintNeutral, 0.9, 0.9)
+	itemHighlightedTextToken             = defineToken("item-highlighted-text", TintNeutral, 1, 1)
+	itemHighlightedBackgroundToken       = defineToken("item-highlighted-background", TintAccent, 0.6, 0.35)
+	itemSelectedUnfocusedBackgroundToken = defineToken("item-selected-unfocused-background", TintNeutral, 0.85, 0.35)
+	itemSelectedDisabledBackgroundToken  = defineToken("item-selected-disabled-background", TintNeutral, 0.8, 0.35)
+	itemHoveredBackgroundToken           = defineToken("item-hovered-background", TintNeutral, 0.9, 0.35)
+	dividerToken                         = defineToken("divider", TintNeutral, 0.8, 0.2)
+	focusBorderToken                     = defineToken("focus-border", TintAccent, 0.8, 0.2)
+	textCaretToken                       = defineToken("text-caret", TintAccent, 0.5, 0.6)
+	trackOffToken                        = defineToken("track-off", TintNeutral, 0.8, 0.2)
+	trackOffPressedToken                 = defineToken("track-off-pressed", TintNeutral, 0.75, 0.25)
+	thumbHoveredToken                    = defineToken("thumb-hovered", TintNeutral, 0.975, 0.575)
+	thumbPressedToken                    = defineToken("thumb-pressed", TintNeutral, 0.95, 0.55)
+	accentPressedToken                   = defineToken("accent-pressed", TintAccent, 0.45, 0.55)
+	accentHoveredToken                   = defineToken("accent-hovered", TintAccent, 0.475, 0.525)
+	pressedButtonToken                   = defineToken("pressed-button", TintAccent, 0.875, 0.5)
+	pressedButtonHoveredToken            = defineToken("pressed-button-hovered", TintAccent, 0.85, 0.525)
+	headerSeparatorToken                 = defineToken("header-separator", TintNeutral, 0.9, 0.4)
+	headerSeparatorDisabledToken         = defineToken("header-separator-disabled", TintNeutral, 0.8, 0.3)
+	sliderTickToken                      = defineToken("slider-tick", TintNeutral, 0.7, 0.3)
+	trackOnDisabledToken                 = defineToken("track-on-disabled", TintNeutral, 0.6, 0.4)
+	popupDarkBackgroundToken             = defineToken("popup-dark-background", TintNeutral, 0.1, 0)
+	shadowToken                          = defineToken("shadow", TintNeutral, 0, 0)
+	foregroundToken                      = defineToken("foreground", TintNeutral, 0, 1)
+	radioButtonMarkToken                 = defineToken("radio-button-mark", TintNeutral, 1, 1)
 )
 
-func TextColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func TextColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return textEnabledToken.color(colorMode)
+		return s.color(textEnabledToken)
 	}
-	return textDisabledToken.color(colorMode)
+	return s.color(textDisabledToken)
 }
 
 // TextColorFromTint returns the color for text tinted with tint.
 // A nil tint returns the color for enabled text.
-func TextColorFromTint(colorMode ebiten.ColorMode, tint color.Color) color.Color {
+func TextColorFromTint(s ColorScheme, tint color.Color) color.Color {
 	if tint == nil {
-		return TextColor(colorMode, true)
+		return TextColor(s, true)
 	}
-	return tokenFromTint(tint, 0.3, 0.8).color(colorMode)
+	return s.tintedColor(tint, 0.3, 0.8)
 }
 
-func TextSelectionColor(colorMode ebiten.ColorMode) color.Color {
-	return textSelectionToken.color(colorMode)
+func TextSelectionColor(s ColorScheme) color.Color {
+	return s.color(textSelectionToken)
 }
 
-func TextActiveCompositionColor(colorMode ebiten.ColorMode) color.Color {
-	return textActiveCompositionToken.color(colorMode)
+func TextActiveCompositionColor(s ColorScheme) color.Color {
+	return s.color(textActiveCompositionToken)
 }
 
-func TextInactiveCompositionColor(colorMode ebiten.ColorMode) color.Color {
-	return textInactiveCompositionToken.color(colorMode)
+func TextInactiveCompositionColor(s ColorScheme) color.Color {
+	return s.color(textInactiveCompositionToken)
 }
 
-func ControlColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func ControlColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return controlEnabledToken.color(colorMode)
+		return s.color(controlEnabledToken)
 	}
-	return controlDisabledToken.color(colorMode)
+	return s.color(controlDisabledToken)
 }
 
 // ContentBackgroundColor returns the color for a surface that holds content, like a list or a text input.
-func ContentBackgroundColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func ContentBackgroundColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return contentBackgroundEnabledToken.color(colorMode)
+		return s.color(contentBackgroundEnabledToken)
 	}
-	return contentBackgroundDisabledToken.color(colorMode)
+	return s.color(contentBackgroundDisabledToken)
 }
 
 // MenuBackgroundColor returns the color for the surface of a menu.
-func MenuBackgroundColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func MenuBackgroundColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return menuBackgroundEnabledToken.color(colorMode)
+		return s.color(menuBackgroundEnabledToken)
 	}
-	return menuBackgroundDisabledToken.color(colorMode)
+	return s.color(menuBackgroundDisabledToken)
 }
 
-func ControlSecondaryColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func ControlSecondaryColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return controlSecondaryEnabledToken.color(colorMode)
+		return s.color(controlSecondaryEnabledToken)
 	}
-	return controlSecondaryDisabledToken.color(colorMode)
+	return s.color(controlSecondaryDisabledToken)
 }
 
 // ButtonBackgroundColorFromTint returns the background color for a button
 // tinted with tint. A nil tint returns the color for an untinted button.
-func ButtonBackgroundColorFromTint(colorMode ebiten.ColorMode, tint color.Color, pressed bool, hovered bool) color.Color {
+func ButtonBackgroundColorFromTint(s ColorScheme, tint color.Color, pressed bool, hovered bool) color.Color {
 	if tint == nil {
 		if pressed {
-			return buttonPressedToken.color(colorMode)
+			return s.color(buttonPressedToken)
 		}
 		if hovered {
-			return buttonHoveredToken.color(colorMode)
+			return s.color(buttonHoveredToken)
 		}
-		return ControlColor(colorMode, true)
+		return ControlColor(s, true)
 	}
 	if pressed {
-		return tokenFromTint(tint, 0.85, 0.4).color(colorMode)
+		return s.tintedColor(tint, 0.85, 0.4)
 	}
 	if hovered {
-		return tokenFromTint(tint, 0.875, 0.375).color(colorMode)
+		return s.tintedColor(tint, 0.875, 0.375)
 	}
-	return tokenFromTint(tint, 0.9, 0.35).color(colorMode)
+	return s.tintedColor(tint, 0.9, 0.35)
 }
 
-func ThumbColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func ThumbColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return thumbEnabledToken.color(colorMode)
+		return s.color(thumbEnabledToken)
 	}
-	return thumbDisabledToken.color(colorMode)
+	return s.color(thumbDisabledToken)
 }
 
-func BackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return backgroundToken.color(colorMode)
+func BackgroundColor(s ColorScheme) color.Color {
+	return s.color(backgroundToken)
 }
 
-func BackgroundSecondaryColor(colorMode ebiten.ColorMode) color.Color {
-	return backgroundSecondaryToken.color(colorMode)
+func BackgroundSecondaryColor(s ColorScheme) color.Color {
+	return s.color(backgroundSecondaryToken)
 }
 
-func PopupBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return popupBackgroundToken.color(colorMode)
+func PopupBackgroundColor(s ColorScheme) color.Color {
+	return s.color(popupBackgroundToken)
 }
 
 // PopupBackgroundColorFromTint returns the background color for a popup tinted
 // with tint. A nil tint returns the color for an untinted popup.
-func PopupBackgroundColorFromTint(colorMode ebiten.ColorMode, tint color.Color) color.Color {
+func PopupBackgroundColorFromTint(s ColorScheme, tint color.Color) color.Color {
 	if tint == nil {
-		return PopupBackgroundColor(colorMode)
+		return PopupBackgroundColor(s)
 	}
-	return tokenFromTint(tint, 0.95, 0.1).color(colorMode)
+	return s.tintedColor(tint, 0.95, 0.1)
 }
 
 // BackgroundColorFromTint returns the background color tinted with tint.
 // A nil tint returns the color for an untinted background.
-func BackgroundColorFromTint(colorMode ebiten.ColorMode, tint color.Color) color.Color {
+func BackgroundColorFromTint(s ColorScheme, tint color.Color) color.Color {
 	if tint == nil {
-		return BackgroundColor(colorMode)
+		return BackgroundColor(s)
 	}
-	return tokenFromTint(tint, 0.95, 0.15).color(colorMode)
+	return s.tintedColor(tint, 0.95, 0.15)
 }
 
-func AccentColor(colorMode ebiten.ColorMode) color.Color {
-	return accentToken.color(colorMode)
+func AccentColor(s ColorScheme) color.Color {
+	return s.color(accentToken)
 }
 
-func TextOnAccentColor(colorMode ebiten.ColorMode) color.Color {
-	return textOnAccentToken.color(colorMode)
+func TextOnAccentColor(s ColorScheme) color.Color {
+	return s.color(textOnAccentToken)
 }
 
-func ItemHighlightedTextColor(colorMode ebiten.ColorMode) color.Color {
-	return itemHighlightedTextToken.color(colorMode)
+func ItemHighlightedTextColor(s ColorScheme) color.Color {
+	return s.color(itemHighlightedTextToken)
 }
 
-func ItemHighlightedBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return itemHighlightedBackgroundToken.color(colorMode)
+func ItemHighlightedBackgroundColor(s ColorScheme) color.Color {
+	return s.color(itemHighlightedBackgroundToken)
 }
 
-func ItemSelectedUnfocusedBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return itemSelectedUnfocusedBackgroundToken.color(colorMode)
+func ItemSelectedUnfocusedBackgroundColor(s ColorScheme) color.Color {
+	return s.color(itemSelectedUnfocusedBackgroundToken)
 }
 
-func ItemSelectedDisabledBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return itemSelectedDisabledBackgroundToken.color(colorMode)
+func ItemSelectedDisabledBackgroundColor(s ColorScheme) color.Color {
+	return s.color(itemSelectedDisabledBackgroundToken)
 }
 
-func ItemHoveredBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return itemHoveredBackgroundToken.color(colorMode)
+func ItemHoveredBackgroundColor(s ColorScheme) color.Color {
+	return s.color(itemHoveredBackgroundToken)
 }
 
-func ItemStripeBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return ScaleAlpha(foregroundToken.color(colorMode), 2.0/32)
+func ItemStripeBackgroundColor(s ColorScheme) color.Color {
+	return ScaleAlpha(s.color(foregroundToken), 2.0/32)
 }
 
-func DividerColor(colorMode ebiten.ColorMode) color.Color {
-	return dividerToken.color(colorMode)
+func DividerColor(s ColorScheme) color.Color {
+	return s.color(dividerToken)
 }
 
-func FocusBorderColor(colorMode ebiten.ColorMode) color.Color {
-	return focusBorderToken.color(colorMode)
+func FocusBorderColor(s ColorScheme) color.Color {
+	return s.color(focusBorderToken)
 }
 
-func TextCaretColor(colorMode ebiten.ColorMode) color.Color {
-	return textCaretToken.color(colorMode)
+func TextCaretColor(s ColorScheme) color.Color {
+	return s.color(textCaretToken)
 }
 
-func ScrollBarColor(colorMode ebiten.ColorMode) color.Color {
-	return ScaleAlpha(foregroundToken.color(colorMode), 0.8)
+func ScrollBarColor(s ColorScheme) color.Color {
+	return ScaleAlpha(s.color(foregroundToken), 0.8)
 }
 
-func TrackColor(colorMode ebiten.ColorMode, on bool, pressed bool) color.Color {
+func TrackColor(s ColorScheme, on bool, pressed bool) color.Color {
 	if on {
 		if pressed {
-			return accentPressedToken.color(colorMode)
+			return s.color(accentPressedToken)
 		}
-		return accentToken.color(colorMode)
+		return s.color(accentToken)
 	}
 	if pressed {
-		return trackOffPressedToken.color(colorMode)
+		return s.color(trackOffPressedToken)
 	}
-	return trackOffToken.color(colorMode)
+	return s.color(trackOffToken)
 }
 
-func ThumbHoveredColor(colorMode ebiten.ColorMode) color.Color {
-	return thumbHoveredToken.color(colorMode)
+func ThumbHoveredColor(s ColorScheme) color.Color {
+	return s.color(thumbHoveredToken)
 }
 
-func ThumbPressedColor(colorMode ebiten.ColorMode) color.Color {
-	return thumbPressedToken.color(colorMode)
+func ThumbPressedColor(s ColorScheme) color.Color {
+	return s.color(thumbPressedToken)
 }
 
-func CheckableControlBackgroundColor(colorMode ebiten.ColorMode, checked bool, pressed bool, enabled bool) color.Color {
+func CheckableControlBackgroundColor(s ColorScheme, checked bool, pressed bool, enabled bool) color.Color {
 	if !enabled {
-		return ControlColor(colorMode, false)
+		return ControlColor(s, false)
 	}
 	if checked {
 		if pressed {
-			return accentPressedToken.color(colorMode)
+			return s.color(accentPressedToken)
 		}
-		return accentToken.color(colorMode)
+		return s.color(accentToken)
 	}
 	if pressed {
-		return ControlSecondaryColor(colorMode, true)
+		return ControlSecondaryColor(s, true)
 	}
-	return ControlColor(colorMode, true)
+	return ControlColor(s, true)
 }
 
-func RadioButtonMarkColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func RadioButtonMarkColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return radioButtonMarkToken.color(colorMode)
+		return s.color(radioButtonMarkToken)
 	}
-	return ScaleAlpha(foregroundToken.color(colorMode), 0.5)
+	return ScaleAlpha(s.color(foregroundToken), 0.5)
 }
 
-func PrimaryButtonBackgroundColor(colorMode ebiten.ColorMode, pressed bool, hovered bool) color.Color {
+func PrimaryButtonBackgroundColor(s ColorScheme, pressed bool, hovered bool) color.Color {
 	if pressed {
-		return accentPressedToken.color(colorMode)
+		return s.color(accentPressedToken)
 	}
 	if hovered {
-		return accentHoveredToken.color(colorMode)
+		return s.color(accentHoveredToken)
 	}
-	return accentToken.color(colorMode)
+	return s.color(accentToken)
 }
 
 // PressedButtonBackgroundColor returns the color for a button that is kept pressed.
-func PressedButtonBackgroundColor(colorMode ebiten.ColorMode, hovered bool) color.Color {
+func PressedButtonBackgroundColor(s ColorScheme, hovered bool) color.Color {
 	if hovered {
-		return pressedButtonHoveredToken.color(colorMode)
+		return s.color(pressedButtonHoveredToken)
 	}
-	return pressedButtonToken.color(colorMode)
+	return s.color(pressedButtonToken)
 }
 
-func HeaderSeparatorColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func HeaderSeparatorColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return headerSeparatorToken.color(colorMode)
+		return s.color(headerSeparatorToken)
 	}
-	return headerSeparatorDisabledToken.color(colorMode)
+	return s.color(headerSeparatorDisabledToken)
 }
 
-func SliderTickColor(colorMode ebiten.ColorMode) color.Color {
-	return sliderTickToken.color(colorMode)
+func SliderTickColor(s ColorScheme) color.Color {
+	return s.color(sliderTickToken)
 }
 
 // TrackFillColor returns the color for the filled part of a track.
-func TrackFillColor(colorMode ebiten.ColorMode, enabled bool) color.Color {
+func TrackFillColor(s ColorScheme, enabled bool) color.Color {
 	if enabled {
-		return TrackColor(colorMode, true, false)
+		return TrackColor(s, true, false)
 	}
-	return trackOnDisabledToken.color(colorMode)
+	return s.color(trackOnDisabledToken)
 }
 
-func PopupDarkBackgroundColor(colorMode ebiten.ColorMode, openingRate float64) color.Color {
+func PopupDarkBackgroundColor(s ColorScheme, openingRate float64) color.Color {
 	alpha := 0.25
-	if colorMode == ebiten.ColorModeDark {
+	if s.colorMode == ebiten.ColorModeDark {
 		alpha = 0.5
 	}
-	return ScaleAlpha(popupDarkBackgroundToken.color(colorMode), alpha*openingRate)
+	return ScaleAlpha(s.color(popupDarkBackgroundToken), alpha*openingRate)
 }
 
-func ShadowColor(colorMode ebiten.ColorMode) color.Color {
-	return shadowToken.color(colorMode)
+func ShadowColor(s ColorScheme) color.Color {
+	return s.color(shadowToken)
 }
 
-func IconColor(colorMode ebiten.ColorMode) color.Color {
-	return foregroundToken.color(colorMode)
+func IconColor(s ColorScheme) color.Color {
+	return s.color(foregroundToken)
 }
 
-func OverlayBackgroundColor(colorMode ebiten.ColorMode) color.Color {
-	return ScaleAlpha(foregroundToken.color(colorMode), 1.0/32)
+func OverlayBackgroundColor(s ColorScheme) color.Color {
+	return ScaleAlpha(s.color(foregroundToken), 1.0/32)
 }
 
-func OverlayBorderColor(colorMode ebiten.ColorMode) color.Color {
-	return ScaleAlpha(foregroundToken.color(colorMode), 2.0/32)
+func OverlayBorderColor(s ColorScheme) color.Color {
+	return ScaleAlpha(s.color(foregroundToken), 2.0/32)
 }
@@ -157,6 +157,7 @@ func (a Attributes) weight() text.Weight {
 // Face is a resolved text face.
 type Face struct {
 	face       text.Face
+	rtlFace    text.Face
 	family     *Family
 	attributes Attributes
 	id         uint64
@@ -167,9 +168,10 @@ type Face struct {
 // disables fallback). A nil fnt resolves using the registered fallback stack
 // alone.
 func NewFace(context *guigui.Context, fnt *Family, attributes Attributes) Face {
-	face, id := resolveFace(context, fnt, attributes)
+	face, rtlFace, id := resolveFace(context, fnt, attributes)
 	return Face{
 		face:       face,
+		rtlFace:    rtlFace,
 		family:     fnt,
 		attributes: attributes,
 		id:         id,
@@ -179,8 +181,18 @@ func NewFace(context *guigui.Context, fnt *Family, attributes Attributes) Face {
 // NewFaceForTest wraps face directly with attributes, bypassing font
 // resolution. It is intended for tests that supply a specific [text.Face].
 func NewFaceForTest(face text.Face, attributes Attributes) Face {
+	rtlFace := face
+	if gtf, ok := face.(*text.GoTextFace); ok {
+		rtlFace = &text.GoTextFace{
+			Source:    gtf.Source,
+			Direction: text.DirectionRightToLeft,
+			Size:      gtf.Size,
+			Language:  gtf.Language,
+		}
+	}
 	return Face{
 		face:       face,
+		rtlFace:    rtlFace,
 		attributes: attributes,
 		id:         theNextFaceID.Add(1),
 	}
@@ -191,6 +203,12 @@ func (f Face) TextFace() text.Face {
 	return f.face
 }
 
+// RightToLeftTextFace returns the resolved face that shapes text from right to left.
+// It measures the same as [Face.TextFace], and is used to draw the right-to-left runs of bidirectional text.
+func (f Face) RightToLeftTextFace() text.Face {
+	return f.rtlFace
+}
+
 // Family returns the [Family] f was resolved from, or nil when f was resolved
 // without a family.
 func (f Face) Family() *Family {
@@ -268,8 +286,9 @@ type cacheKey struct {
 
 // cachedFace is a resolved face together with its process-unique id.
 type cachedFace struct {
-	face text.Face
-	id   uint64
+	face    text.Face
+	rtlFace text.Face
+	id      uint64
 }
 
 var (
@@ -286,7 +305,7 @@ var (
 	prevLocales []language.Tag
 )
 
-func resolveFace(context *guigui.Context, fnt *Family, attributes Attributes) (text.Face, uint64) {
+func resolveFace(context *guigui.Context, fnt *Family, attributes Attributes) (text.Face, text.Face, uint64) {
 	// As face source entries registered by [RegisterFaceSourceEntries] might be
 	// affected by locales, clear the cache when the locales change. Dropping the
 	// entries re-resolves faces, which assigns them fresh ids so cache entries
@@ -307,7 +326,7 @@ func resolveFace(context *guigui.Context, fnt *Family, attributes Attributes) (t
 		attributes: attributes,
 	}
 	if f, ok := theFaceCache[ck]; ok {
-		return f.face, f.id
+		return f.face, f.rtlFace, f.id
 	}
 
 	tmpFaceSourceEntries = slices.Delete(tmpFaceSourceEntries, 0, len(tmpFaceSourceEntries))
@@ -345,12 +364,28 @@ func resolveFace(context *guigui.Context, fnt *Family, attributes Attributes) (t
 		tmpFaceSourceEntries = appendFontFaceEntries(tmpFaceSourceEntries, context)
 	}
 
+	// The right-to-left face is resolved together so that both shape with the same sources.
+	mf := newMultiFace(tmpFaceSourceEntries, attributes, text.DirectionLeftToRight)
+	rtlMF := newMultiFace(tmpFaceSourceEntries, attributes, text.DirectionRightToLeft)
+
+	if theFaceCache == nil {
+		theFaceCache = map[cacheKey]cachedFace{}
+	}
+	id := theNextFaceID.Add(1)
+	theFaceCache[ck] = cachedFace{face: mf, rtlFace: rtlMF, id: id}
+
+	return mf, rtlMF, id
+}
+
+// newMultiFace returns the face rendering with entries in order, shaping in direction.
+func newMultiFace(entries []FaceSourceEntry, attributes Attributes, direction text.Direction) text.Face {
 	var fs []text.Face
-	for _, entry := range tmpFaceSourceEntries {
+	for _, entry := range entries {
 		gtf := &text.GoTextFace{
-			Source:   entry.FaceSource,
-			Size:     attributes.Size,
-			Language: attributes.Lang,
+			Source:    entry.FaceSource,
+			Direction: direction,
+			Size:      attributes.Size,
+			Language:  attributes.Lang,
 		}
 		if attributes.Italic {
 			// A no-op for sources without the ital axis; an explicit ital
@@ -380,14 +415,7 @@ func resolveFace(context *guigui.Context, fnt *Family, attributes Attributes) (t
 	if err != nil {
 		panic(err)
 	}
-
-	if theFaceCache == nil {
-		theFaceCache = map[cacheKey]cachedFace{}
-	}
-	id := theNextFaceID.Add(1)
-	theFaceCache[ck] = cachedFace{face: mf, id: id}
-
-	return mf, id
+	return mf
 }
 
 // styleDistance returns 0 when entry matches the italic face selection —
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package goldentest_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget"
+	"github.com/guigui-gui/guigui/guiguitest"
+)
+
+// densityProbe records its control height in Layout.
+type densityProbe struct {
+	guigui.DefaultWidget
+
+	controlHeight int
+}
+
+func (d *densityProbe) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	d.controlHeight = basicwidget.ControlHeight(context, d)
+}
+
+// densitySubtree provides its style to the probe.
+type densitySubtree struct {
+	guigui.DefaultWidget
+
+	style basicwidget.Style
+	probe densityProbe
+}
+
+func (d *densitySubtree) Env(context *guigui.Context, key guigui.EnvKey, source *guigui.EnvSource) (any, bool) {
+	return d.style.Env(key)
+}
+
+func (d *densitySubtree) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	d.style.WriteStateKeyTo(w)
+}
+
+func (d *densitySubtree) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&d.probe)
+	return nil
+}
+
+func (d *densitySubtree) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	layouter.LayoutWidget(&d.probe, widgetBounds.Bounds())
+}
+
+// densityRoot has a probe with the application density, and a probe in a subtree with its own style.
+type densityRoot struct {
+	guigui.DefaultWidget
+
+	probe   densityProbe
+	subtree densitySubtree
+}
+
+func (d *densityRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&d.probe)
+	adder.AddWidget(&d.subtree)
+	return nil
+}
+
+func (d *densityRoot) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	b := widgetBounds.Bounds()
+	top, bottom := b, b
+	top.Max.Y = b.Min.Y + b.Dy()/2
+	bottom.Min.Y = top.Max.Y
+	layouter.LayoutWidget(&d.probe, top)
+	layouter.LayoutWidget(&d.subtree, bottom)
+}
+
+// renderDensity renders a densityRoot with the application density and the subtree style at the scale 1,
+// and returns the control heights of the probe outside and inside the subtree.
+func renderDensity(t *testing.T, appDensity basicwidget.Density, style basicwidget.Style) (app, subtree int) {
+	t.Helper()
+	orig := basicwidget.AppDensity()
+	defer basicwidget.SetDensity(orig)
+	basicwidget.SetDensity(appDensity)
+
+	var root densityRoot
+	root.subtree.style = style
+	guiguitest.Render(t, &root, image.Pt(100, 100), nil)
+	return root.probe.controlHeight, root.subtree.probe.controlHeight
+}
+
+func TestControlHeightDensity(t *testing.T) {
+	// The unit size of the default theme is 24 at the scale 1.
+	testCases := []struct {
+		density basicwidget.Density
+		want    int
+	}{
+		{density: basicwidget.DensityCompact, want: 20},
+		{density: basicwidget.DensityStandard, want: 24},
+		{density: basicwidget.DensityTouch, want: 36},
+	}
+	for _, tc := range testCases {
+		got, _ := renderDensity(t, tc.density, basicwidget.Style{})
+		if got != tc.want {
+			t.Errorf("ControlHeight with the density %v: got: %d, want: %d", tc.density, got, tc.want)
+		}
+	}
+}
+
+func TestControlHeightSubtreeDensity(t *testing.T) {
+	// Style.Density of a subtree overrides the application density set by SetDensity.
+	app, subtree := renderDensity(t, basicwidget.DensityTouch, basicwidget.Style{
+		Density: basicwidget.DensityCompact,
+	})
+	if got, want := app, 36; got != want {
+		t.Errorf("ControlHeight outside the subtree: got: %d, want: %d", got, want)
+	}
+	if got, want := subtree, 20; got != want {
+		t.Errorf("ControlHeight in the subtree: got: %d, want: %d", got, want)
+	}
+
+	// A zero Style.Density inherits the application density.
+	_, subtree = renderDensity(t, basicwidget.DensityCompact, basicwidget.Style{})
+	if got, want := subtree, 20; got != want {
+		t.Errorf("ControlHeight in the subtree with DensityDefault: got: %d, want: %d", got, want)
+	}
+}
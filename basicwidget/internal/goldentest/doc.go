@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package goldentest has the tests of the basicwidget package that render widgets,
+// such as the golden image tests and the tests of the control heights in each density.
+//
+// The tests are in a separate package, as they run on a Guigui application by guiguitest.Main,
+// which needs a display. Run the tests with GUIGUI_GOLDEN_UPDATE=1 to create or refresh the golden images
+// in testdata:
+//
+//	GUIGUI_GOLDEN_UPDATE=1 go test ./basicwidget/internal/goldentest
+package goldentest
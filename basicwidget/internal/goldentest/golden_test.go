@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package goldentest_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget"
+	"github.com/guigui-gui/guigui/guiguitest"
+)
+
+func TestMain(m *testing.M) {
+	guiguitest.Main(m)
+}
+
+// appScales are the application scales the widgets are rendered at.
+var appScales = []float64{1, 2}
+
+// renderVariants renders the widget created by newWidget in each variant, and compares it with the golden image.
+// size is in device-independent pixels.
+func renderVariants(t *testing.T, name string, size image.Point, newWidget func() guigui.Widget) {
+	for _, v := range guiguitest.Variants(guiguitest.ColorModes, appScales, nil) {
+		t.Run(v.Name(), func(t *testing.T) {
+			img := guiguitest.Render(t, newWidget(), size.Mul(int(v.AppScale)), v.RenderOptions())
+			guiguitest.AssertGolden(t, img, name+"-"+v.Name(), nil)
+		})
+	}
+}
+
+func TestButton(t *testing.T) {
+	renderVariants(t, "button", image.Pt(100, 40), func() guigui.Widget {
+		var button basicwidget.Button
+		button.SetText("OK")
+		return &button
+	})
+}
+
+func TestButtonPrimary(t *testing.T) {
+	renderVariants(t, "button-primary", image.Pt(100, 40), func() guigui.Widget {
+		var button basicwidget.Button
+		button.SetText("OK")
+		button.SetType(basicwidget.ButtonTypePrimary)
+		return &button
+	})
+}
+
+func TestCheckbox(t *testing.T) {
+	renderVariants(t, "checkbox", image.Pt(32, 32), func() guigui.Widget {
+		return &basicwidget.Checkbox{}
+	})
+}
+
+func TestCheckboxChecked(t *testing.T) {
+	renderVariants(t, "checkbox-checked", image.Pt(32, 32), func() guigui.Widget {
+		var checkbox basicwidget.Checkbox
+		checkbox.SetValue(true)
+		return &checkbox
+	})
+}
+
+func TestTextInput(t *testing.T) {
+	renderVariants(t, "textinput", image.Pt(160, 40), func() guigui.Widget {
+		var textInput basicwidget.TextInput
+		textInput.SetValue("Hello, World!")
+		return &textInput
+	})
+}
+
+func TestTextInputPlaceholder(t *testing.T) {
+	renderVariants(t, "textinput-placeholder", image.Pt(160, 40), func() guigui.Widget {
+		var textInput basicwidget.TextInput
+		textInput.SetPlaceholder("Name")
+		return &textInput
+	})
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package goldentest_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget"
+	"github.com/guigui-gui/guigui/guiguitest"
+)
+
+// metricsProbe records the metrics in Layout.
+type metricsProbe struct {
+	guigui.DefaultWidget
+
+	unitSize   int
+	fontSize   float64
+	lineHeight int
+}
+
+func (m *metricsProbe) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	m.unitSize = basicwidget.UnitSize(context)
+	m.fontSize = basicwidget.FontSize(context)
+	m.lineHeight = basicwidget.LineHeight(context)
+}
+
+// setTheme sets the theme of the application until the test ends.
+func setTheme(t *testing.T, theme *basicwidget.Theme) {
+	t.Helper()
+	if err := basicwidget.SetTheme(theme); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = basicwidget.SetTheme(nil)
+	})
+}
+
+func TestThemeMetrics(t *testing.T) {
+	testCases := []struct {
+		unitSize       float64
+		wantUnitSize   int
+		wantFontSize   float64
+		wantLineHeight int
+	}{
+		// The default unit size is 24.
+		{unitSize: 0, wantUnitSize: 24, wantFontSize: 12, wantLineHeight: 18},
+		{unitSize: 32, wantUnitSize: 32, wantFontSize: 16, wantLineHeight: 24},
+		{unitSize: 20, wantUnitSize: 20, wantFontSize: 10, wantLineHeight: 15},
+	}
+	for _, tc := range testCases {
+		setTheme(t, &basicwidget.Theme{UnitSize: tc.unitSize})
+		var probe metricsProbe
+		guiguitest.Render(t, &probe, image.Pt(100, 100), nil)
+		if got := probe.unitSize; got != tc.wantUnitSize {
+			t.Errorf("UnitSize with the theme's unit size %g: got: %d, want: %d", tc.unitSize, got, tc.wantUnitSize)
+		}
+		if got := probe.fontSize; got != tc.wantFontSize {
+			t.Errorf("FontSize with the theme's unit size %g: got: %g, want: %g", tc.unitSize, got, tc.wantFontSize)
+		}
+		if got := probe.lineHeight; got != tc.wantLineHeight {
+			t.Errorf("LineHeight with the theme's unit size %g: got: %d, want: %d", tc.unitSize, got, tc.wantLineHeight)
+		}
+	}
+}
+
+// themeSubtree provides its theme to its background.
+type themeSubtree struct {
+	guigui.DefaultWidget
+
+	theme      *basicwidget.Theme
+	background basicwidget.Background
+}
+
+func (t *themeSubtree) Env(context *guigui.Context, key guigui.EnvKey, source *guigui.EnvSource) (any, bool) {
+	if key == basicwidget.EnvKeyTheme && t.theme != nil {
+		return t.theme, true
+	}
+	return nil, false
+}
+
+func (t *themeSubtree) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&t.background)
+	return nil
+}
+
+func (t *themeSubtree) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	layouter.LayoutWidget(&t.background, widgetBounds.Bounds())
+}
+
+// themeRoot has a background on the left half, and a subtree with its own theme on the right half.
+type themeRoot struct {
+	guigui.DefaultWidget
+
+	background basicwidget.Background
+	subtree    themeSubtree
+}
+
+func (t *themeRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&t.background)
+	adder.AddWidget(&t.subtree)
+	return nil
+}
+
+func (t *themeRoot) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	b := widgetBounds.Bounds()
+	left, right := b, b
+	left.Max.X = b.Min.X + b.Dx()/2
+	right.Min.X = left.Max.X
+	layouter.LayoutWidget(&t.background, left)
+	layouter.LayoutWidget(&t.subtree, right)
+}
+
+// renderThemeColors renders a themeRoot with the subtree theme, and returns the colors of the backgrounds
+// outside and inside the subtree.
+func renderThemeColors(t *testing.T, subtreeTheme *basicwidget.Theme) (app, subtree color.Color) {
+	t.Helper()
+	var root themeRoot
+	root.subtree.theme = subtreeTheme
+	img := guiguitest.Render(t, &root, image.Pt(100, 100), &guiguitest.RenderOptions{
+		ColorMode: ebiten.ColorModeLight,
+	})
+	return img.At(25, 50), img.At(75, 50)
+}
+
+// redBackgroundTheme is a theme overriding the background color.
+var redBackgroundTheme = &basicwidget.Theme{
+	Colors: map[string]basicwidget.ThemeColor{
+		"background": {Tint: "#ff0000", Light: 0.5, Dark: 0.5},
+	},
+}
+
+func TestSetThemeColors(t *testing.T) {
+	defaultColor, _ := renderThemeColors(t, nil)
+
+	setTheme(t, redBackgroundTheme)
+	app, subtree := renderThemeColors(t, nil)
+	if app == defaultColor {
+		t.Errorf("the background color with SetTheme: got: %v, want: other than the default %v", app, defaultColor)
+	}
+	// A subtree without its own theme follows the application theme.
+	if subtree != app {
+		t.Errorf("the background color in the subtree: got: %v, want: %v", subtree, app)
+	}
+}
+
+func TestEnvKeyThemeColors(t *testing.T) {
+	app, subtree := renderThemeColors(t, redBackgroundTheme)
+	defaultColor, _ := renderThemeColors(t, nil)
+	if app != defaultColor {
+		t.Errorf("the background color outside the subtree: got: %v, want: %v", app, defaultColor)
+	}
+	if subtree == defaultColor {
+		t.Errorf("the background color in the subtree: got: %v, want: other than the default %v", subtree, defaultColor)
+	}
+
+	// The subtree theme takes precedence over the application theme.
+	setTheme(t, &basicwidget.Theme{
+		Colors: map[string]basicwidget.ThemeColor{
+			"background": {Tint: "#0000ff", Light: 0.5, Dark: 0.5},
+		},
+	})
+	app2, subtree2 := renderThemeColors(t, redBackgroundTheme)
+	if app2 == defaultColor {
+		t.Errorf("the background color outside the subtree with SetTheme: got: %v, want: other than the default %v", app2, defaultColor)
+	}
+	if subtree2 != subtree {
+		t.Errorf("the background color in the subtree with SetTheme: got: %v, want: %v", subtree2, subtree)
+	}
+}
+
+func TestEnvKeyThemeInvalid(t *testing.T) {
+	// An invalid subtree theme falls back to the application theme instead of panicking.
+	app, subtree := renderThemeColors(t, &basicwidget.Theme{
+		Tints: map[string]color.Color{"primary": color.Black},
+	})
+	if subtree != app {
+		t.Errorf("the background color in the subtree with an invalid theme: got: %v, want: %v", subtree, app)
+	}
+}
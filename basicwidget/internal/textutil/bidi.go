@@ -0,0 +1,520 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package textutil
+
+import (
+	"math"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/go-text/typesetting/bidi"
+	unicodebidi "golang.org/x/text/unicode/bidi"
+)
+
+// bidiRun is a part of a visual line at a single embedding level of the
+// Unicode Bidirectional Algorithm (UAX #9).
+type bidiRun struct {
+	// start and end are the byte range of the run in the visual line.
+	start int
+	end   int
+
+	level bidi.Level
+
+	// x is the left of the run measured from the left of the visual line,
+	// as [oneLineLeft] returns it. width is the run's advance.
+	x     float64
+	width float64
+}
+
+// rightToLeft reports whether the run's characters are laid out from right to left.
+func (r *bidiRun) rightToLeft() bool {
+	return r.level%2 == 1
+}
+
+// hasRightToLeftRune reports whether str has a character that is laid out from
+// right to left, or that starts a right-to-left embedding, override or isolate.
+func hasRightToLeftRune(str string) bool {
+	for i := 0; i < len(str); i++ {
+		// Every right-to-left character is at U+0590 or later, whose UTF-8
+		// encoding starts with 0xD6 or a larger byte.
+		if str[i] < 0xd6 {
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(str[i:])
+		p, _ := unicodebidi.LookupRune(r)
+		switch p.Class() {
+		case unicodebidi.R, unicodebidi.AL, unicodebidi.AN, unicodebidi.RLE, unicodebidi.RLO, unicodebidi.RLI:
+			return true
+		}
+	}
+	return false
+}
+
+// needsBidi reports whether the visual line vlStr must be reordered by the
+// bidirectional algorithm. A line of left-to-right text in a left-to-right
+// paragraph is laid out in the logical order as it is.
+func needsBidi(vlStr string, style *Style) bool {
+	return style.RightToLeft || hasRightToLeftRune(vlStr)
+}
+
+// paragraphLevel returns the embedding level of the paragraphs laid out with style.
+func (s *Style) paragraphLevel() bidi.Level {
+	if s.RightToLeft {
+		return 1
+	}
+	return 0
+}
+
+// Bidi holds the state to reorder the bidirectional text of a text widget by
+// the Unicode Bidirectional Algorithm. Each text widget has its own Bidi, so
+// that laying out a text does not invalidate the runs of another.
+//
+// The zero value is ready to use.
+type Bidi struct {
+	paragraph bidi.Paragraph
+
+	// str and rightToLeft are the visual line and the base direction that
+	// runs are resolved for.
+	str         string
+	rightToLeft bool
+	runs        []bidiRun
+	runsValid   bool
+
+	// classes, types and levels are the bidirectional classes, the resolved
+	// types and the levels of the runes of the visual line.
+	classes []unicodebidi.Class
+	types   []unicodebidi.Class
+	levels  []bidi.Level
+
+	// runsBuffer holds the runs in the visual order for withBidiRuns.
+	runsBuffer []bidiRun
+}
+
+// bidiOf returns the Bidi of style, or a new Bidi if style has none.
+func bidiOf(style *Style) *Bidi {
+	if style.Bidi != nil {
+		return style.Bidi
+	}
+	return &Bidi{}
+}
+
+// logicalRuns returns the runs of vlStr at each embedding level in the
+// logical order, without positions. The runs are valid until the next call.
+//
+// The levels are resolved for the visual line alone, with the paragraph level
+// of style as the base direction: the text before the line does not affect the
+// line's levels.
+//
+// The levels match the conformance data of UAX #9 except for the text with
+// explicit embeddings, overrides and isolates: their directions are resolved,
+// but the levels of the characters in them are resolved only to their parity.
+func (b *Bidi) logicalRuns(vlStr string, style *Style) []bidiRun {
+	if b.runsValid && b.str == vlStr && b.rightToLeft == style.RightToLeft {
+		return b.runs
+	}
+	b.str = vlStr
+	b.rightToLeft = style.RightToLeft
+	b.runsValid = true
+	b.runs = b.runs[:0]
+	if vlStr == "" {
+		return b.runs
+	}
+
+	b.classes = b.classes[:0]
+	for _, r := range vlStr {
+		p, _ := unicodebidi.LookupRune(r)
+		b.classes = append(b.classes, p.Class())
+	}
+	b.levels = slices.Grow(b.levels[:0], len(b.classes))[:len(b.classes)]
+	clear(b.levels)
+
+	dir := bidi.LeftToRight
+	if style.RightToLeft {
+		dir = bidi.RightToLeft
+	}
+	paraLevel := style.paragraphLevel()
+
+	// The runs are in runes. An invalid byte counts as one rune both here and
+	// in the segmenter. The segmenter stops at a paragraph separator, which
+	// only a trailing line break can be in a visual line: the line break is
+	// covered by rule L1.
+	segmented := b.paragraph.SegmentString(vlStr, dir)
+	for i := range segmented.NumRuns() {
+		r := segmented.Run(i)
+		// A run has the level of its first character, as the segmenter splits
+		// the runs only where the direction changes. A run of an even level in
+		// a left-to-right paragraph holds both the left-to-right text at
+		// level 0 and the numbers after right-to-left text at level 2, which
+		// are reordered differently.
+		if r.Level%2 == 0 && paraLevel == 0 {
+			b.resolveNumberLevels(r.Start, r.End)
+			continue
+		}
+		for j := r.Start; j < r.End; j++ {
+			b.levels[j] = r.Level
+		}
+	}
+	b.resetWhitespaceLevels(paraLevel)
+
+	// Convert the levels of the runes to the runs in bytes.
+	var byteOffset int
+	for _, level := range b.levels {
+		_, s := utf8.DecodeRuneInString(vlStr[byteOffset:])
+		if n := len(b.runs); n > 0 && b.runs[n-1].level == level {
+			b.runs[n-1].end = byteOffset + s
+		} else {
+			b.runs = append(b.runs, bidiRun{
+				start: byteOffset,
+				end:   byteOffset + s,
+				level: level,
+			})
+		}
+		byteOffset += s
+	}
+	return b.runs
+}
+
+// resolveNumberLevels resolves the levels of the runes in [start, end), a run
+// of an even level in a left-to-right paragraph, by the rules W1-W7 of UAX #9.
+// A rune resolved to a European or an Arabic number is at level 2, and the
+// other runes are at level 0. The neutral characters in the run are resolved to
+// left-to-right, as the ones resolved to right-to-left are in an odd-level run.
+func (b *Bidi) resolveNumberLevels(start, end int) {
+	// The strong type before the run starts the sequence, or L at the start of
+	// the paragraph.
+	sos := unicodebidi.L
+	for i := start - 1; i >= 0; i-- {
+		if c := b.classes[i]; c == unicodebidi.L || c == unicodebidi.R || c == unicodebidi.AL {
+			sos = c
+			break
+		}
+	}
+
+	b.types = append(b.types[:0], b.classes[start:end]...)
+	types := b.types
+
+	// prev and next return the index of the previous and the next rune not
+	// removed by rule X9, or -1.
+	prev := func(i int) int {
+		for i--; i >= 0; i-- {
+			if types[i] != unicodebidi.BN {
+				return i
+			}
+		}
+		return -1
+	}
+	next := func(i int) int {
+		for i++; i < len(types); i++ {
+			if types[i] != unicodebidi.BN {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// W1-W3: a nonspacing mark takes the type of the previous character, a
+	// European number after an Arabic letter is an Arabic number, and an
+	// Arabic letter is right-to-left.
+	lastStrong := sos
+	for i, t := range types {
+		if t == unicodebidi.NSM {
+			if j := prev(i); j >= 0 {
+				t = types[j]
+			} else if start > 0 {
+				t = b.classes[start-1]
+			} else {
+				t = sos
+			}
+		}
+		switch t {
+		case unicodebidi.L, unicodebidi.R:
+			lastStrong = t
+		case unicodebidi.AL:
+			lastStrong = t
+			t = unicodebidi.R
+		case unicodebidi.EN:
+			if lastStrong == unicodebidi.AL {
+				t = unicodebidi.AN
+			}
+		}
+		types[i] = t
+	}
+
+	// W4: a single separator between two numbers of the same type is the
+	// number.
+	for i, t := range types {
+		if t != unicodebidi.ES && t != unicodebidi.CS {
+			continue
+		}
+		p, n := prev(i), next(i)
+		if p < 0 || n < 0 || types[p] != types[n] {
+			continue
+		}
+		if types[p] == unicodebidi.EN || (types[p] == unicodebidi.AN && t == unicodebidi.CS) {
+			types[i] = types[p]
+		}
+	}
+
+	// W5: a sequence of European terminators next to a European number is the
+	// number.
+	for i, t := range types {
+		if t != unicodebidi.EN {
+			continue
+		}
+		for j := prev(i); j >= 0 && types[j] == unicodebidi.ET; j = prev(j) {
+			types[j] = unicodebidi.EN
+		}
+		for j := next(i); j >= 0 && types[j] == unicodebidi.ET; j = next(j) {
+			types[j] = unicodebidi.EN
+		}
+	}
+
+	// W6 and W7: the other separators and terminators are neutral, and a
+	// European number after a left-to-right letter is left-to-right.
+	lastStrong = sos
+	var level bidi.Level
+	for i, t := range types {
+		switch t {
+		case unicodebidi.L, unicodebidi.R:
+			lastStrong = t
+			level = 0
+		case unicodebidi.EN:
+			level = 0
+			if lastStrong != unicodebidi.L {
+				level = 2
+			}
+		case unicodebidi.AN:
+			level = 2
+		case unicodebidi.BN:
+			// A character removed by rule X9 takes the level of the previous
+			// character.
+		default:
+			level = 0
+		}
+		b.levels[start+i] = level
+	}
+}
+
+// resetWhitespaceLevels applies rule L1: a segment separator like a tab, a
+// paragraph separator, and the whitespace before them or at the end of the
+// line are at the paragraph level.
+func (b *Bidi) resetWhitespaceLevels(paraLevel bidi.Level) {
+	reset := true
+	for i := len(b.classes) - 1; i >= 0; i-- {
+		switch b.classes[i] {
+		case unicodebidi.S, unicodebidi.B:
+			b.levels[i] = paraLevel
+			reset = true
+		case unicodebidi.WS, unicodebidi.LRI, unicodebidi.RLI, unicodebidi.FSI, unicodebidi.PDI,
+			unicodebidi.BN, unicodebidi.LRE, unicodebidi.RLE, unicodebidi.LRO, unicodebidi.RLO, unicodebidi.PDF:
+			if reset {
+				b.levels[i] = paraLevel
+			}
+		default:
+			reset = false
+		}
+	}
+}
+
+// appendBidiRuns appends the runs of the visual line vlStr to dst in the
+// visual order from left to right, with their positions. vlStrStartInBytes is
+// the byte offset of vlStr's start in the text that the face runs' offsets
+// index.
+func appendBidiRuns(dst []bidiRun, vlStr string, vlStrStartInBytes int, style *Style) []bidiRun {
+	base := len(dst)
+	dst = append(dst, bidiOf(style).logicalRuns(vlStr, style)...)
+	runs := dst[base:]
+
+	// Measure the runs in the logical order: each run is measured standalone,
+	// matching how it is drawn.
+	var total float64
+	for i := range runs {
+		r := &runs[i]
+		r.width = advanceWithFaces(vlStr[r.start:r.end], vlStrStartInBytes+r.start, r.end-r.start, style.Face, style.FaceRuns, style.TabWidth, true)
+		total += r.width
+	}
+
+	// Rule L2: from the highest level to the lowest odd level, reverse every
+	// sequence of the runs at that level or higher.
+	var highest bidi.Level
+	lowestOdd := bidi.Level(math.MaxInt8)
+	for _, r := range runs {
+		highest = max(highest, r.level)
+		if r.level%2 == 1 {
+			lowestOdd = min(lowestOdd, r.level)
+		}
+	}
+	for level := highest; level >= lowestOdd && level > 0; level-- {
+		for i := 0; i < len(runs); {
+			if runs[i].level < level {
+				i++
+				continue
+			}
+			j := i + 1
+			for j < len(runs) && runs[j].level >= level {
+				j++
+			}
+			slices.Reverse(runs[i:j])
+			i = j
+		}
+	}
+
+	// In a right-to-left paragraph, the text without the trailing spaces ends
+	// at the line's right edge that [oneLineLeft] aligns, and the trailing
+	// spaces are at the left.
+	var x float64
+	if style.RightToLeft {
+		x = advanceWithFaces(vlStr, vlStrStartInBytes, len(vlStr)-tailingLineBreakLen(vlStr), style.Face, style.FaceRuns, style.TabWidth, style.KeepTailingSpace) - total
+	}
+	for i := range runs {
+		runs[i].x = x
+		x += runs[i].width
+	}
+	return dst
+}
+
+// withBidiRuns calls f with the runs of the visual line vlStr in the visual
+// order. The runs are valid only during the call.
+func withBidiRuns[T any](vlStr string, vlStrStartInBytes int, style *Style, f func(runs []bidiRun) T) T {
+	b := bidiOf(style)
+	base := len(b.runsBuffer)
+	b.runsBuffer = appendBidiRuns(b.runsBuffer, vlStr, vlStrStartInBytes, style)
+	defer func() {
+		b.runsBuffer = b.runsBuffer[:base]
+	}()
+	return f(b.runsBuffer[base:])
+}
+
+// caretXInRun returns the x of the caret at index in the visual line, which
+// must be in [r.start, r.end].
+func caretXInRun(r *bidiRun, vlStr string, vlStrStartInBytes, index int, style *Style) float64 {
+	a := advanceWithFaces(vlStr[r.start:r.end], vlStrStartInBytes+r.start, index-r.start, style.Face, style.FaceRuns, style.TabWidth, true)
+	if r.rightToLeft() {
+		return r.x + r.width - a
+	}
+	return r.x + a
+}
+
+// caretXInVisualLine returns the x of the caret at index in the visual line
+// vlStr, measured from the left of the layout. vlStrStartInBytes is the byte
+// offset of vlStr's start in the text that the face runs' offsets index.
+//
+// The caret at a boundary of two runs is at the edge of the run that starts
+// there. The caret at the end of the line is at the edge of the logically last
+// run.
+func caretXInVisualLine(width int, vlStr string, vlStrStartInBytes, index int, style *Style) float64 {
+	left := oneLineLeft(width, vlStr, vlStrStartInBytes, style)
+	if !needsBidi(vlStr, style) {
+		return left + advanceWithFaces(vlStr, vlStrStartInBytes, index, style.Face, style.FaceRuns, style.TabWidth, true)
+	}
+	return left + withBidiRuns(vlStr, vlStrStartInBytes, style, func(runs []bidiRun) float64 {
+		for i := range runs {
+			if r := &runs[i]; r.start <= index && index < r.end {
+				return caretXInRun(r, vlStr, vlStrStartInBytes, index, style)
+			}
+		}
+		for i := range runs {
+			if r := &runs[i]; r.end == index {
+				return caretXInRun(r, vlStr, vlStrStartInBytes, index, style)
+			}
+		}
+		return 0
+	})
+}
+
+// indexFromXInBidiVisualLine is [indexFromXInVisualLine] for a visual line
+// reordered by the bidirectional algorithm. target is measured from the left of
+// the visual line, as [oneLineLeft] returns it.
+func indexFromXInBidiVisualLine(vlStr string, vlStrStartInBytes int, target float64, style *Style) int {
+	if !needsBidi(vlStr, style) {
+		return indexFromXInVisualLine(vlStr, vlStrStartInBytes, target, style)
+	}
+	return withBidiRuns(vlStr, vlStrStartInBytes, style, func(runs []bidiRun) int {
+		if len(runs) == 0 {
+			return 0
+		}
+		r := &runs[len(runs)-1]
+		for i := range runs {
+			if target < runs[i].x+runs[i].width {
+				r = &runs[i]
+				break
+			}
+		}
+		x := target - r.x
+		if r.rightToLeft() {
+			x = r.width - x
+		}
+		return r.start + indexFromXInVisualLine(vlStr[r.start:r.end], vlStrStartInBytes+r.start, x, style)
+	})
+}
+
+// xSpan is a horizontal extent [x0, x1).
+type xSpan struct {
+	x0 float64
+	x1 float64
+}
+
+// appendRangeXSpansInVisualLine appends the horizontal extents covering the
+// byte range [start, end) of the visual line vlStr to dst from left to right,
+// measured from the left of the layout. A range crossing runs of different
+// directions is split into several extents.
+func appendRangeXSpansInVisualLine(dst []xSpan, width int, vlStr string, vlStrStartInBytes, start, end int, style *Style) []xSpan {
+	if !needsBidi(vlStr, style) {
+		return append(dst, xSpan{
+			x0: caretXInVisualLine(width, vlStr, vlStrStartInBytes, start, style),
+			x1: caretXInVisualLine(width, vlStr, vlStrStartInBytes, end, style),
+		})
+	}
+	left := oneLineLeft(width, vlStr, vlStrStartInBytes, style)
+	return withBidiRuns(vlStr, vlStrStartInBytes, style, func(runs []bidiRun) []xSpan {
+		for i := range runs {
+			r := &runs[i]
+			s := max(start, r.start)
+			e := min(end, r.end)
+			if s >= e {
+				continue
+			}
+			x0 := caretXInRun(r, vlStr, vlStrStartInBytes, s, style)
+			x1 := caretXInRun(r, vlStr, vlStrStartInBytes, e, style)
+			if x0 > x1 {
+				x0, x1 = x1, x0
+			}
+			// Join the extents adjacent on the screen.
+			if n := len(dst); n > 0 && dst[n-1].x1 == left+x0 {
+				dst[n-1].x1 = left + x1
+				continue
+			}
+			dst = append(dst, xSpan{x0: left + x0, x1: left + x1})
+		}
+		return dst
+	})
+}
+
+// IsRightToLeftAt reports whether the character at position in the logical
+// line str is laid out from right to left. rightToLeft is the base direction
+// of the paragraph. At the end of the line, the character before position is
+// used instead.
+//
+// An editor moves the caret logically backward for the Left key when this
+// returns false, and logically forward when this returns true.
+func (b *Bidi) IsRightToLeftAt(str string, position int, rightToLeft bool) bool {
+	style := Style{
+		RightToLeft: rightToLeft,
+		Bidi:        b,
+	}
+	if !needsBidi(str, &style) {
+		return false
+	}
+	position = min(position, len(str))
+	if position >= len(str)-tailingLineBreakLen(str) && position > 0 {
+		_, size := utf8.DecodeLastRuneInString(str[:position])
+		position -= size
+	}
+	for _, r := range b.logicalRuns(str, &style) {
+		if r.start <= position && position < r.end {
+			return r.rightToLeft()
+		}
+	}
+	return style.paragraphLevel()%2 == 1
+}
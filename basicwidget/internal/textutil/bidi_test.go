@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package textutil_test
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	unicodebidi "golang.org/x/text/unicode/bidi"
+
+	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+)
+
+func TestIsRightToLeftAt(t *testing.T) {
+	testCases := []struct {
+		str         string
+		position    int
+		rightToLeft bool
+		want        bool
+	}{
+		{str: "abc", position: 0, rightToLeft: false, want: false},
+		{str: "abc", position: 3, rightToLeft: false, want: false},
+		{str: "abc", position: 1, rightToLeft: true, want: false},
+		{str: "ab שלום cd", position: 0, rightToLeft: false, want: false},
+		{str: "ab שלום cd", position: 3, rightToLeft: false, want: true},
+		{str: "ab שלום cd", position: 5, rightToLeft: false, want: true},
+		{str: "ab שלום cd", position: 12, rightToLeft: false, want: false},
+		{str: "שלום", position: 0, rightToLeft: false, want: true},
+		{str: "שלום", position: 8, rightToLeft: false, want: true},
+		{str: "שלום\n", position: 8, rightToLeft: false, want: true},
+		{str: "שלום 123", position: 9, rightToLeft: true, want: false},
+		{str: "مرحبا", position: 2, rightToLeft: true, want: true},
+		{str: "hello!", position: 6, rightToLeft: true, want: true},
+	}
+	for _, tc := range testCases {
+		if got := (&textutil.Bidi{}).IsRightToLeftAt(tc.str, tc.position, tc.rightToLeft); got != tc.want {
+			t.Errorf("IsRightToLeftAt(%q, %d, %t): got: %t, want: %t", tc.str, tc.position, tc.rightToLeft, got, tc.want)
+		}
+	}
+}
+
+// TestBidiConformance compares the levels with BidiCharacterTest.txt, the
+// conformance data of UAX #9 in the go-text/typesetting module.
+//
+// The lines with explicit embeddings, overrides and isolates are skipped, as
+// the levels in them are resolved only to their parity. The lines with a
+// paragraph separator in the middle are skipped, as a visual line never has
+// one.
+func TestBidiConformance(t *testing.T) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/go-text/typesetting").Output()
+	if err != nil {
+		t.Skipf("the go-text/typesetting module is not found: %v", err)
+	}
+	f, err := os.Open(filepath.Join(strings.TrimSpace(string(out)), "bidi", "test", "BidiCharacterTest.txt"))
+	if err != nil {
+		t.Skipf("the conformance data is not found: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var b textutil.Bidi
+	var count int
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := s.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// The fields are the code points, the paragraph direction, the resolved paragraph level,
+		// the resolved levels, and the visual order.
+		fields := strings.Split(line, ";")
+		if len(fields) < 4 {
+			t.Fatalf("invalid line: %q", line)
+		}
+		var runes []rune
+		for c := range strings.FieldsSeq(fields[0]) {
+			v, err := strconv.ParseUint(c, 16, 32)
+			if err != nil {
+				t.Fatal(err)
+			}
+			runes = append(runes, rune(v))
+		}
+		if !isBidiConformanceTarget(runes) {
+			continue
+		}
+		paragraphLevel, err := strconv.Atoi(fields[2])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := b.BidiLevels(string(runes), paragraphLevel == 1)
+		want := strings.Fields(fields[3])
+		for i, w := range want {
+			// A character removed by rule X9 has no level.
+			if w == "x" {
+				continue
+			}
+			if strconv.Itoa(got[i]) != w {
+				t.Errorf("levels of %s in the paragraph level %d: got: %v, want: %s", fields[0], paragraphLevel, got, fields[3])
+				break
+			}
+		}
+		count++
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count == 0 {
+		t.Errorf("no conformance test was run")
+	}
+}
+
+// isBidiConformanceTarget reports whether runes have neither explicit formatting characters nor a paragraph
+// separator before the end.
+func isBidiConformanceTarget(runes []rune) bool {
+	for i, r := range runes {
+		p, _ := unicodebidi.LookupRune(r)
+		switch p.Class() {
+		case unicodebidi.LRE, unicodebidi.RLE, unicodebidi.LRO, unicodebidi.RLO, unicodebidi.PDF,
+			unicodebidi.LRI, unicodebidi.RLI, unicodebidi.FSI, unicodebidi.PDI:
+			return false
+		case unicodebidi.B:
+			if i != len(runes)-1 {
+				return false
+			}
+		}
+	}
+	return true
+}
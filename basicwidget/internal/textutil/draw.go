@@ -118,6 +118,38 @@ func rangePositionsInVisualLines(layoutWidth int, vls []visualLine, vlsStartInBy
 	return posStart, posEnd0, true
 }
 
+// rangeSpansInVisualLines iterates the positions of the extents covering
+// [start, end), which must be in a single visual line of vls, from left to
+// right: the first position of each pair is at the extent's left and the
+// second at its right. A range crossing bidirectional runs of different
+// directions has several extents. The arguments are as for
+// [rangePositionsInVisualLines].
+func rangeSpansInVisualLines(layoutWidth int, vls []visualLine, vlsStartInBytes, start, end int, style *Style) iter.Seq2[TextPosition, TextPosition] {
+	return func(yield func(TextPosition, TextPosition) bool) {
+		posStart, posEnd, ok := rangePositionsInVisualLines(layoutWidth, vls, vlsStartInBytes, start, end, style)
+		if !ok {
+			return
+		}
+		i := slices.IndexFunc(vls, func(vl visualLine) bool {
+			return vl.pos <= start && start < vl.pos+len(vl.str)
+		})
+		if i < 0 || !needsBidi(vls[i].str, style) {
+			yield(posStart, posEnd)
+			return
+		}
+		vl := vls[i]
+		var buf [4]xSpan
+		for _, s := range appendRangeXSpansInVisualLine(buf[:0], layoutWidth, vl.str, vlsStartInBytes+vl.pos, start-vl.pos, end-vl.pos, style) {
+			p0, p1 := posStart, posStart
+			p0.X = s.x0
+			p1.X = s.x1
+			if !yield(p0, p1) {
+				return
+			}
+		}
+	}
+}
+
 var theVisualLinesBuffer []visualLine
 
 // appendVisualLinesFromCachedStarts reproduces visualLines for str by reading
@@ -243,7 +275,7 @@ func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOp
 				start := max(start, options.SelectionStart)
 				end := min(end, options.SelectionEnd)
 				if start != end {
-					if posStart, posEnd, ok := rangePositionsInVisualLines(layoutWidth, theVisualLinesBuffer, 0, start, end, &options.Style); ok {
+					for posStart, posEnd := range rangeSpansInVisualLines(layoutWidth, theVisualLinesBuffer, 0, start, end, &options.Style) {
 						x := float32(posStart.X) + float32(bounds.Min.X)
 						y := float32(posStart.Top) + float32(bounds.Min.Y)
 						width := float32(posEnd.X - posStart.X)
@@ -259,7 +291,7 @@ func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOp
 				start := max(start, options.CompositionStart)
 				end := min(end, options.CompositionEnd)
 				if start != end {
-					if posStart, posEnd, ok := rangePositionsInVisualLines(layoutWidth, theVisualLinesBuffer, 0, start, end, &options.Style); ok {
+					for posStart, posEnd := range rangeSpansInVisualLines(layoutWidth, theVisualLinesBuffer, 0, start, end, &options.Style) {
 						x := float32(posStart.X) + float32(bounds.Min.X)
 						y := float32(posStart.Bottom) + float32(bounds.Min.Y) - options.CompositionBorderWidth
 						w := float32(posEnd.X - posStart.X)
@@ -272,7 +304,7 @@ func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOp
 				start := max(start, options.CompositionActiveStart)
 				end := min(end, options.CompositionActiveEnd)
 				if start != end {
-					if posStart, posEnd, ok := rangePositionsInVisualLines(layoutWidth, theVisualLinesBuffer, 0, start, end, &options.Style); ok {
+					for posStart, posEnd := range rangeSpansInVisualLines(layoutWidth, theVisualLinesBuffer, 0, start, end, &options.Style) {
 						x := float32(posStart.X) + float32(bounds.Min.X)
 						y := float32(posStart.Bottom) + float32(bounds.Min.Y) - options.CompositionBorderWidth
 						w := float32(posEnd.X - posStart.X)
@@ -308,6 +340,11 @@ func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOp
 			}
 		}
 		switch {
+		case needsBidi(vlStr, &options.Style):
+			op.PrimaryAlign = text.AlignStart
+			x := oneLineLeft(layoutWidth, vlStr, start, &options.Style)
+			op.GeoM.Translate(x, 0)
+			drawBidiVisualLine(dst, vlStr, start, start+contentLen, lineRuns, options, op)
 		case styled:
 			op.PrimaryAlign = text.AlignStart
 			x := oneLineLeft(layoutWidth, vlStr, start, &options.Style)
@@ -321,11 +358,11 @@ func Draw(bounds image.Rectangle, dst *ebiten.Image, str string, options *DrawOp
 			// glyph's subpixel position is determined relative to the aligned origin,
 			// producing consistent rendering when the text content changes
 			// (e.g., right-aligned text gaining/losing characters).
-			switch options.HorizontalAlign {
+			switch options.Style.physicalHorizontalAlign() {
 			case HorizontalAlignCenter:
 				op.PrimaryAlign = text.AlignCenter
 				op.GeoM.Translate(float64(layoutWidth)/2, 0)
-			case HorizontalAlignEnd, HorizontalAlignRight:
+			case HorizontalAlignRight:
 				op.PrimaryAlign = text.AlignEnd
 				op.GeoM.Translate(float64(layoutWidth), 0)
 			default:
@@ -422,18 +459,16 @@ func visualLineBackgrounds(layoutWidth int, vls []visualLine, vl visualLine, run
 			if runStart >= runEnd {
 				continue
 			}
-			posStart, posEnd, ok := rangePositionsInVisualLines(layoutWidth, vls, 0, runStart, runEnd, style)
-			if !ok {
-				continue
-			}
-			if !yield(background{
-				X:      posStart.X,
-				Y:      posStart.Top,
-				Width:  posEnd.X - posStart.X,
-				Height: posStart.Bottom - posStart.Top,
-				Color:  run.BackgroundColor,
-			}) {
-				return
+			for posStart, posEnd := range rangeSpansInVisualLines(layoutWidth, vls, 0, runStart, runEnd, style) {
+				if !yield(background{
+					X:      posStart.X,
+					Y:      posStart.Top,
+					Width:  posEnd.X - posStart.X,
+					Height: posStart.Bottom - posStart.Top,
+					Color:  run.BackgroundColor,
+				}) {
+					return
+				}
 			}
 		}
 	}
@@ -529,22 +564,20 @@ func visualLineDecorations(layoutWidth int, vls []visualLine, lineStart, content
 					if runStart >= runEnd {
 						continue
 					}
-					posStart, posEnd, ok := rangePositionsInVisualLines(layoutWidth, vls, 0, runStart, runEnd, &options.Style)
-					if !ok {
-						continue
-					}
 					clr := run.Color
 					if clr == nil {
 						clr = options.TextColor
 					}
-					if !yield(decoration{
-						X:         posStart.X,
-						Y:         posStart.Top + baseAscent + offset - thickness/2,
-						Width:     posEnd.X - posStart.X,
-						Thickness: thickness,
-						Color:     clr,
-					}) {
-						return
+					for posStart, posEnd := range rangeSpansInVisualLines(layoutWidth, vls, 0, runStart, runEnd, &options.Style) {
+						if !yield(decoration{
+							X:         posStart.X,
+							Y:         posStart.Top + baseAscent + offset - thickness/2,
+							Width:     posEnd.X - posStart.X,
+							Thickness: thickness,
+							Color:     clr,
+						}) {
+							return
+						}
 					}
 				}
 			}
@@ -649,3 +682,71 @@ func drawStyledVisualLine(dst *ebiten.Image, vlStr string, lineStart, contentEnd
 	op.GeoM = origGeoM
 	op.ColorScale = origColorScale
 }
+
+// drawBidiVisualLine draws vlStr with its runs reordered by the bidirectional
+// algorithm. The arguments are as for [drawStyledVisualLine].
+func drawBidiVisualLine(dst *ebiten.Image, vlStr string, lineStart, contentEnd int, runs []StyleRun, options *DrawOptions, op *text.DrawOptions) {
+	b := bidiOf(&options.Style)
+	base := len(b.runsBuffer)
+	b.runsBuffer = appendBidiRuns(b.runsBuffer, vlStr, lineStart, &options.Style)
+	defer func() {
+		b.runsBuffer = b.runsBuffer[:base]
+	}()
+
+	origGeoM := op.GeoM
+	for _, r := range b.runsBuffer[base:] {
+		op.GeoM = origGeoM
+		op.GeoM.Translate(r.x, 0)
+		if r.rightToLeft() {
+			drawRightToLeftRun(dst, vlStr[r.start:r.end], lineStart+r.start, contentEnd, r.width, runs, options, op)
+		} else {
+			drawStyledVisualLine(dst, vlStr[r.start:r.end], lineStart+r.start, contentEnd, runs, options, op)
+		}
+	}
+	op.GeoM = origGeoM
+}
+
+// drawRightToLeftRun draws runStr, a right-to-left run whose byte i sits at
+// offset runStart+i of the drawn string, with the faces shaping from right to
+// left. runWidth is the run's advance. The other arguments are as for
+// [drawStyledVisualLine], and op must be positioned at the run's left.
+//
+// Each face-, color- and tab-delimited segment is placed at its logical
+// extent mirrored in the run.
+func drawRightToLeftRun(dst *ebiten.Image, runStr string, runStart, contentEnd int, runWidth float64, runs []StyleRun, options *DrawOptions, op *text.DrawOptions) {
+	origGeoM := op.GeoM
+	origColorScale := op.ColorScale
+	origPrimaryAlign := op.PrimaryAlign
+	baseAscent := options.Face.TextFace().Metrics().HAscent
+	var i int
+	for i < len(runStr) {
+		if options.TabWidth != 0 && runStr[i] == '\t' {
+			i++
+			continue
+		}
+		segFace, faceChange := faceAt(options.FaceRuns, options.Face, runStart+i)
+		clr, colorChange := styleRunColorAt(runs, options.TextColor, contentEnd, runStart+i)
+		segEnd := min(faceChange-runStart, colorChange-runStart, len(runStr))
+		if options.TabWidth != 0 {
+			if tabIdx := strings.IndexByte(runStr[i:segEnd], '\t'); tabIdx >= 0 {
+				segEnd = i + tabIdx
+			}
+		}
+		x0 := advanceWithFaces(runStr, runStart, i, options.Face, options.FaceRuns, options.TabWidth, true)
+		x1 := advanceWithFaces(runStr, runStart, segEnd, options.Face, options.FaceRuns, options.TabWidth, true)
+		face := segFace.RightToLeftTextFace()
+		// The segment is centered on its mirrored extent, which does not
+		// depend on where the face direction puts the origin of the text.
+		dy := baseAscent - face.Metrics().HAscent
+		op.GeoM = origGeoM
+		op.GeoM.Translate(runWidth-(x0+x1)/2, dy)
+		op.PrimaryAlign = text.AlignCenter
+		op.ColorScale.Reset()
+		op.ColorScale.ScaleWithColor(clr)
+		text.Draw(dst, runStr[i:segEnd], face, op)
+		i = segEnd
+	}
+	op.GeoM = origGeoM
+	op.ColorScale = origColorScale
+	op.PrimaryAlign = origPrimaryAlign
+}
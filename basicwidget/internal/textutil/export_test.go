@@ -244,3 +244,20 @@ func DecorationsPerVisualLine(layoutWidth int, str string, options *DrawOptions)
 	}
 	return decorations
 }
+
+// BidiLevels returns the embedding levels of the runes of the visual line str.
+func (b *Bidi) BidiLevels(str string, rightToLeft bool) []int {
+	runs := b.logicalRuns(str, &Style{
+		RightToLeft: rightToLeft,
+	})
+	var levels []int
+	for i := range str {
+		for _, r := range runs {
+			if r.start <= i && i < r.end {
+				levels = append(levels, int(r.level))
+				break
+			}
+		}
+	}
+	return levels
+}
@@ -206,7 +206,7 @@ func textIndexFromPositionInVisualLines(width int, position image.Point, vls ite
 
 	// Determine the index within the visual line.
 	left := oneLineLeft(width, vlStr, vlsStartInBytes+pos, style)
-	pos += indexFromXInVisualLine(vlStr, vlsStartInBytes+pos, float64(position.X)-left, style)
+	pos += indexFromXInBidiVisualLine(vlStr, vlsStartInBytes+pos, float64(position.X)-left, style)
 	return pos
 }
 
@@ -237,6 +237,6 @@ func textIndexFromPosition(width int, position image.Point, str string, style *S
 
 	// Determine the index within the visual line.
 	left := oneLineLeft(width, vlStr, pos, style)
-	pos += indexFromXInVisualLine(vlStr, pos, float64(position.X)-left, style)
+	pos += indexFromXInBidiVisualLine(vlStr, pos, float64(position.X)-left, style)
 	return pos
 }
@@ -202,8 +202,7 @@ func textPositionFromIndexInVisualLines(width int, vls iter.Seq[visualLine], vls
 
 	var pos0, pos1 TextPosition
 	if found0 {
-		x0 := oneLineLeft(width, line0, vlStartInBytes0, style)
-		x0 += advanceWithFaces(line0, vlStartInBytes0, indexInVisualLine0, style.Face, style.FaceRuns, style.TabWidth, true)
+		x0 := caretXInVisualLine(width, line0, vlStartInBytes0, indexInVisualLine0, style)
 		paddingY := style.visualLinePadding(scale0)
 		pos0 = TextPosition{
 			X:      x0,
@@ -212,8 +211,7 @@ func textPositionFromIndexInVisualLines(width int, vls iter.Seq[visualLine], vls
 		}
 	}
 	if found1 {
-		x1 := oneLineLeft(width, line1, vlStartInBytes1, style)
-		x1 += advanceWithFaces(line1, vlStartInBytes1, indexInVisualLine1, style.Face, style.FaceRuns, style.TabWidth, true)
+		x1 := caretXInVisualLine(width, line1, vlStartInBytes1, indexInVisualLine1, style)
 		paddingY := style.visualLinePadding(scale1)
 		pos1 = TextPosition{
 			X:      x1,
@@ -233,8 +231,11 @@ func textPositionFromIndexInVisualLines(width int, vls iter.Seq[visualLine], vls
 // AppendBoundsOfTextRange appends the bounding rectangles covering the byte
 // range [start, end) of the rendering text to dst and returns the extended
 // slice, one rectangle per crossed visual line, in order. A visual line
-// holding an empty part of the range appends nothing. Coordinates are
-// relative to the text layout origin, rounded outward to integers.
+// holding an empty part of the range appends nothing. A part crossing
+// bidirectional runs of different directions can be split on the screen, in
+// which case its visual line appends one rectangle per piece, from left to
+// right. Coordinates are relative to the text layout origin, rounded outward
+// to integers.
 // Endpoints outside the text are clamped.
 func AppendBoundsOfTextRange(dst []image.Rectangle, p *TextLayoutParams, start, end int) []image.Rectangle {
 	start = max(start, 0)
@@ -292,7 +293,8 @@ func visualLinesHeight(vls []visualLine, vlsStartInBytes int, style *Style) floa
 
 // appendBoundsOfRangeInVisualLines appends the rectangles covering
 // [start, end) within the visual lines vls to dst, one per crossed visual
-// line holding a non-empty part of the range. start, end, and each
+// line holding a non-empty part of the range, or one per piece of a part
+// split by bidirectional runs. start, end, and each
 // visualLine's pos are relative to vls' first byte; vlsStartInBytes is the
 // whole-text byte offset of that byte. yOrigin is added to the vertical
 // coordinates.
@@ -303,16 +305,14 @@ func appendBoundsOfRangeInVisualLines(dst []image.Rectangle, width int, vls []vi
 		if segStart >= segEnd {
 			continue
 		}
-		posStart, posEnd, ok := rangePositionsInVisualLines(width, vls, vlsStartInBytes, segStart, segEnd, style)
-		if !ok {
-			continue
+		for posStart, posEnd := range rangeSpansInVisualLines(width, vls, vlsStartInBytes, segStart, segEnd, style) {
+			dst = append(dst, image.Rect(
+				int(math.Floor(posStart.X)),
+				int(math.Floor(yOrigin+posStart.Top)),
+				int(math.Ceil(posEnd.X)),
+				int(math.Ceil(yOrigin+posStart.Bottom)),
+			))
 		}
-		dst = append(dst, image.Rect(
-			int(math.Floor(posStart.X)),
-			int(math.Floor(yOrigin+posStart.Top)),
-			int(math.Ceil(posEnd.X)),
-			int(math.Ceil(yOrigin+posStart.Bottom)),
-		))
 	}
 	return dst
 }
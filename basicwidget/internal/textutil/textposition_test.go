@@ -5,6 +5,7 @@ package textutil_test
 
 import (
 	"fmt"
+	"image"
 	"math"
 	"slices"
 	"testing"
@@ -750,3 +751,244 @@ func TestAppendBoundsOfTextRangeAcrossLines(t *testing.T) {
 		}
 	}
 }
+
+// TestTextPositionFromIndexBidi asserts the caret positions in lines mixing
+// left-to-right and right-to-left runs. Each run is measured standalone, so
+// the expected positions are sums of the runs' own advances.
+func TestTextPositionFromIndexBidi(t *testing.T) {
+	face := newTestFace(t)
+	adv := func(str string) float64 {
+		return textutil.AdvanceForTestParams(str, len(str), face, 0, true)
+	}
+	const lineHeight = 24.0
+
+	// "ab אבג cd" is laid out as "ab גבא cd".
+	const mixed = "ab אבג cd"
+	mixedLTR := adv("ab ")
+	mixedRTL := adv("אבג")
+	// "אבג 123" is laid out as "123 גבא": the number after the right-to-left
+	// text is at level 2, and is laid out at the left.
+	const number = "אבג 123"
+	numberLTR := adv("123")
+	numberRTL := adv("אבג ")
+
+	testCases := []struct {
+		name  string
+		text  string
+		index int
+		wantX float64
+	}{
+		{name: "mixed/start", text: mixed, index: 0, wantX: 0},
+		{name: "mixed/in-first-ltr-run", text: mixed, index: 1, wantX: adv("a")},
+		// The caret at a run boundary is at the edge of the run starting there:
+		// the right edge of the right-to-left run.
+		{name: "mixed/rtl-run-start", text: mixed, index: len("ab "), wantX: mixedLTR + mixedRTL},
+		{name: "mixed/in-rtl-run", text: mixed, index: len("ab א"), wantX: mixedLTR + mixedRTL - adv("א")},
+		{name: "mixed/rtl-run-last", text: mixed, index: len("ab אב"), wantX: mixedLTR + mixedRTL - adv("אב")},
+		{name: "mixed/ltr-run-after-rtl", text: mixed, index: len("ab אבג"), wantX: mixedLTR + mixedRTL},
+		{name: "mixed/end", text: mixed, index: len(mixed), wantX: mixedLTR + mixedRTL + adv(" cd")},
+		{name: "number/start", text: number, index: 0, wantX: numberLTR + numberRTL},
+		{name: "number/in-rtl-run", text: number, index: len("א"), wantX: numberLTR + numberRTL - adv("א")},
+		{name: "number/number-start", text: number, index: len("אבג "), wantX: 0},
+		{name: "number/in-number", text: number, index: len("אבג 1"), wantX: adv("1")},
+		{name: "number/end", text: number, index: len(number), wantX: numberLTR},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pos0, _, count := textutil.TextPositionFromIndex(&textutil.TextLayoutParams{
+				RenderingTextRange:  func(start, end int) string { return tc.text[start:end] },
+				RenderingTextLength: len(tc.text),
+				Width:               1000,
+				Style: textutil.Style{
+					Face:       face,
+					LineHeight: lineHeight,
+				},
+			}, tc.index)
+			if count != 1 {
+				t.Fatalf("count: got %d, want 1", count)
+			}
+			if math.Abs(pos0.X-tc.wantX) > 1e-6 {
+				t.Errorf("pos0.X: got %v, want %v", pos0.X, tc.wantX)
+			}
+		})
+	}
+}
+
+// TestTextPositionFromIndexRightToLeftParagraph asserts the caret positions
+// in a right-to-left paragraph, where the logical start is at the right.
+func TestTextPositionFromIndexRightToLeftParagraph(t *testing.T) {
+	face := newTestFace(t)
+	adv := func(str string) float64 {
+		return textutil.AdvanceForTestParams(str, len(str), face, 0, true)
+	}
+	const width = 1000
+
+	// "אבג abc" is laid out as "abc גבא" aligned to the right.
+	const str = "אבג abc"
+	x := func(index int) float64 {
+		pos0, _, count := textutil.TextPositionFromIndex(&textutil.TextLayoutParams{
+			RenderingTextRange:  func(start, end int) string { return str[start:end] },
+			RenderingTextLength: len(str),
+			Width:               width,
+			Style: textutil.Style{
+				Face:        face,
+				LineHeight:  24,
+				RightToLeft: true,
+			},
+		}, index)
+		if count != 1 {
+			t.Fatalf("index %d: count: got %d, want 1", index, count)
+		}
+		return pos0.X
+	}
+
+	if got, want := x(0), float64(width); math.Abs(got-want) > 1e-6 {
+		t.Errorf("x(0): got %v, want %v", got, want)
+	}
+	// The carets in the right-to-left run move to the left.
+	for _, index := range []int{len("א"), len("אב"), len("אבג")} {
+		prev := index - len("א")
+		if x(index) >= x(prev) {
+			t.Errorf("x(%d) = %v, want less than x(%d) = %v", index, x(index), prev, x(prev))
+		}
+	}
+	// The caret before the left-to-right run is at the run's left edge, and
+	// the caret at the end is at its right edge.
+	ltrStart := x(len("אבג "))
+	if got, want := x(len(str))-ltrStart, adv("abc"); math.Abs(got-want) > 1e-6 {
+		t.Errorf("x(end) - x(ltr start): got %v, want %v", got, want)
+	}
+	if got, want := x(0)-ltrStart, adv("abc")+adv("אבג "); math.Abs(got-want) > 1e-6 {
+		t.Errorf("x(0) - x(ltr start): got %v, want %v", got, want)
+	}
+}
+
+// TestTextIndexFromPositionBidi asserts that hit-testing the caret position
+// of an index inside a run returns the index again, in both paragraph
+// directions.
+func TestTextIndexFromPositionBidi(t *testing.T) {
+	face := newTestFace(t)
+	testCases := []struct {
+		text        string
+		rightToLeft bool
+		// indices are the indices whose carets are not on a run boundary,
+		// which is shared by two indices.
+		indices []int
+	}{
+		{
+			text:    "ab אבג cd",
+			indices: []int{0, 1, 2, len("ab א"), len("ab אב"), len("ab אבג c"), len("ab אבג cd")},
+		},
+		{
+			text:    "אבג 123",
+			indices: []int{len("א"), len("אב"), len("אבג 1"), len("אבג 12")},
+		},
+		{
+			text:        "אבג abc",
+			rightToLeft: true,
+			indices:     []int{0, len("א"), len("אב"), len("אבג a"), len("אבג ab")},
+		},
+		{
+			text:        "abc",
+			rightToLeft: true,
+			indices:     []int{1, 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/rtl=%t", tc.text, tc.rightToLeft), func(t *testing.T) {
+			params := &textutil.TextLayoutParams{
+				RenderingTextRange:  func(start, end int) string { return tc.text[start:end] },
+				RenderingTextLength: len(tc.text),
+				Width:               1000,
+				Style: textutil.Style{
+					Face:        face,
+					LineHeight:  24,
+					RightToLeft: tc.rightToLeft,
+				},
+			}
+			for _, index := range tc.indices {
+				pos0, _, _ := textutil.TextPositionFromIndex(params, index)
+				position := image.Pt(int(math.Round(pos0.X)), int((pos0.Top+pos0.Bottom)/2))
+				if got := textutil.TextIndexFromPosition(params, position); got != index {
+					t.Errorf("index %d at %v: got %d", index, position, got)
+				}
+			}
+		})
+	}
+}
+
+// TestAppendBoundsOfTextRangeBidi asserts that a range crossing a run
+// boundary is split into one rectangle per visually separate piece, and that
+// adjacent pieces are joined.
+func TestAppendBoundsOfTextRangeBidi(t *testing.T) {
+	face := newTestFace(t)
+	adv := func(str string) float64 {
+		return textutil.AdvanceForTestParams(str, len(str), face, 0, true)
+	}
+
+	// "ab אבג cd" is laid out as "ab גבא cd".
+	const str = "ab אבג cd"
+	ltr := adv("ab ")
+	rtl := adv("אבג")
+	params := &textutil.TextLayoutParams{
+		RenderingTextRange:  func(start, end int) string { return str[start:end] },
+		RenderingTextLength: len(str),
+		Width:               1000,
+		Style: textutil.Style{
+			Face:       face,
+			LineHeight: 24,
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		start int
+		end   int
+		// wantXs is the pairs of the left and the right of the rectangles from left to right.
+		wantXs [][2]float64
+	}{
+		{
+			name:   "ltr-to-rtl",
+			start:  1,
+			end:    len("ab א"),
+			wantXs: [][2]float64{{adv("a"), ltr}, {ltr + rtl - adv("א"), ltr + rtl}},
+		},
+		{
+			name:   "rtl-to-ltr",
+			start:  len("ab א"),
+			end:    len("ab אבג c"),
+			wantXs: [][2]float64{{ltr, ltr + rtl - adv("א")}, {ltr + rtl, ltr + rtl + adv(" c")}},
+		},
+		{
+			name:   "rtl-only",
+			start:  len("ab "),
+			end:    len("ab אבג"),
+			wantXs: [][2]float64{{ltr, ltr + rtl}},
+		},
+		{
+			name:   "whole",
+			start:  0,
+			end:    len(str),
+			wantXs: [][2]float64{{0, ltr + rtl + adv(" cd")}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bounds := textutil.AppendBoundsOfTextRange(nil, params, tc.start, tc.end)
+			slices.SortFunc(bounds, func(a, b image.Rectangle) int {
+				return a.Min.X - b.Min.X
+			})
+			if len(bounds) != len(tc.wantXs) {
+				t.Fatalf("bounds: got %v, want %d rectangles", bounds, len(tc.wantXs))
+			}
+			for i, b := range bounds {
+				if want := int(math.Floor(tc.wantXs[i][0])); b.Min.X != want {
+					t.Errorf("bounds[%d].Min.X: got %d, want %d", i, b.Min.X, want)
+				}
+				if want := int(math.Ceil(tc.wantXs[i][1])); b.Max.X != want {
+					t.Errorf("bounds[%d].Max.X: got %d, want %d", i, b.Max.X, want)
+				}
+			}
+		})
+	}
+}
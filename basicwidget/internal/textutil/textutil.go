@@ -127,6 +127,17 @@ type Style struct {
 	TabWidth         float64
 	KeepTailingSpace bool
 	EllipsisString   string
+
+	// RightToLeft makes the base direction of the paragraphs right-to-left:
+	// [HorizontalAlignStart] aligns the lines to the right, and neutral
+	// characters like punctuation at the ends of a line follow the right-to-left
+	// order. The text is reordered by the Unicode Bidirectional Algorithm
+	// regardless of RightToLeft.
+	RightToLeft bool
+
+	// Bidi is the state to reorder the text by the Unicode Bidirectional
+	// Algorithm. If Bidi is nil, a temporary state is used.
+	Bidi *Bidi
 }
 
 // visualLineScale returns the factor scaling the height and the baseline
@@ -509,19 +520,35 @@ func visualLines(width int, str string, wrapMode WrapMode, advance func(str stri
 	}
 }
 
+// physicalHorizontalAlign returns the horizontal alignment resolved to left,
+// center or right by the base direction.
+func (s *Style) physicalHorizontalAlign() HorizontalAlign {
+	switch s.HorizontalAlign {
+	case HorizontalAlignStart:
+		if s.RightToLeft {
+			return HorizontalAlignRight
+		}
+		return HorizontalAlignLeft
+	case HorizontalAlignEnd:
+		if s.RightToLeft {
+			return HorizontalAlignLeft
+		}
+		return HorizontalAlignRight
+	}
+	return s.HorizontalAlign
+}
+
 // oneLineLeft returns the left x of the visual line vlStr under style's
 // horizontal alignment. vlStrStartInBytes is the byte offset of vlStr's
 // start in the text that the face runs' offsets index.
 func oneLineLeft(width int, vlStr string, vlStrStartInBytes int, style *Style) float64 {
-	switch style.HorizontalAlign {
-	case HorizontalAlignStart, HorizontalAlignLeft:
-		// For RTL languages, HorizontalAlignStart should be the same as HorizontalAlignRight.
+	switch style.physicalHorizontalAlign() {
+	case HorizontalAlignLeft:
 		return 0
 	case HorizontalAlignCenter:
 		w := advanceWithFaces(vlStr, vlStrStartInBytes, len(vlStr)-tailingLineBreakLen(vlStr), style.Face, style.FaceRuns, style.TabWidth, style.KeepTailingSpace)
 		return (float64(width) - w) / 2
-	case HorizontalAlignEnd, HorizontalAlignRight:
-		// For RTL languages, HorizontalAlignEnd should be the same as HorizontalAlignLeft.
+	case HorizontalAlignRight:
 		w := advanceWithFaces(vlStr, vlStrStartInBytes, len(vlStr)-tailingLineBreakLen(vlStr), style.Face, style.FaceRuns, style.TabWidth, style.KeepTailingSpace)
 		return float64(width) - w
 	default:
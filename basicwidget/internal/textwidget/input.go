@@ -4,26 +4,30 @@
 package textwidget
 
 import (
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // IsMouseButtonRepeating reports whether button is pressed and its press
 // duration is at a key-repeat firing point.
 func IsMouseButtonRepeating(button ebiten.MouseButton) bool {
-	if !ebiten.IsMouseButtonPressed(button) {
+	if !inputsource.IsMouseButtonPressed(button) {
 		return false
 	}
-	return repeat(inpututil.MouseButtonPressDuration(button))
+	return repeat(inputsource.MouseButtonPressDuration(button))
 }
 
 // IsKeyRepeating reports whether key is pressed and its press duration is at a
 // key-repeat firing point.
 func IsKeyRepeating(key ebiten.Key) bool {
-	if !ebiten.IsKeyPressed(key) {
+	if !inputsource.IsKeyPressed(key) {
 		return false
 	}
-	return repeat(inpututil.KeyPressDuration(key))
+	return repeat(inputsource.KeyPressDuration(key))
 }
 
 func repeat(duration int) bool {
@@ -42,5 +46,13 @@ func repeat(duration int) bool {
 // doubleClickLimitInTicks returns the maximum number of ticks between two
 // clicks that count as a double click.
 func doubleClickLimitInTicks() int {
+	if d := desktopsettings.Get().DoubleClickTime; d > 0 {
+		return max(durationInTicks(d), 1)
+	}
 	return ebiten.TPS() / 2
 }
+
+// durationInTicks returns the number of ticks in the duration, rounded down.
+func durationInTicks(d time.Duration) int {
+	return int(d * time.Duration(ebiten.TPS()) / time.Second)
+}
@@ -250,7 +250,7 @@ func (t *Text) SetInsertionStyle(style textstyle.Style) {
 // discarding it without applying, such as on a selection change. The handler
 // is not invoked for [Text.SetInsertionStyle].
 func (t *Text) OnInsertionStyleReset(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(t, textEventInsertionStyleReset, f)
+	textEventInsertionStyleReset.SetHandler(t, f)
 }
 
 // adoptStylesForInsertedText applies the ranged style overrides insertedText,
@@ -340,7 +340,7 @@ func (t *Text) resetInsertionStyle() {
 		return
 	}
 	t.insertionStyle = textstyle.Style{}
-	guigui.DispatchEvent(t, textEventInsertionStyleReset)
+	textEventInsertionStyleReset.Dispatch(t)
 }
 
 // styleDefaults holds the rendering defaults that unset style properties
@@ -21,12 +21,15 @@ import (
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
 )
 
+// EventValueChanged is the event dispatched when a [Text]'s value is changed.
+// The arguments are the current text and whether the change is committed.
+var EventValueChanged = guigui.GenerateEventKey2[string, bool]()
+
 var (
-	textEventValueChanged            guigui.EventKey = guigui.GenerateEventKey()
-	textEventValueChangedWithoutText guigui.EventKey = guigui.GenerateEventKey()
-	textEventScrollDelta             guigui.EventKey = guigui.GenerateEventKey()
-	textEventScrollIntoView          guigui.EventKey = guigui.GenerateEventKey()
-	textEventInsertionStyleReset     guigui.EventKey = guigui.GenerateEventKey()
+	textEventValueChangedWithoutText = guigui.GenerateEventKey1[bool]()
+	textEventScrollDelta             = guigui.GenerateEventKey2[float64, float64]()
+	textEventScrollIntoView          = guigui.GenerateEventKey2[CaretScrollTarget, CaretScrollTarget]()
+	textEventInsertionStyleReset     = guigui.GenerateEventKey0()
 )
 
 // Text is a theme-free text widget: it owns the value, selection, caret, IME
@@ -145,6 +148,9 @@ type Text struct {
 
 	drawOptions textutil.DrawOptions
 
+	// bidi is the state to reorder the bidirectional text.
+	bidi textutil.Bidi
+
 	prevStart              int
 	prevEnd                int
 	paddingForScrollOffset guigui.Padding
@@ -200,14 +206,14 @@ type Text struct {
 // committed. Dispatch and commit semantics are documented on the wrapping
 // widget's OnValueChanged.
 func (t *Text) OnValueChanged(f func(context *guigui.Context, text string, committed bool)) {
-	guigui.SetEventHandler(t, textEventValueChanged, f)
+	EventValueChanged.SetHandler(t, f)
 }
 
 // OnValueChangedWithoutText sets a handler that fires under the same
 // conditions as [Text.OnValueChanged] but is not given the current text, so
 // the value is not materialized into a string on every change.
 func (t *Text) OnValueChangedWithoutText(f func(context *guigui.Context, committed bool)) {
-	guigui.SetEventHandler(t, textEventValueChangedWithoutText, f)
+	textEventValueChangedWithoutText.SetHandler(t, f)
 }
 
 // dispatchValueChanged dispatches a value-changed event, suppressing it when
@@ -233,10 +239,11 @@ func (t *Text) dispatchValueChanged(committed bool, force bool) {
 		}
 		t.lastDispatchedUncommittedGen = gen
 	}
-	guigui.DispatchEventLazy(t, textEventValueChanged, func() (string, bool) {
-		return t.stringValue(), committed
-	})
-	guigui.DispatchEvent(t, textEventValueChangedWithoutText, committed)
+	// Materializing the string is expensive for a large text. Skip it when no handler is registered.
+	if EventValueChanged.HasHandler(t) {
+		EventValueChanged.Dispatch(t, t.stringValue(), committed)
+	}
+	textEventValueChangedWithoutText.Dispatch(t, committed)
 }
 
 func (t *Text) OnHandleButtonInput(f func(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult) {
@@ -246,14 +253,14 @@ func (t *Text) OnHandleButtonInput(f func(context *guigui.Context, widgetBounds
 // OnScrollDelta registers a handler invoked when input handling needs the
 // containing scrollable area to scroll by a delta in pixels.
 func (t *Text) OnScrollDelta(f func(context *guigui.Context, deltaX, deltaY float64)) {
-	guigui.SetEventHandler(t, textEventScrollDelta, f)
+	textEventScrollDelta.SetHandler(t, f)
 }
 
 // OnScrollIntoView registers a handler invoked when the selection needs to be
 // brought into view. start and end are the selection endpoints (start <= end
 // as byte indices); both are equal when the selection has zero width.
 func (t *Text) OnScrollIntoView(f func(context *guigui.Context, start, end CaretScrollTarget)) {
-	guigui.SetEventHandler(t, textEventScrollIntoView, f)
+	textEventScrollIntoView.SetHandler(t, f)
 }
 
 // contentHashForStateKey returns a 64-bit fingerprint of the current field
@@ -814,6 +821,7 @@ func (t *Text) SetWrapMode(wrapMode textutil.WrapMode) {
 
 // SetCaretBlinking sets whether the caret blinks.
 // The default value is true.
+// Even if caretBlinking is true, the caret does not blink when the desktop disables caret blinking.
 func (t *Text) SetCaretBlinking(caretBlinking bool) {
 	t.caretStatic = !caretBlinking
 }
@@ -934,7 +942,7 @@ func (t *Text) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds)
 	// TODO: The caret position might be unstable when the text horizontal align is center or right. Fix this.
 	if t.selectable || t.editable {
 		if dx, dy := t.adjustScrollOffset(context, widgetBounds); dx != 0 || dy != 0 {
-			guigui.DispatchEvent(t, textEventScrollDelta, dx, dy)
+			textEventScrollDelta.Dispatch(t, dx, dy)
 		}
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
 )
 
 func textCaretWidth(context *guigui.Context) int {
@@ -61,14 +62,23 @@ func (t *textCaret) alpha(context *guigui.Context) float64 {
 	if s != e {
 		return 0
 	}
-	if t.text.caretStatic {
+	settings := desktopsettings.Get()
+	if t.text.caretStatic || settings.CaretBlinkDisabled {
 		return 1
 	}
 	offset := ebiten.TPS() / 2
 	if t.counter <= offset {
 		return 1
 	}
+	// Like GTK, the caret stops blinking after the timeout since the last move.
+	if settings.CaretBlinkTimeout > 0 && t.counter > offset+durationInTicks(settings.CaretBlinkTimeout) {
+		return 1
+	}
 	interval := ebiten.TPS()
+	if settings.CaretBlinkTime > 0 {
+		// The fading steps below take a fifth of the interval each.
+		interval = max(durationInTicks(settings.CaretBlinkTime), 5)
+	}
 	c := (t.counter - offset) % interval
 	if c < interval/5 {
 		return 1 - float64(c)/float64(interval/5)
@@ -267,6 +267,8 @@ func (t *Text) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds,
 	op.Style.VerticalAlign = t.baseStyle.vAlign
 	op.Style.TabWidth = t.actualTabWidth(context)
 	op.Style.KeepTailingSpace = t.keepTailingSpace
+	op.Style.RightToLeft = context.IsRightToLeft(t)
+	op.Style.Bidi = &t.bidi
 	insertion := t.insertion(context, false)
 	op.Style.Insertion = insertion
 	op.LayoutWidth = t.LayoutWidth(textBounds)
@@ -431,6 +433,8 @@ func (t *Text) DrawPlainString(context *guigui.Context, widgetBounds *guigui.Wid
 	op.Style.VerticalAlign = t.baseStyle.vAlign
 	op.Style.TabWidth = t.actualTabWidth(context)
 	op.Style.KeepTailingSpace = t.keepTailingSpace
+	op.Style.RightToLeft = context.IsRightToLeft(t)
+	op.Style.Bidi = &t.bidi
 	op.LayoutWidth = t.LayoutWidth(textBounds)
 	if !t.editable {
 		op.Style.EllipsisString = t.ellipsisString
@@ -8,15 +8,15 @@ import (
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/piecetable"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	textEventHotspotDown guigui.EventKey = guigui.GenerateEventKey()
-	textEventHotspotUp   guigui.EventKey = guigui.GenerateEventKey()
+	textEventHotspotDown = guigui.GenerateEventKey1[TextRange]()
+	textEventHotspotUp   = guigui.GenerateEventKey1[TextRange]()
 )
 
 // TextRange is a byte range of a text value.
@@ -123,7 +123,7 @@ func replaceTextRange(r TextRange, start, end, newLen int) (adjusted TextRange,
 // button is pressed on a hotspot range. The handler is given the pressed
 // range.
 func (t *Text) OnHotspotDown(f func(context *guigui.Context, textRange TextRange)) {
-	guigui.SetEventHandler(t, textEventHotspotDown, f)
+	textEventHotspotDown.SetHandler(t, f)
 }
 
 // OnHotspotUp sets the event handler that is called when the left mouse
@@ -132,7 +132,7 @@ func (t *Text) OnHotspotDown(f func(context *guigui.Context, textRange TextRange
 // between the press and the release: a drag selects, it does not click. The
 // handler is given the released range.
 func (t *Text) OnHotspotUp(f func(context *guigui.Context, textRange TextRange)) {
-	guigui.SetEventHandler(t, textEventHotspotUp, f)
+	textEventHotspotUp.SetHandler(t, f)
 }
 
 // hotspotRangeAt returns the hotspot range whose rectangles contain
@@ -173,16 +173,16 @@ func (t *Text) handleHotspotPointingInput(context *guigui.Context, widgetBounds
 	// IsMouseButtonJustPressed and IsMouseButtonJustReleased can be true at the same time as of Ebitengine v2.9.
 	// Check both.
 	var fired bool
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		if r, ok := t.hotspotRangeAt(context, widgetBounds, cursorPosition); ok {
 			t.hotspotPressed = true
 			t.pressedHotspotRange = r
 			t.hotspotPressSelectionStart, t.hotspotPressSelectionEnd = t.store.Selection()
-			guigui.DispatchEvent(t, textEventHotspotDown, r)
+			textEventHotspotDown.Dispatch(t, r)
 			fired = true
 		}
 	}
-	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && t.hotspotPressed {
+	if inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) && t.hotspotPressed {
 		t.hotspotPressed = false
 		if r, ok := t.hotspotRangeAt(context, widgetBounds, cursorPosition); ok && r == t.pressedHotspotRange && !t.dragState.moved(cursorPosition) {
 			// A selection made during the press cancels the click: a drag
@@ -191,12 +191,12 @@ func (t *Text) handleHotspotPointingInput(context *guigui.Context, widgetBounds
 			// not.
 			start, end := t.store.Selection()
 			if start == end || start == t.hotspotPressSelectionStart && end == t.hotspotPressSelectionEnd {
-				guigui.DispatchEvent(t, textEventHotspotUp, r)
+				textEventHotspotUp.Dispatch(t, r)
 				fired = true
 			}
 		}
 	}
-	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		t.hotspotPressed = false
 	}
 	if fired {
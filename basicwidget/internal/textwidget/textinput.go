@@ -9,11 +9,11 @@ import (
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textstyle"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // findWordBoundaries returns the byte range of the word containing idx,
@@ -64,6 +64,45 @@ func (t *Text) nextPositionOnGraphemes(position int) int {
 	return lineStart + textutil.NextPositionOnGraphemes(line, position-lineStart)
 }
 
+// isRightToLeftAt reports whether the character at position is laid out from
+// right to left. A masked text is always laid out from left to right.
+func (t *Text) isRightToLeftAt(context *guigui.Context, position int) bool {
+	if t.masking() {
+		return false
+	}
+	line, lineStart := t.stringValueForLineContaining(position)
+	return t.bidi.IsRightToLeftAt(line, position-lineStart, context.IsRightToLeft(t))
+}
+
+// leftPositionOnGraphemes returns the byte offset of the grapheme cluster
+// boundary that is visually left of position. This is the previous boundary in
+// left-to-right text and the next boundary in right-to-left text.
+func (t *Text) leftPositionOnGraphemes(context *guigui.Context, position int) int {
+	if t.isRightToLeftAt(context, position) {
+		return t.nextPositionOnGraphemes(position)
+	}
+	return t.prevPositionOnGraphemes(position)
+}
+
+// visualSelectionEnds returns the ends of the selection [start, end) that are
+// visually at the left and at the right. In right-to-left text, the logical
+// start is at the right.
+func (t *Text) visualSelectionEnds(context *guigui.Context, start, end int) (left, right int) {
+	if t.isRightToLeftAt(context, start) {
+		return end, start
+	}
+	return start, end
+}
+
+// rightPositionOnGraphemes returns the byte offset of the grapheme cluster
+// boundary that is visually right of position (cf. leftPositionOnGraphemes).
+func (t *Text) rightPositionOnGraphemes(context *guigui.Context, position int) int {
+	if t.isRightToLeftAt(context, position) {
+		return t.prevPositionOnGraphemes(position)
+	}
+	return t.nextPositionOnGraphemes(position)
+}
+
 // prevWordStart returns the byte offset of the start of the last word before
 // position, or 0 when no earlier word exists.
 func (t *Text) prevWordStart(position int) int {
@@ -278,7 +317,7 @@ func (t *Text) navigateForward(shift bool, target func(position int) (int, bool)
 }
 
 func (t *Text) HandlePointingInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
-	cursorPosition := image.Pt(ebiten.CursorPosition())
+	cursorPosition := image.Pt(inputsource.CursorPosition())
 	hotspotResult := t.handleHotspotPointingInput(context, widgetBounds, cursorPosition)
 
 	if !t.selectable && !t.editable {
@@ -286,7 +325,7 @@ func (t *Text) HandlePointingInput(context *guigui.Context, widgetBounds *guigui
 	}
 	if t.dragState.isDragging() {
 		t.dragState.trackCursorMovement(cursorPosition)
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 			idx := t.textIndexFromPosition(context, widgetBounds.Bounds(), cursorPosition, false)
 			start, end := t.dragState.extendedSelection(idx)
 			// idx is the dragged-to position; record whichever endpoint it
@@ -307,15 +346,15 @@ func (t *Text) HandlePointingInput(context *guigui.Context, widgetBounds *guigui
 				return guigui.AbortHandlingInputByWidget(t)
 			}
 		}
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 			t.dragState.reset()
 			return guigui.HandleInputByWidget(t)
 		}
 		return guigui.AbortHandlingInputByWidget(t)
 	}
 
-	left := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
-	right := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
+	left := inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	right := inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
 	if left || right {
 		if widgetBounds.IsHitAtCursor() {
 			t.handleClick(context, widgetBounds.Bounds(), cursorPosition, left)
@@ -354,7 +393,7 @@ func (t *Text) handleClick(context *guigui.Context, textBounds image.Rectangle,
 	// Shift+click on a text that already holds a cursor moves one end of the
 	// selection to the clicked position and keeps the opposite end anchored.
 	// Dragging afterwards keeps extending from the same anchor.
-	if leftClick && idx >= 0 && ebiten.IsKeyPressed(ebiten.KeyShift) && context.IsFocusedOrHasFocusedDescendant(t) {
+	if leftClick && idx >= 0 && inputsource.IsKeyPressed(ebiten.KeyShift) && context.IsFocusedOrHasFocusedDescendant(t) {
 		selStart, selEnd := t.store.Selection()
 		anchor := shiftClickAnchor(selStart, selEnd, t.shiftSelectionSide, idx)
 		t.dragState.start(cursorPosition, anchor, anchor)
@@ -406,7 +445,7 @@ func (t *Text) HandleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 	// within the same tick, avoiding a one-tick wobble.
 	if r.IsHandled() && (t.selectable || t.editable) {
 		if dx, dy := t.adjustScrollOffset(context, widgetBounds); dx != 0 || dy != 0 {
-			guigui.DispatchEvent(t, textEventScrollDelta, dx, dy)
+			textEventScrollDelta.Dispatch(t, dx, dy)
 		}
 	}
 	return r
@@ -454,7 +493,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 	// commandMode also selects the navigation layout: Command and Option with the
 	// arrow keys, and Home and End that scroll without moving the caret.
 	commandMode := mode == guigui.KeyBindingModeCommand
-	shortcutModifierPressed := ebiten.IsKeyPressed(mode.ShortcutModifierKey())
+	shortcutModifierPressed := inputsource.IsKeyPressed(mode.ShortcutModifierKey())
 	emacsKeymap := mode.UsesEmacsKeymap()
 
 	if t.editable {
@@ -471,7 +510,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 		// https://support.microsoft.com/en-us/windows/keyboard-shortcuts-in-windows-dcc61a57-8ff0-cffe-9796-cb9706c75eec#textediting
 
 		switch {
-		case inpututil.IsKeyJustPressed(ebiten.KeyEnter):
+		case inputsource.IsKeyJustPressed(ebiten.KeyEnter):
 			if t.IsMultiline() {
 				t.replaceTextAtSelection("\n")
 			} else {
@@ -479,7 +518,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 			}
 			return guigui.HandleInputByWidget(t)
 		case IsKeyRepeating(ebiten.KeyBackspace) ||
-			emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyH):
+			emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyH):
 			start, end := t.store.Selection()
 			if start != end {
 				t.replaceTextAtSelection("")
@@ -488,7 +527,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 				t.replaceTextAt("", pos, start, nil)
 			}
 			return guigui.HandleInputByWidget(t)
-		case ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyD):
+		case inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyD):
 			// Delete
 			start, end := t.store.Selection()
 			if start != end {
@@ -500,7 +539,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 			return guigui.HandleInputByWidget(t)
 		// The Emacs key theme deletes the next word with Alt and D. The macOS text
 		// system leaves the chord unbound, so Command mode does not take it.
-		case mode == guigui.KeyBindingModeControlEmacs && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyD):
+		case mode == guigui.KeyBindingModeControlEmacs && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyD):
 			start, end := t.store.Selection()
 			if start != end {
 				t.replaceTextAtSelection("")
@@ -511,7 +550,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 		// The Emacs key theme deletes back to the line head with Control+U. The
 		// macOS text system leaves the chord unbound, so Command mode does not
 		// take it.
-		case mode == guigui.KeyBindingModeControlEmacs && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyU):
+		case mode == guigui.KeyBindingModeControlEmacs && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyU):
 			start, end := t.store.Selection()
 			if start == end {
 				start = 0
@@ -530,7 +569,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 		// The macOS text system transposes the two clusters around the caret with
 		// Control+T. The Emacs key theme leaves the chord unbound, so ControlEmacs
 		// does not take it.
-		case commandMode && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyT):
+		case commandMode && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyT):
 			if start, end := t.store.Selection(); start == end {
 				if a, b, c, ok := t.transposableClusters(start); ok {
 					// The clusters carry their styles across the swap, which the
@@ -553,10 +592,10 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 		// The Emacs key theme binds Control+W to a cut as well. The macOS text
 		// system leaves Control+W unbound, so Command mode does not take it.
 		case shortcutModifierPressed && IsKeyRepeating(ebiten.KeyX) ||
-			mode == guigui.KeyBindingModeControlEmacs && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyW):
+			mode == guigui.KeyBindingModeControlEmacs && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyW):
 			t.Cut()
 			return guigui.HandleInputByWidget(t)
-		case shortcutModifierPressed && ebiten.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyV):
+		case shortcutModifierPressed && inputsource.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyV):
 			// Paste without styles. An additionally held Option also lands
 			// here, covering the macOS Paste and Match Style chord.
 			t.PasteWithoutStyles()
@@ -566,14 +605,14 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 			return guigui.HandleInputByWidget(t)
 		// Where the Emacs keymap is in effect, Control+Y is a yank, so redo is
 		// only Shift and the shortcut modifier with Z.
-		case shortcutModifierPressed && ebiten.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyZ) ||
+		case shortcutModifierPressed && inputsource.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyZ) ||
 			!emacsKeymap && shortcutModifierPressed && IsKeyRepeating(ebiten.KeyY):
 			t.Redo()
 			return guigui.HandleInputByWidget(t)
 		case shortcutModifierPressed && IsKeyRepeating(ebiten.KeyZ):
 			t.Undo()
 			return guigui.HandleInputByWidget(t)
-		case emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyK):
+		case emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyK):
 			// 'Kill' the text after the caret or the selection.
 			start, end := t.store.Selection()
 			if start == end {
@@ -589,7 +628,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 			t.tmpClipboard = t.stringValueWithRange(start, end)
 			t.replaceTextAt("", start, end, nil)
 			return guigui.HandleInputByWidget(t)
-		case emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyY):
+		case emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyY):
 			// 'Yank' the killed text.
 			if t.tmpClipboard != "" {
 				t.replaceTextAtSelection(t.tmpClipboard)
@@ -601,88 +640,88 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 	switch {
 	// macOS: Command+Arrow moves to a visual-line or document extreme;
 	// Option+Arrow moves by word or paragraph. Shift extends the selection.
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyLeft):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyLeft):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.visualLineStart(context, widgetBounds, position)
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyRight):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyRight):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.visualLineEnd(context, widgetBounds, position)
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyUp):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyUp):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
 			return 0, true
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyDown):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyMeta) && IsKeyRepeating(ebiten.KeyDown):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
 			return t.store.TextLengthInBytes(), true
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyLeft):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyLeft):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.prevWordStart(position), true
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyRight):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyRight):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.nextWordEnd(position), true
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyUp):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyUp):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.paragraphStart(position), true
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyDown):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyDown):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.paragraphEnd(position), true
 		})
 		return guigui.HandleInputByWidget(t)
 	// macOS: Shift+Home/End extend the selection to the start/end of the text.
 	// Plain Home/End scroll without moving the caret; they are left unhandled
 	// here and handled by the virtualizing parent after bubbling up.
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyHome):
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyHome):
 		t.navigateBackward(true, func(int) (int, bool) {
 			return 0, true
 		})
 		return guigui.HandleInputByWidget(t)
-	case commandMode && ebiten.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyEnd):
+	case commandMode && inputsource.IsKeyPressed(ebiten.KeyShift) && IsKeyRepeating(ebiten.KeyEnd):
 		t.navigateForward(true, func(int) (int, bool) {
 			return t.store.TextLengthInBytes(), true
 		})
 		return guigui.HandleInputByWidget(t)
 	// Windows/Linux: Ctrl+Arrow moves by word, Home/End to line head/tail,
 	// Ctrl+Home/End to document head/tail. Shift extends the selection.
-	case !commandMode && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyLeft):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case !commandMode && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyLeft):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.prevWordStart(position), true
 		})
 		return guigui.HandleInputByWidget(t)
-	case !commandMode && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyRight):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case !commandMode && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyRight):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.nextWordStart(position), true
 		})
 		return guigui.HandleInputByWidget(t)
-	case !commandMode && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyHome):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
+	case !commandMode && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyHome):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
 			return 0, true
 		})
 		return guigui.HandleInputByWidget(t)
-	case !commandMode && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyEnd):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
+	case !commandMode && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyEnd):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(int) (int, bool) {
 			return t.store.TextLengthInBytes(), true
 		})
 		return guigui.HandleInputByWidget(t)
 	case !commandMode && IsKeyRepeating(ebiten.KeyHome):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.visualLineStart(context, widgetBounds, position)
 		})
 		return guigui.HandleInputByWidget(t)
 	case !commandMode && IsKeyRepeating(ebiten.KeyEnd):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.visualLineEnd(context, widgetBounds, position)
 		})
 		return guigui.HandleInputByWidget(t)
@@ -690,63 +729,79 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 	// system places the same motion on Alt and Control. Both cases precede the
 	// character motion below, whose guard does not test Alt and would otherwise
 	// take the chord first.
-	case mode == guigui.KeyBindingModeControlEmacs && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyB) ||
-		commandMode && ebiten.IsKeyPressed(ebiten.KeyAlt) && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyB):
-		t.navigateBackward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case mode == guigui.KeyBindingModeControlEmacs && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyB) ||
+		commandMode && inputsource.IsKeyPressed(ebiten.KeyAlt) && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyB):
+		t.navigateBackward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.prevWordStart(position), true
 		})
 		return guigui.HandleInputByWidget(t)
 	// Both keymaps stop at the word end, where Control with an arrow key stops at
 	// the next word start.
-	case mode == guigui.KeyBindingModeControlEmacs && ebiten.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyF) ||
-		commandMode && ebiten.IsKeyPressed(ebiten.KeyAlt) && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyF):
-		t.navigateForward(ebiten.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
+	case mode == guigui.KeyBindingModeControlEmacs && inputsource.IsKeyPressed(ebiten.KeyAlt) && IsKeyRepeating(ebiten.KeyF) ||
+		commandMode && inputsource.IsKeyPressed(ebiten.KeyAlt) && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyF):
+		t.navigateForward(inputsource.IsKeyPressed(ebiten.KeyShift), func(position int) (int, bool) {
 			return t.nextWordEnd(position), true
 		})
 		return guigui.HandleInputByWidget(t)
 	case IsKeyRepeating(ebiten.KeyLeft) ||
-		emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyB):
+		emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyB):
 		start, end := t.store.Selection()
-		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		// The Left key moves the caret visually, while Control+B moves it logically.
+		prev := t.prevPositionOnGraphemes
+		collapseTo := start
+		if IsKeyRepeating(ebiten.KeyLeft) {
+			prev = func(position int) int {
+				return t.leftPositionOnGraphemes(context, position)
+			}
+			collapseTo, _ = t.visualSelectionEnds(context, start, end)
+		}
+		if inputsource.IsKeyPressed(ebiten.KeyShift) {
 			if t.shiftSelectionSide == SelectionSideEnd {
-				pos := t.prevPositionOnGraphemes(end)
+				pos := prev(end)
 				t.setSelection(start, pos, SelectionSideEnd, true)
 			} else {
-				pos := t.prevPositionOnGraphemes(start)
+				pos := prev(start)
 				t.setSelection(pos, end, SelectionSideStart, true)
 			}
 		} else {
 			if start != end {
-				t.setSelection(start, start, SelectionSideNone, true)
-			} else if start > 0 {
-				pos := t.prevPositionOnGraphemes(start)
+				t.setSelection(collapseTo, collapseTo, SelectionSideNone, true)
+			} else if pos := prev(start); pos != start {
 				t.setSelection(pos, pos, SelectionSideNone, true)
 			}
 		}
 		return guigui.HandleInputByWidget(t)
 	case IsKeyRepeating(ebiten.KeyRight) ||
-		emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyF):
+		emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyF):
 		start, end := t.store.Selection()
-		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		// The Right key moves the caret visually, while Control+F moves it logically.
+		next := t.nextPositionOnGraphemes
+		collapseTo := end
+		if IsKeyRepeating(ebiten.KeyRight) {
+			next = func(position int) int {
+				return t.rightPositionOnGraphemes(context, position)
+			}
+			_, collapseTo = t.visualSelectionEnds(context, start, end)
+		}
+		if inputsource.IsKeyPressed(ebiten.KeyShift) {
 			if t.shiftSelectionSide == SelectionSideStart {
-				pos := t.nextPositionOnGraphemes(start)
+				pos := next(start)
 				t.setSelection(pos, end, SelectionSideStart, true)
 			} else {
-				pos := t.nextPositionOnGraphemes(end)
+				pos := next(end)
 				t.setSelection(start, pos, SelectionSideEnd, true)
 			}
 		} else {
 			if start != end {
-				t.setSelection(end, end, SelectionSideNone, true)
-			} else if start < t.store.TextLengthInBytes() {
-				pos := t.nextPositionOnGraphemes(start)
+				t.setSelection(collapseTo, collapseTo, SelectionSideNone, true)
+			} else if pos := next(start); pos != start {
 				t.setSelection(pos, pos, SelectionSideNone, true)
 			}
 		}
 		return guigui.HandleInputByWidget(t)
 	case IsKeyRepeating(ebiten.KeyUp) ||
-		emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyP):
-		shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+		emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyP):
+		shift := inputsource.IsKeyPressed(ebiten.KeyShift)
 		var moveEnd bool
 		start, end := t.store.Selection()
 		idx := start
@@ -776,8 +831,8 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 		}
 		return guigui.HandleInputByWidget(t)
 	case IsKeyRepeating(ebiten.KeyDown) ||
-		emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyN):
-		shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+		emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyN):
+		shift := inputsource.IsKeyPressed(ebiten.KeyShift)
 		var moveStart bool
 		start, end := t.store.Selection()
 		idx := end
@@ -806,25 +861,25 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 			}
 		}
 		return guigui.HandleInputByWidget(t)
-	case emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyA):
+	case emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyA):
 		idx := 0
 		start, end := t.store.Selection()
 		if i, l := textutil.LastLineBreakPositionAndLen(t.stringValueWithRange(0, start)); i >= 0 {
 			idx = i + l
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		if inputsource.IsKeyPressed(ebiten.KeyShift) {
 			t.setSelection(idx, end, SelectionSideStart, true)
 		} else {
 			t.setSelection(idx, idx, SelectionSideNone, true)
 		}
 		return guigui.HandleInputByWidget(t)
-	case emacsKeymap && ebiten.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyE):
+	case emacsKeymap && inputsource.IsKeyPressed(ebiten.KeyControl) && IsKeyRepeating(ebiten.KeyE):
 		idx := t.store.TextLengthInBytes()
 		start, end := t.store.Selection()
 		if i, _ := textutil.FirstLineBreakPositionAndLen(t.stringValueWithRange(end, -1)); i >= 0 {
 			idx = end + i
 		}
-		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		if inputsource.IsKeyPressed(ebiten.KeyShift) {
 			t.setSelection(start, idx, SelectionSideEnd, true)
 		} else {
 			t.setSelection(idx, idx, SelectionSideNone, true)
@@ -845,7 +900,7 @@ func (t *Text) handleButtonInput(context *guigui.Context, widgetBounds *guigui.W
 }
 
 func (t *Text) CursorShape(context *guigui.Context, widgetBounds *guigui.WidgetBounds) (ebiten.CursorShapeType, bool) {
-	cursorPosition := image.Pt(ebiten.CursorPosition())
+	cursorPosition := image.Pt(inputsource.CursorPosition())
 	if !t.dragState.moved(cursorPosition) {
 		if _, ok := t.hotspotRangeAt(context, widgetBounds, cursorPosition); ok {
 			return ebiten.CursorShapePointer, true
@@ -78,6 +78,8 @@ func (t *Text) maskStyle(context *guigui.Context) textutil.Style {
 		VerticalAlign:    t.baseStyle.vAlign,
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		RightToLeft:      context.IsRightToLeft(t),
+		Bidi:             &t.bidi,
 	}
 }
 
@@ -7,10 +7,9 @@ import (
 	"image"
 	"math"
 
-	"github.com/hajimehoshi/ebiten/v2"
-
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // LineCount returns the number of logical lines (spans between hard line
@@ -185,6 +184,8 @@ func (t *Text) textIndexFromPosition(context *guigui.Context, textBounds image.R
 		VerticalAlign:    t.baseStyle.vAlign,
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		RightToLeft:      context.IsRightToLeft(t),
+		Bidi:             &t.bidi,
 	}
 	position = position.Sub(textContentBounds.Min)
 
@@ -259,6 +260,8 @@ func (t *Text) textPosition(context *guigui.Context, bounds image.Rectangle, ind
 		VerticalAlign:    t.baseStyle.vAlign,
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		RightToLeft:      context.IsRightToLeft(t),
+		Bidi:             &t.bidi,
 	}
 
 	// Pass the cached lineByteOffsets and the
@@ -376,6 +379,8 @@ func (t *Text) caretPositionWithinLine(context *guigui.Context, bounds image.Rec
 		VerticalAlign:    t.baseStyle.vAlign,
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		RightToLeft:      context.IsRightToLeft(t),
+		Bidi:             &t.bidi,
 	}
 	t.ensureLineByteOffsets()
 
@@ -450,7 +455,7 @@ func (t *Text) adjustScrollOffset(context *guigui.Context, widgetBounds *guigui.
 
 	if t.dragState.isDragging() {
 		// Drag autoscroll tracks the mouse, not the caret.
-		cx, cy := ebiten.CursorPosition()
+		cx, cy := inputsource.CursorPosition()
 		exEnd := float64(textVisibleBounds.Max.X) - float64(cx) - float64(t.paddingForScrollOffset.End)
 		eyEnd := float64(textVisibleBounds.Max.Y) - float64(cy) - float64(t.paddingForScrollOffset.Bottom)
 		if cx > textVisibleBounds.Max.X {
@@ -492,7 +497,7 @@ func (t *Text) adjustScrollOffset(context *guigui.Context, widgetBounds *guigui.
 			startTarget = st
 		}
 	}
-	guigui.DispatchEvent(t, textEventScrollIntoView, startTarget, endTarget)
+	textEventScrollIntoView.Dispatch(t, startTarget, endTarget)
 	return 0, 0
 }
 
@@ -598,6 +603,8 @@ func (t *Text) AppendBoundsOfTextRange(dst []image.Rectangle, context *guigui.Co
 		VerticalAlign:    t.baseStyle.vAlign,
 		TabWidth:         t.actualTabWidth(context),
 		KeepTailingSpace: t.keepTailingSpace,
+		RightToLeft:      context.IsRightToLeft(t),
+		Bidi:             &t.bidi,
 	}
 	t.ensureLineByteOffsets()
 	n := len(dst)
@@ -15,6 +15,8 @@ import (
 
 	"github.com/guigui-gui/guigui/basicwidget/internal/piecetable"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
+	"github.com/guigui-gui/guigui/internal/imecommit"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // maxComposerSurroundingBytes caps the bytes of surrounding text handed to
@@ -36,6 +38,12 @@ type textStore struct {
 	composer       textinput.Composer
 	composerInited bool
 
+	// replayedTick is the replayed tick whose typed characters were committed last.
+	replayedTick int64
+
+	// replayedChars is a buffer for the replayed typed characters.
+	replayedChars []rune
+
 	// inputEndedByUser records that the user ended text inputting from the
 	// platform side, e.g. by dismissing the virtual keyboard. It is reset by
 	// ConsumeInputEndedByUser.
@@ -151,6 +159,7 @@ func (s *textStore) ConsumeInputEndedByUser() bool {
 
 func (s *textStore) onIMECommit(c *textinput.Commit) {
 	text := c.Text()
+	imecommit.Notify(text)
 	beforeRepl, afterRepl := c.IsSurroundingTextReplaced()
 	if !beforeRepl && !afterRepl {
 		// Typical case: insert Text at the current selection.
@@ -375,6 +384,10 @@ func (s *textStore) Update() (handled bool, err error) {
 	if !s.focused {
 		return false, nil
 	}
+	// While input is replayed, the typed characters are committed directly, as the IME is not replayed.
+	if tick := inputsource.ReplayedTick(); tick > 0 {
+		return s.commitReplayedChars(tick), nil
+	}
 	s.ensureComposerInited()
 	handled, err = s.composer.Update()
 	if err != nil {
@@ -384,6 +397,21 @@ func (s *textStore) Update() (handled bool, err error) {
 	return handled, nil
 }
 
+// commitReplayedChars commits the characters typed in the replayed tick, once per tick,
+// and reports whether any character was committed.
+func (s *textStore) commitReplayedChars(tick int64) bool {
+	if s.replayedTick == tick {
+		return false
+	}
+	s.replayedTick = tick
+	s.replayedChars = inputsource.AppendInputChars(s.replayedChars[:0])
+	if len(s.replayedChars) == 0 {
+		return false
+	}
+	s.commitText(string(s.replayedChars))
+	return true
+}
+
 // TextLengthInBytes returns the length of the current text in bytes.
 func (s *textStore) TextLengthInBytes() int {
 	return s.pieceTable.Len()
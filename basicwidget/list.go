@@ -11,11 +11,11 @@ import (
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // EnvKeyListItemColorType is the environment key for obtaining a [ListItemColorType] from a list item.
@@ -79,40 +79,44 @@ const (
 )
 
 // TextColor returns the text color for the given color type.
-func (t ListItemColorType) TextColor(context *guigui.Context) color.Color {
+// widget is the item's widget, whose theme, including one provided by [EnvKeyTheme], is used.
+func (t ListItemColorType) TextColor(context *guigui.Context, widget guigui.Widget) color.Color {
+	cs := colorScheme(context, widget)
 	switch t {
 	case ListItemColorTypeHighlighted:
-		return draw.ItemHighlightedTextColor(context.ColorMode())
+		return draw.ItemHighlightedTextColor(cs)
 	case ListItemColorTypeItemDisabled, ListItemColorTypeListDisabled:
-		return draw.TextColor(context.ColorMode(), false)
+		return draw.TextColor(cs, false)
 	default:
-		return draw.TextColor(context.ColorMode(), true)
+		return draw.TextColor(cs, true)
 	}
 }
 
 // BackgroundColor returns the background color for the given color type.
 // BackgroundColor returns nil when no special background should be applied.
-func (t ListItemColorType) BackgroundColor(context *guigui.Context) color.Color {
+// widget is the widget drawing the background, whose theme, including one provided by [EnvKeyTheme], is used.
+func (t ListItemColorType) BackgroundColor(context *guigui.Context, widget guigui.Widget) color.Color {
+	cs := colorScheme(context, widget)
 	switch t {
 	case ListItemColorTypeHighlighted:
-		return draw.ItemHighlightedBackgroundColor(context.ColorMode())
+		return draw.ItemHighlightedBackgroundColor(cs)
 	case ListItemColorTypeListDisabled:
-		return draw.ItemSelectedDisabledBackgroundColor(context.ColorMode())
+		return draw.ItemSelectedDisabledBackgroundColor(cs)
 	case ListItemColorTypeSelectedInUnfocusedList:
-		return draw.ItemSelectedUnfocusedBackgroundColor(context.ColorMode())
+		return draw.ItemSelectedUnfocusedBackgroundColor(cs)
 	case ListItemColorTypeHovered:
-		return draw.ItemHoveredBackgroundColor(context.ColorMode())
+		return draw.ItemHoveredBackgroundColor(cs)
 	default:
 		return nil
 	}
 }
 
 var (
-	listEventItemSelected        guigui.EventKey = guigui.GenerateEventKey()
-	listEventItemsSelected       guigui.EventKey = guigui.GenerateEventKey()
-	listEventItemsMoved          guigui.EventKey = guigui.GenerateEventKey()
-	listEventItemsCanMove        guigui.EventKey = guigui.GenerateEventKey()
-	listEventItemExpanderToggled guigui.EventKey = guigui.GenerateEventKey()
+	listEventItemSelected        = guigui.GenerateEventKey1[int]()
+	listEventItemsSelected       = guigui.GenerateEventKey1[[]int]()
+	listEventItemsMoved          = guigui.GenerateEventKey3[int, int, int]()
+	listEventItemsCanMove        = guigui.GenerateEventKey3R[int, int, int, bool]()
+	listEventItemExpanderToggled = guigui.GenerateEventKey2[int, bool]()
 )
 
 type ListItem[T comparable] struct {
@@ -137,6 +141,12 @@ func (l *ListItem[T]) selectable() bool {
 }
 
 // writeStateKey writes the item's state into w.
+//
+// The fields are written by hand instead of by [guigui.StateKeyWriter.WriteStruct].
+// Value can be of any comparable type, such as a pointer to a struct or a channel, which WriteStruct would walk
+// or reject, and excluding Value from WriteStruct would need a tag on this public type.
+// Also, this is called for every item at every state key check.
+// TestListItemStateKeyFields checks that every field but Value is written here and compared in equals.
 func (l *ListItem[T]) writeStateKey(w *guigui.StateKeyWriter) {
 	w.WriteString(l.Text)
 	l.TextStyle.writeStateKey(w)
@@ -752,22 +762,22 @@ func (l *listItemWidget[T]) ensureLayout(context *guigui.Context) guigui.LinearL
 			Layout: l.textCenterLayout,
 			Size:   guigui.FlexibleSize(1),
 		})
-		layout.Padding = ListItemTextPadding(context)
+		layout.Padding = listItemTextPadding(context, l)
 	}
 	if l.item.KeyText != "" {
 		l.layoutItems = append(l.layoutItems, guigui.LinearLayoutItem{
 			Widget: &l.keyText,
 		})
-		layout.Padding.End = ListItemTextPadding(context).End
+		layout.Padding.End = listItemTextPadding(context, l).End
 	}
 	layout.Items = l.layoutItems
 	var h int
 	if l.heightPlus1 > 0 {
 		h = l.heightPlus1 - 1
 	} else if l.item.Border && l.item.Content == nil {
-		h = UnitSize(context) / 2
+		h = controlUnitSize(context, l) / 2
 	} else if l.item.Header && l.item.Content == nil {
-		h = UnitSize(context) * 3 / 2
+		h = controlUnitSize(context, l) * 3 / 2
 	}
 	if h > 0 {
 		l.wrapperLayoutItems = slices.Delete(l.wrapperLayoutItems, 0, len(l.wrapperLayoutItems))
@@ -802,11 +812,13 @@ func (l *listItemWidget[T]) Layout(context *guigui.Context, widgetBounds *guigui
 			if ct == ListItemColorTypeDefault {
 				if c := l.textColor(); c != nil {
 					clr = c
+				} else if c := inheritedTextColor(context, l); c != nil {
+					clr = c
 				} else {
-					clr = ct.TextColor(context)
+					clr = ct.TextColor(context, l)
 				}
 			} else {
-				clr = ct.TextColor(context)
+				clr = ct.TextColor(context, l)
 			}
 		}
 	}
@@ -825,18 +837,35 @@ func (l *listItemWidget[T]) Draw(context *guigui.Context, widgetBounds *guigui.W
 		x0 := float32(b.Min.X + u/4)
 		x1 := float32(b.Max.X - u/4)
 		y := float32(b.Min.Y) + float32(b.Dy())/2
-		width := float32(1 * context.Scale())
-		vector.StrokeLine(dst, x0, y, x1, y, width, draw.DividerColor(context.ColorMode()), false)
+		width := borderWidth(context)
+		vector.StrokeLine(dst, x0, y, x1, y, width, draw.DividerColor(colorScheme(context, l)), false)
 		return
 	}
 	/*if l.item.Header {
 		bounds := widgetBounds.Bounds()
-		draw.DrawRoundedRect(context, dst, bounds, draw.DividerColor(context.ColorMode()), RoundedCornerRadius(context))
+		draw.DrawRoundedRect(context, dst, bounds, draw.DividerColor(colorScheme(context, l)), RoundedCornerRadius(context))
 	}*/
 }
 
+// ListItemTextPadding returns the padding around the text of a list item at the standard density.
 func ListItemTextPadding(context *guigui.Context) guigui.Padding {
-	u := UnitSize(context)
+	return listItemTextPaddingForUnitSize(context, UnitSize(context))
+}
+
+// listItemTextPadding returns the padding around the text of the widget's list item, with the density applied.
+func listItemTextPadding(context *guigui.Context, widget guigui.Widget) guigui.Padding {
+	u := controlUnitSize(context, widget)
+	p := listItemTextPaddingForUnitSize(context, u)
+	if densityOf(context, widget) == DensityTouch {
+		// Make a single-line item as tall as a control, so that it is easy to tap.
+		y := max((u-LineHeight(context))/2, p.Top)
+		p.Top = y
+		p.Bottom = y
+	}
+	return p
+}
+
+func listItemTextPaddingForUnitSize(context *guigui.Context, u int) guigui.Padding {
 	return guigui.Padding{
 		Start:  u / 4,
 		Top:    int(context.Scale()),
@@ -1012,23 +1041,23 @@ func (l *listContent[T]) SetBackground(widget guigui.Widget) {
 }
 
 func (l *listContent[T]) OnItemSelected(f func(context *guigui.Context, index int)) {
-	guigui.SetEventHandler(l, listEventItemSelected, f)
+	listEventItemSelected.SetHandler(l, f)
 }
 
 func (l *listContent[T]) OnItemsSelected(f func(context *guigui.Context, indices []int)) {
-	guigui.SetEventHandler(l, listEventItemsSelected, f)
+	listEventItemsSelected.SetHandler(l, f)
 }
 
 func (l *listContent[T]) OnItemsMoved(f func(context *guigui.Context, from, count, to int)) {
-	guigui.SetEventHandler(l, listEventItemsMoved, f)
+	listEventItemsMoved.SetHandler(l, f)
 }
 
 func (l *listContent[T]) OnItemsCanMove(f func(context *guigui.Context, from, count, to int) bool) {
-	guigui.SetEventHandler(l, listEventItemsCanMove, f)
+	listEventItemsCanMove.SetHandler(l, f)
 }
 
 func (l *listContent[T]) OnItemExpanderToggled(f func(context *guigui.Context, index int, expanded bool)) {
-	guigui.SetEventHandler(l, listEventItemExpanderToggled, f)
+	listEventItemExpanderToggled.SetHandler(l, f)
 }
 
 func (l *listContent[T]) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
@@ -1229,14 +1258,14 @@ func (l *listContent[T]) Build(context *guigui.Context, adder *guigui.ChildAdder
 
 	if l.onItemSelected == nil {
 		l.onItemSelected = func(index int) {
-			guigui.DispatchEvent(l, listEventItemSelected, index)
+			listEventItemSelected.Dispatch(l, index)
 		}
 	}
 	l.abstractList.OnItemSelected(l.onItemSelected)
 
 	if l.onItemsSelected == nil {
 		l.onItemsSelected = func(indices []int) {
-			guigui.DispatchEvent(l, listEventItemsSelected, indices)
+			listEventItemsSelected.Dispatch(l, indices)
 		}
 	}
 	l.abstractList.OnItemsSelected(l.onItemsSelected)
@@ -1417,6 +1446,9 @@ func (l *listContent[T]) layoutItem(context *guigui.Context, widgetBounds *guigu
 
 	hasCheckmarkColumn := l.hasCheckmarkColumn()
 
+	// The positions below are computed from the left edge, and mirrored in the row in a right-to-left direction.
+	row := widgetBounds.Bounds().Inset(RoundedCornerRadius(context))
+
 	// Record item bounds.
 	{
 		itemP := p
@@ -1427,10 +1459,10 @@ func (l *listContent[T]) layoutItem(context *guigui.Context, widgetBounds *guigu
 		itemP.X += item.Padding.Start
 		itemP.Y = l.adjustItemY(context, itemP.Y)
 		itemP.Y += item.Padding.Top
-		l.itemBoundsForLayoutFromIndex[index] = image.Rectangle{
+		l.itemBoundsForLayoutFromIndex[index] = context.MirrorBounds(l, image.Rectangle{
 			Min: itemP,
 			Max: itemP.Add(image.Pt(itemW, contentH)),
-		}
+		}, row)
 	}
 
 	// Skip widget layout for items outside the visible bounds.
@@ -1449,10 +1481,10 @@ func (l *listContent[T]) layoutItem(context *guigui.Context, widgetBounds *guigu
 		imgP.Y += UnitSize(context) / 16
 		imgP.Y += item.Padding.Top
 		imgP.Y = l.adjustItemY(context, imgP.Y)
-		layouter.LayoutWidget(l.checkmarks.At(index), image.Rectangle{
+		layouter.LayoutWidget(l.checkmarks.At(index), context.MirrorBounds(l, image.Rectangle{
 			Min: imgP,
 			Max: imgP.Add(image.Pt(imgSize, imgSize)),
-		})
+		}, row))
 	}
 
 	if item.IndentLevel > 0 {
@@ -1477,10 +1509,10 @@ func (l *listContent[T]) layoutItem(context *guigui.Context, widgetBounds *guigu
 			LineHeight(context),
 			contentH,
 		)
-		layouter.LayoutWidget(l.expanderImages.At(index), image.Rectangle{
+		layouter.LayoutWidget(l.expanderImages.At(index), context.MirrorBounds(l, image.Rectangle{
 			Min: expanderP,
 			Max: expanderP.Add(s),
-		})
+		}, row))
 	}
 
 	itemP := p
@@ -1491,10 +1523,10 @@ func (l *listContent[T]) layoutItem(context *guigui.Context, widgetBounds *guigu
 	itemP.X += item.Padding.Start
 	itemP.Y = l.adjustItemY(context, itemP.Y)
 	itemP.Y += item.Padding.Top
-	r := image.Rectangle{
+	r := context.MirrorBounds(l, image.Rectangle{
 		Min: itemP,
 		Max: itemP.Add(image.Pt(itemW, contentH)),
-	}
+	}, row)
 	layouter.LayoutWidget(item.Content, r)
 	l.itemBoundsForLayoutFromIndex[index] = r
 
@@ -1811,7 +1843,7 @@ func (l *listContent[T]) setVerticalAdjust(adjust listVerticalAdjust) {
 }
 
 func (l *listContent[T]) calcDropDstIndex(context *guigui.Context) int {
-	_, y := ebiten.CursorPosition()
+	_, y := inputsource.CursorPosition()
 	var nonEmptyBoundsFound bool
 	for i := range l.abstractList.ItemCount() {
 		if !l.isItemAvailable(i) {
@@ -1891,7 +1923,7 @@ func (l *listContent[T]) hoveredItemIndex(context *guigui.Context, widgetBounds
 	if !widgetBounds.IsHitAtCursor() {
 		return -1
 	}
-	cp := image.Pt(ebiten.CursorPosition())
+	cp := image.Pt(inputsource.CursorPosition())
 	listBounds := widgetBounds.Bounds()
 	for i := range l.abstractList.ItemCount() {
 		if !l.isItemAvailable(i) {
@@ -1926,7 +1958,7 @@ func (l *listContent[T]) HandleButtonInput(context *guigui.Context, widgetBounds
 	down := isKeyRepeating(ebiten.KeyDown)
 	up := isKeyRepeating(ebiten.KeyUp)
 	if !down && !up {
-		if l.showsHoverHighlight() && inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		if l.showsHoverHighlight() && inputsource.IsKeyJustPressed(ebiten.KeyEnter) {
 			if l.selectKeyboardHighlightedItem() {
 				return guigui.HandleInputByWidget(l)
 			}
@@ -2016,7 +2048,7 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 	}
 
 	// Reset keyboard highlight when cursor moves.
-	cursorPos := image.Pt(ebiten.CursorPosition())
+	cursorPos := image.Pt(inputsource.CursorPosition())
 	if l.keyboardHighlightIndexPlus1 > 0 && cursorPos != l.lastCursorPosition {
 		l.keyboardHighlightIndexPlus1 = 0
 	}
@@ -2035,8 +2067,8 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 
 	// Process dragging.
 	if l.dragSrcIndexPlus1 > 0 {
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-			_, y := ebiten.CursorPosition()
+		if inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			_, y := inputsource.CursorPosition()
 			p := widgetBounds.VisibleBounds().Min
 			h := widgetBounds.VisibleBounds().Dy()
 			var dy float64
@@ -2053,8 +2085,8 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 				droppable := true
 				l.tmpSelectedIndices = l.abstractList.AppendSelectedItemIndices(l.tmpSelectedIndices[:0])
 				if len(l.tmpSelectedIndices) > 0 {
-					if result, handled := guigui.DispatchEvent(l, listEventItemsCanMove, l.tmpSelectedIndices[0], len(l.tmpSelectedIndices), i); handled {
-						droppable = result[0].(bool)
+					if result, handled := listEventItemsCanMove.Dispatch(l, l.tmpSelectedIndices[0], len(l.tmpSelectedIndices), i); handled {
+						droppable = result
 					}
 				}
 				if droppable {
@@ -2071,11 +2103,11 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 			if len(l.tmpSelectedIndices) > 0 {
 				from, count, to := l.tmpSelectedIndices[0], len(l.tmpSelectedIndices), l.dragDstIndexPlus1-1
 				canMove := true
-				if result, handled := guigui.DispatchEvent(l, listEventItemsCanMove, from, count, to); handled {
-					canMove = result[0].(bool)
+				if result, handled := listEventItemsCanMove.Dispatch(l, from, count, to); handled {
+					canMove = result
 				}
 				if canMove {
-					guigui.DispatchEvent(l, listEventItemsMoved, from, count, to)
+					listEventItemsMoved.Dispatch(l, from, count, to)
 				}
 			}
 			l.dragDstIndexPlus1 = 0
@@ -2085,17 +2117,17 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 	}
 
 	if index := l.hoveredItemIndexPlus1 - 1; index >= 0 && index < l.abstractList.ItemCount() {
-		c := image.Pt(ebiten.CursorPosition())
+		c := image.Pt(inputsource.CursorPosition())
 
-		left := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
-		right := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
+		left := inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+		right := inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonRight)
 		switch {
 		case (left || right):
 			item, _ := l.abstractList.ItemByIndex(index)
 			if c.X < l.itemBoundsForLayoutFromIndex[index].Min.X {
 				if left {
 					expanded := !item.Collapsed
-					guigui.DispatchEvent(l, listEventItemExpanderToggled, index, !expanded)
+					listEventItemExpanderToggled.Dispatch(l, index, !expanded)
 				}
 				l.pressStartPlus1 = image.Point{}
 				l.startPressingIndexPlus1 = 0
@@ -2118,9 +2150,9 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 			}
 
 			if !l.multiSelectionClickDisabled && l.abstractList.MultiSelection() {
-				if ebiten.IsKeyPressed(ebiten.KeyShift) {
+				if inputsource.IsKeyPressed(ebiten.KeyShift) {
 					l.extendItemSelectionByIndex(index, false)
-				} else if ebiten.IsKeyPressed(context.KeyBindingMode().ShortcutModifierKey()) {
+				} else if inputsource.IsKeyPressed(context.KeyBindingMode().ShortcutModifierKey()) {
 					l.toggleItemSelectionByIndex(index, false)
 				} else if !l.abstractList.IsSelectedItemIndex(index) {
 					l.selectItemByIndex(index, false)
@@ -2143,11 +2175,11 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 			// TODO: This behavior seems a little ad-hoc. Consider a better way.
 			return guigui.HandleInputResult{}
 
-		case ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
-			if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		case inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft):
+			if inputsource.IsKeyPressed(ebiten.KeyShift) {
 				return guigui.AbortHandlingInputByWidget(l)
 			}
-			if ebiten.IsKeyPressed(context.KeyBindingMode().ShortcutModifierKey()) {
+			if inputsource.IsKeyPressed(context.KeyBindingMode().ShortcutModifierKey()) {
 				return guigui.AbortHandlingInputByWidget(l)
 			}
 			if l.startPressingIndexPlus1 == 0 {
@@ -2188,11 +2220,11 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 			}
 			return guigui.AbortHandlingInputByWidget(l)
 
-		case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
+		case inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
 			// For the multi selection, the index is updated when the user releases the mouse button.
 			if !l.multiSelectionClickDisabled && l.abstractList.MultiSelection() && l.startPressingIndexPlus1 > 0 && l.dragSrcIndexPlus1 == 0 {
-				if !ebiten.IsKeyPressed(ebiten.KeyShift) &&
-					!ebiten.IsKeyPressed(context.KeyBindingMode().ShortcutModifierKey()) {
+				if !inputsource.IsKeyPressed(ebiten.KeyShift) &&
+					!inputsource.IsKeyPressed(context.KeyBindingMode().ShortcutModifierKey()) {
 					l.selectItemByIndex(l.startPressingIndexPlus1-1, false)
 					l.pressStartPlus1 = image.Point{}
 					l.startPressingIndexPlus1 = 0
@@ -2212,7 +2244,7 @@ func (l *listContent[T]) HandlePointingInput(context *guigui.Context, widgetBoun
 
 func (l *listContent[T]) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
 	// Clear press tracking once the button is released, in case a wrapping widget consumed the release frame.
-	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+	if !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 		l.pressStartPlus1 = image.Point{}
 		l.startPressingIndexPlus1 = 0
 		l.dragSrcIndexPlus1 = 0
@@ -2441,7 +2473,7 @@ func (l *listContent[T]) useHighlightedBackgroundColor(context *guigui.Context)
 }
 
 func (l *listContent[T]) selectedItemBackgroundColor(context *guigui.Context, index int) color.Color {
-	return l.itemColorType(context, index).BackgroundColor(context)
+	return l.itemColorType(context, index).BackgroundColor(context, l)
 }
 
 type listBackground1[T comparable] struct {
@@ -2459,9 +2491,9 @@ func (l *listBackground1[T]) Draw(context *guigui.Context, widgetBounds *guigui.
 	switch l.content.backgroundStyle {
 	case ListBackgroundStyleNone:
 	case ListBackgroundStyleContent:
-		clr = draw.ContentBackgroundColor(context.ColorMode(), context.IsEnabled(l))
+		clr = draw.ContentBackgroundColor(colorScheme(context, l), context.IsEnabled(l))
 	case ListBackgroundStyleMenu:
-		clr = draw.MenuBackgroundColor(context.ColorMode(), context.IsEnabled(l))
+		clr = draw.MenuBackgroundColor(colorScheme(context, l), context.IsEnabled(l))
 	}
 	if clr != nil {
 		bounds := widgetBounds.Bounds()
@@ -2491,7 +2523,7 @@ func (l *listBackground1[T]) Draw(context *guigui.Context, widgetBounds *guigui.
 			if !bounds.Overlaps(vb) {
 				continue
 			}
-			clr := draw.ItemStripeBackgroundColor(context.ColorMode())
+			clr := draw.ItemStripeBackgroundColor(colorScheme(context, l))
 			draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
 		}
 	}
@@ -2560,7 +2592,7 @@ func (l *listBackground2[T]) Draw(context *guigui.Context, widgetBounds *guigui.
 	if ok && hoveredItemIndex >= 0 && hoveredItemIndex < l.content.abstractList.ItemCount() && !hoveredItem.Unselectable && l.content.isItemAvailable(hoveredItemIndex) {
 		ct := l.content.itemColorType(context, hoveredItemIndex)
 		if l.content.showsHoverHighlight() || ct == ListItemColorTypeHovered {
-			clr := ct.BackgroundColor(context)
+			clr := ct.BackgroundColor(context, l)
 			bounds := l.content.itemBounds(context, hoveredItemIndex)
 			if l.content.fullWidthHighlight {
 				bounds.Max.X = bounds.Min.X + widgetBounds.Bounds().Dx() - 2*RoundedCornerRadius(context)
@@ -2622,7 +2654,7 @@ func (l *listBackground2[T]) Draw(context *guigui.Context, widgetBounds *guigui.
 			}
 		}
 		if ok {
-			vector.StrokeLine(dst, x0, y, x1, y, 2*float32(context.Scale()), draw.AccentColor(context.ColorMode()), false)
+			vector.StrokeLine(dst, x0, y, x1, y, 2*float32(context.Scale()), draw.AccentColor(colorScheme(context, l)), false)
 		}
 	}
 }
@@ -2674,7 +2706,7 @@ func (l *listFrame) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBou
 	// Draw a header.
 	if l.headerHeight > 0 {
 		bounds := l.headerBounds(context, widgetBounds)
-		draw.DrawRoundedRectWithSharpCorners(context, dst, bounds, draw.ContentBackgroundColor(context.ColorMode(), context.IsEnabled(l)), RoundedCornerRadius(context), draw.Corners{
+		draw.DrawRoundedRectWithSharpCorners(context, dst, bounds, draw.ContentBackgroundColor(colorScheme(context, l), context.IsEnabled(l)), RoundedCornerRadius(context), draw.Corners{
 			TopStart:    false,
 			TopEnd:      false,
 			BottomStart: true,
@@ -2685,14 +2717,14 @@ func (l *listFrame) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBou
 		x1 := float32(bounds.Max.X)
 		y0 := float32(bounds.Max.Y)
 		y1 := float32(bounds.Max.Y)
-		clr := draw.HeaderSeparatorColor(context.ColorMode(), context.IsEnabled(l))
+		clr := draw.HeaderSeparatorColor(colorScheme(context, l), context.IsEnabled(l))
 		vector.StrokeLine(dst, x0, y0, x1, y1, float32(context.Scale()), clr, false)
 	}
 
 	// Draw a footer.
 	if l.footerHeight > 0 {
 		bounds := l.footerBounds(context, widgetBounds)
-		draw.DrawRoundedRectWithSharpCorners(context, dst, bounds, draw.ContentBackgroundColor(context.ColorMode(), context.IsEnabled(l)), RoundedCornerRadius(context), draw.Corners{
+		draw.DrawRoundedRectWithSharpCorners(context, dst, bounds, draw.ContentBackgroundColor(colorScheme(context, l), context.IsEnabled(l)), RoundedCornerRadius(context), draw.Corners{
 			TopStart:    true,
 			TopEnd:      true,
 			BottomStart: false,
@@ -2703,13 +2735,13 @@ func (l *listFrame) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBou
 		x1 := float32(bounds.Max.X)
 		y0 := float32(bounds.Min.Y)
 		y1 := float32(bounds.Min.Y)
-		clr := draw.HeaderSeparatorColor(context.ColorMode(), context.IsEnabled(l))
+		clr := draw.HeaderSeparatorColor(colorScheme(context, l), context.IsEnabled(l))
 		vector.StrokeLine(dst, x0, y0, x1, y1, float32(context.Scale()), clr, false)
 	}
 
 	bounds := widgetBounds.Bounds()
 	border := draw.RoundedRectBorderTypeInset
-	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderType(border))
+	clr1, clr2 := draw.BorderColors(colorScheme(context, l), draw.RoundedRectBorderType(border))
 	borderWidth := listBorderWidth(context)
 	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), borderWidth, border)
 }
@@ -2730,5 +2762,5 @@ func ListItemIndentSize(context *guigui.Context, level int) int {
 }
 
 func listBorderWidth(context *guigui.Context) float32 {
-	return float32(1 * context.Scale())
+	return borderWidth(context)
 }
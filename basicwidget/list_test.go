@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func BenchmarkListItemWriteStateKey(b *testing.B) {
+	items := make([]basicwidget.ListItem[int], 1000)
+	for i := range items {
+		items[i] = basicwidget.ListItem[int]{
+			Text:        "Item",
+			IndentLevel: i % 3,
+			Checked:     i%2 == 0,
+			Value:       i,
+		}
+	}
+
+	b.Run("Handwritten", func(b *testing.B) {
+		w := guigui.NewStateKeyWriter()
+		for b.Loop() {
+			for i := range items {
+				basicwidget.WriteListItemStateKey(w, &items[i])
+			}
+		}
+	})
+	// WriteStruct is what the items were written with before, for comparison.
+	b.Run("WriteStruct", func(b *testing.B) {
+		w := guigui.NewStateKeyWriter()
+		for b.Loop() {
+			for i := range items {
+				w.WriteStruct(&items[i])
+			}
+		}
+	})
+}
+
+// setNonZero sets v to a non-zero value, and reports whether it could.
+// A struct is set by its first exported field that can be set.
+func setNonZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Interface:
+		if v.Type() != reflect.TypeFor[guigui.Widget]() {
+			return false
+		}
+		v.Set(reflect.ValueOf(&basicwidget.Text{}))
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() && setNonZero(v.Field(i)) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+	return true
+}
+
+func TestListItemStateKeyFields(t *testing.T) {
+	var zero basicwidget.ListItem[int]
+	w := guigui.NewStateKeyWriter()
+	basicwidget.WriteListItemStateKey(w, &zero)
+	zeroKey := w.Sum64()
+
+	typ := reflect.TypeFor[basicwidget.ListItem[int]]()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		var item basicwidget.ListItem[int]
+		if !setNonZero(reflect.ValueOf(&item).Elem().Field(i)) {
+			t.Fatalf("field %s: cannot set a non-zero value; update setNonZero", f.Name)
+		}
+		if basicwidget.ListItemEquals(&item, &zero) {
+			t.Errorf("field %s: equals ignores the field", f.Name)
+		}
+
+		w := guigui.NewStateKeyWriter()
+		basicwidget.WriteListItemStateKey(w, &item)
+		// Value identifies the item, and does not affect the state.
+		if got := w.Sum64() != zeroKey; got != (f.Name != "Value") {
+			t.Errorf("field %s: state key changed: got: %t, want: %t", f.Name, got, !got)
+		}
+	}
+}
@@ -8,14 +8,14 @@ import (
 	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	menubarEventItemSelected guigui.EventKey = guigui.GenerateEventKey()
+	menubarEventItemSelected = guigui.GenerateEventKey2[int, int]()
 )
 
 // MenubarItem is a single entry in a [Menubar]. Each entry has a title text;
@@ -82,7 +82,7 @@ func (m *Menubar[T]) PopupMenuAt(index int) *PopupMenu[T] {
 // item is selected. menuIndex identifies the title and itemIndex identifies
 // the selected item within that title's popup.
 func (m *Menubar[T]) OnItemSelected(f func(context *guigui.Context, menuIndex, itemIndex int)) {
-	guigui.SetEventHandler(m, menubarEventItemSelected, f)
+	menubarEventItemSelected.SetHandler(m, f)
 }
 
 // WriteStateKey implements [guigui.Widget.WriteStateKey].
@@ -130,7 +130,7 @@ func (m *Menubar[T]) Build(context *guigui.Context, adder *guigui.ChildAdder) er
 				if m.openIndexPlus1 == idx+1 {
 					m.openIndexPlus1 = 0
 				}
-				guigui.DispatchEvent(m, menubarEventItemSelected, idx, itemIndex)
+				menubarEventItemSelected.Dispatch(m, idx, itemIndex)
 			}
 		}
 		popup.OnItemSelected(m.onItemSelectedHandlers[i])
@@ -209,6 +209,10 @@ func (m *Menubar[T]) Layout(context *guigui.Context, widgetBounds *guigui.Widget
 		// with the highlight pill.
 		bb := m.titles.At(i).backgroundBounds(context, tb)
 		popupBounds := image.Rect(bb.Min.X, bb.Max.Y, bb.Min.X+size.X, bb.Max.Y+size.Y)
+		// In a right-to-left reading direction, the popup extends to the left from the title's right edge.
+		if context.IsRightToLeft(m) {
+			popupBounds = popupBounds.Add(image.Pt(bb.Dx()-size.X, 0))
+		}
 		layouter.LayoutWidget(popup, popupBounds)
 	}
 }
@@ -245,7 +249,7 @@ func (t *menubarTitle[T]) Build(context *guigui.Context, adder *guigui.ChildAdde
 
 	var style TextStyle
 	if t.isOpen() && context.IsEnabled(t) {
-		style.SetColor(ListItemColorTypeHighlighted.TextColor(context))
+		style.SetColor(ListItemColorTypeHighlighted.TextColor(context, t))
 	}
 	t.text.SetBaseStyle(&style)
 	return nil
@@ -260,12 +264,12 @@ func (t *menubarTitle[T]) layout(context *guigui.Context) guigui.LinearLayout {
 	inner := guigui.LinearLayout{
 		Direction: guigui.LayoutDirectionHorizontal,
 		Items:     t.layoutItems,
-		Padding:   ListItemTextPadding(context),
+		Padding:   listItemTextPadding(context, t),
 	}
 	t.wrapperLayoutItems = slices.Delete(t.wrapperLayoutItems, 0, len(t.wrapperLayoutItems))
 	t.wrapperLayoutItems = append(t.wrapperLayoutItems, guigui.LinearLayoutItem{
 		Layout: inner,
-		Size:   guigui.FixedSize(UnitSize(context)),
+		Size:   guigui.FixedSize(controlUnitSize(context, t)),
 	})
 	return guigui.LinearLayout{
 		Direction: guigui.LayoutDirectionVertical,
@@ -294,7 +298,7 @@ func (t *menubarTitle[T]) HandlePointingInput(context *guigui.Context, widgetBou
 	}
 
 	// Click: toggle this title's popup.
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		if t.isOpen() {
 			t.menubar.requestOpen(-1)
 		} else {
@@ -319,17 +323,17 @@ func (t *menubarTitle[T]) CursorShape(context *guigui.Context, widgetBounds *gui
 
 // backgroundBounds returns the rectangle for the highlighted background drawn
 // behind the title text. The height matches a list item's highlighted
-// background (text height plus top/bottom of [ListItemTextPadding]) and the
+// background (text height plus top/bottom of the list item text padding) and the
 // rectangle is vertically centered within the title's full bounds.
 func (t *menubarTitle[T]) backgroundBounds(context *guigui.Context, bounds image.Rectangle) image.Rectangle {
-	p := ListItemTextPadding(context)
+	p := listItemTextPadding(context, t)
 	h := t.text.Measure(context, guigui.Constraints{}).Y + p.Top + p.Bottom
 	y := bounds.Min.Y + (bounds.Dy()-h)/2
 	return image.Rect(bounds.Min.X, y, bounds.Max.X, y+h)
 }
 
 func (t *menubarTitle[T]) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
-	dst.Fill(draw.BackgroundColor(context.ColorMode()))
+	dst.Fill(draw.BackgroundColor(colorScheme(context, t)))
 
 	if !context.IsEnabled(t) {
 		return
@@ -337,6 +341,6 @@ func (t *menubarTitle[T]) Draw(context *guigui.Context, widgetBounds *guigui.Wid
 	if !t.isOpen() {
 		return
 	}
-	clr := draw.ItemHighlightedBackgroundColor(context.ColorMode())
+	clr := draw.ItemHighlightedBackgroundColor(colorScheme(context, t))
 	draw.DrawRoundedRect(context, dst, t.backgroundBounds(context, widgetBounds.Bounds()), clr, RoundedCornerRadius(context))
 }
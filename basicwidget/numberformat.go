@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget
+
+import (
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// NumberFormat converts the values of a [NumberInput] to texts and back.
+type NumberFormat interface {
+	// FormatNumber returns the text to display for value.
+	FormatNumber(value *big.Int) string
+
+	// ParseNumber returns the value that text represents.
+	// ParseNumber returns false when text is not a number.
+	ParseNumber(text string) (*big.Int, bool)
+}
+
+// NewLocaleNumberFormat returns the [NumberFormat] for locale.
+//
+// The format uses the locale's native digits and minus sign, and the locale's
+// grouping separators when grouping is true (e.g. 1,234,567 in English,
+// 1.234.567 in German, 12,34,567 in Hindi and ١٬٢٣٤ in Arabic).
+//
+// Parsing is lenient: the text can have the digits of any script, the
+// locale's grouping separators or spaces between the groups of digits, and a
+// plus or minus sign, ASCII or not, before or after the digits.
+// A separator is accepted only where the locale groups digits, so that a
+// decimal like "1,5" in English is not taken as 15.
+func NewLocaleNumberFormat(locale language.Tag, grouping bool) NumberFormat {
+	return &localeNumberFormat{
+		symbols:  numberSymbolsForLocale(locale),
+		grouping: grouping,
+	}
+}
+
+// defaultNumberFormat is the format of a number input whose locale is not
+// resolved yet. This uses ASCII digits without grouping separators.
+var defaultNumberFormat NumberFormat = &localeNumberFormat{
+	symbols: &asciiNumberSymbols,
+}
+
+// numberSymbols is the set of symbols to write numbers in a locale.
+type numberSymbols struct {
+	digits [10]string
+
+	// group is the grouping separator, or an empty string if the locale does not group digits.
+	group string
+
+	// primaryGroupSize is the number of the digits in the rightmost group.
+	primaryGroupSize int
+
+	// secondaryGroupSize is the number of the digits in the other groups.
+	secondaryGroupSize int
+
+	minusPrefix string
+	minusSuffix string
+}
+
+var asciiNumberSymbols = numberSymbols{
+	digits:      [10]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"},
+	minusPrefix: "-",
+}
+
+// theNumberSymbols caches the symbols for each locale.
+var theNumberSymbols = map[language.Tag]*numberSymbols{}
+
+// numberSymbolsForLocale returns the symbols for locale.
+//
+// golang.org/x/text does not expose the locales' symbols, and cannot format
+// a *big.Int. Instead, the symbols are taken from a few numbers that
+// golang.org/x/text formats.
+func numberSymbolsForLocale(locale language.Tag) *numberSymbols {
+	if s, ok := theNumberSymbols[locale]; ok {
+		return s
+	}
+
+	p := message.NewPrinter(locale)
+	s := &numberSymbols{}
+	for i := range s.digits {
+		s.digits[i] = p.Sprint(number.Decimal(i))
+		if s.digits[i] == "" {
+			s.digits = asciiNumberSymbols.digits
+			break
+		}
+	}
+
+	// Take the grouping separator and the group sizes from the separated
+	// digits, e.g. [1, 234, 567, 890] or [1, 23, 45, 67, 890].
+	var groupSizes []int
+	var n int
+	grouped := p.Sprint(number.Decimal(1234567890))
+	for grouped != "" {
+		if d := s.digitPrefix(grouped); d >= 0 {
+			grouped = grouped[len(s.digits[d]):]
+			n++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(grouped)
+		if s.group == "" {
+			s.group = grouped[:size]
+		}
+		grouped = grouped[size:]
+		groupSizes = append(groupSizes, n)
+		n = 0
+	}
+	groupSizes = append(groupSizes, n)
+	if len(groupSizes) >= 2 {
+		s.primaryGroupSize = groupSizes[len(groupSizes)-1]
+		s.secondaryGroupSize = groupSizes[len(groupSizes)-2]
+		if len(groupSizes) == 2 {
+			s.secondaryGroupSize = s.primaryGroupSize
+		}
+	}
+	if s.primaryGroupSize == 0 || s.secondaryGroupSize == 0 {
+		s.group = ""
+	}
+
+	// The minus sign might be with bidi marks, e.g. U+061C and '-' in Arabic.
+	negative := p.Sprint(number.Decimal(-1))
+	if i := strings.Index(negative, s.digits[1]); i >= 0 {
+		s.minusPrefix = negative[:i]
+		s.minusSuffix = negative[i+len(s.digits[1]):]
+	}
+	if s.minusPrefix == "" && s.minusSuffix == "" {
+		s.minusPrefix = asciiNumberSymbols.minusPrefix
+	}
+
+	theNumberSymbols[locale] = s
+	return s
+}
+
+// digitPrefix returns the value of the digit at the start of str, or -1 if str
+// does not start with a digit of s.
+func (s *numberSymbols) digitPrefix(str string) int {
+	for i, d := range s.digits {
+		if strings.HasPrefix(str, d) {
+			return i
+		}
+	}
+	return -1
+}
+
+type localeNumberFormat struct {
+	symbols  *numberSymbols
+	grouping bool
+}
+
+// FormatNumber implements [NumberFormat.FormatNumber].
+func (l *localeNumberFormat) FormatNumber(value *big.Int) string {
+	s := l.symbols
+	str := value.String()
+	negative := strings.HasPrefix(str, "-")
+	if negative {
+		str = str[1:]
+	}
+
+	var b strings.Builder
+	if negative {
+		b.WriteString(s.minusPrefix)
+	}
+	group := l.grouping && s.group != ""
+	for i := 0; i < len(str); i++ {
+		b.WriteString(s.digits[str[i]-'0'])
+		if !group {
+			continue
+		}
+		// rest is the number of the digits after this one.
+		rest := len(str) - i - 1
+		if rest == 0 {
+			continue
+		}
+		if rest == s.primaryGroupSize || rest > s.primaryGroupSize && (rest-s.primaryGroupSize)%s.secondaryGroupSize == 0 {
+			b.WriteString(s.group)
+		}
+	}
+	if negative {
+		b.WriteString(s.minusSuffix)
+	}
+	return b.String()
+}
+
+// ParseNumber implements [NumberFormat.ParseNumber].
+func (l *localeNumberFormat) ParseNumber(text string) (*big.Int, bool) {
+	s := l.symbols
+	text = strings.TrimSpace(text)
+
+	var digits []byte
+	var negative bool
+	var signSeen bool
+	// trailingSign reports whether the sign follows the digits.
+	var trailingSign bool
+	// separated reports whether a separator follows the last digit.
+	var separated bool
+	// groups is the numbers of the digits between the separators, except for the last group.
+	var groups []int
+	// run is the number of the digits after the last separator.
+	var run int
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		if s.group != "" && strings.HasPrefix(text, s.group) {
+			size = len(s.group)
+			r = ' '
+		}
+		text = text[size:]
+
+		if v, ok := digitValue(r); ok {
+			// A digit after the trailing sign is not allowed.
+			if trailingSign {
+				return nil, false
+			}
+			digits = append(digits, byte('0'+v))
+			separated = false
+			run++
+			continue
+		}
+		switch {
+		case isBidiControl(r):
+		case unicode.IsSpace(r):
+			// A separator is allowed only between digits.
+			if len(digits) == 0 || separated {
+				return nil, false
+			}
+			separated = true
+			groups = append(groups, run)
+			run = 0
+		case r == '+' || r == '\ufe62' || r == '\uff0b':
+			if signSeen {
+				return nil, false
+			}
+			signSeen = true
+			trailingSign = len(digits) > 0
+		case r == '-' || r == '\u2212' || r == '\ufe63' || r == '\uff0d':
+			if signSeen {
+				return nil, false
+			}
+			signSeen = true
+			trailingSign = len(digits) > 0
+			negative = true
+		default:
+			return nil, false
+		}
+	}
+	if len(digits) == 0 || separated {
+		return nil, false
+	}
+	if !s.validGroups(append(groups, run)) {
+		return nil, false
+	}
+
+	var v big.Int
+	if _, ok := v.SetString(string(digits), 10); !ok {
+		return nil, false
+	}
+	if negative {
+		v.Neg(&v)
+	}
+	return &v, true
+}
+
+// validGroups reports whether the numbers of the digits between the separators, from the left,
+// follow the group sizes of the locale. A locale that does not group digits groups them by three.
+func (s *numberSymbols) validGroups(groups []int) bool {
+	if len(groups) == 1 {
+		return true
+	}
+	primary, secondary := s.primaryGroupSize, s.secondaryGroupSize
+	if primary == 0 || secondary == 0 {
+		primary, secondary = 3, 3
+	}
+	last := len(groups) - 1
+	if groups[last] != primary {
+		return false
+	}
+	for _, n := range groups[1:last] {
+		if n != secondary {
+			return false
+		}
+	}
+	// The leftmost group can be shorter.
+	return groups[0] <= secondary
+}
+
+// digitValue returns the value of the decimal digit r in any script.
+func digitValue(r rune) (int, bool) {
+	if '0' <= r && r <= '9' {
+		return int(r - '0'), true
+	}
+	if !unicode.Is(unicode.Nd, r) {
+		return 0, false
+	}
+	// Each range of the decimal digits starts with a zero, and its length
+	// is a multiple of 10.
+	for _, rng := range unicode.Nd.R16 {
+		if rune(rng.Lo) <= r && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10, true
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if rune(rng.Lo) <= r && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10, true
+		}
+	}
+	return 0, false
+}
+
+// isBidiControl reports whether r is a bidi mark or a bidi control
+// character, which locales put around signs.
+func isBidiControl(r rune) bool {
+	switch {
+	case r == '\u061c', r == '\u200e', r == '\u200f':
+		return true
+	case '\u202a' <= r && r <= '\u202e':
+		return true
+	case '\u2066' <= r && r <= '\u2069':
+		return true
+	}
+	return false
+}
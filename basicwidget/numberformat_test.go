@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"math/big"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestLocaleNumberFormatFormatNumber(t *testing.T) {
+	testCases := []struct {
+		locale   string
+		grouping bool
+		value    string
+		want     string
+	}{
+		{locale: "en", grouping: true, value: "0", want: "0"},
+		{locale: "en", grouping: true, value: "123", want: "123"},
+		{locale: "en", grouping: true, value: "1234", want: "1,234"},
+		{locale: "en", grouping: true, value: "-1234567", want: "-1,234,567"},
+		{locale: "en", grouping: false, value: "-1234567", want: "-1234567"},
+		{locale: "en", grouping: true, value: "123456789012345678901234567890", want: "123,456,789,012,345,678,901,234,567,890"},
+		{locale: "de", grouping: true, value: "1234567", want: "1.234.567"},
+		{locale: "fr", grouping: true, value: "1234567", want: "1\u00a0234\u00a0567"},
+		{locale: "hi-IN", grouping: true, value: "1234567", want: "12,34,567"},
+		{locale: "ar", grouping: true, value: "1234", want: "١٬٢٣٤"},
+		{locale: "ar", grouping: true, value: "-12", want: "\u061c-١٢"},
+		{locale: "fa", grouping: false, value: "-1234", want: "\u200e−۱۲۳۴"},
+	}
+	for _, tc := range testCases {
+		v, ok := new(big.Int).SetString(tc.value, 10)
+		if !ok {
+			t.Fatalf("invalid value: %q", tc.value)
+		}
+		f := basicwidget.NewLocaleNumberFormat(language.MustParse(tc.locale), tc.grouping)
+		if got := f.FormatNumber(v); got != tc.want {
+			t.Errorf("FormatNumber(%s) in %s (grouping: %t): got: %q, want: %q", tc.value, tc.locale, tc.grouping, got, tc.want)
+		}
+	}
+}
+
+func TestLocaleNumberFormatParseNumber(t *testing.T) {
+	testCases := []struct {
+		locale string
+		text   string
+		want   string
+		ok     bool
+	}{
+		{locale: "en", text: "1234", want: "1234", ok: true},
+		{locale: "en", text: " 1,234,567 ", want: "1234567", ok: true},
+		{locale: "en", text: "1 234", want: "1234", ok: true},
+		{locale: "en", text: "+12", want: "12", ok: true},
+		{locale: "en", text: "-12", want: "-12", ok: true},
+		{locale: "en", text: "12-", want: "-12", ok: true},
+		{locale: "en", text: "−12", want: "-12", ok: true},
+		{locale: "en", text: "－１２", want: "-12", ok: true},
+		{locale: "en", text: "١٢٣", want: "123", ok: true},
+		{locale: "en", text: "123456789012345678901234567890", want: "123456789012345678901234567890", ok: true},
+		{locale: "de", text: "1.234.567", want: "1234567", ok: true},
+		{locale: "fr", text: "1\u202f234", want: "1234", ok: true},
+		{locale: "ar", text: "\u061c-١٬٢٣٤", want: "-1234", ok: true},
+		{locale: "fa", text: "\u200e−۱۲۳۴", want: "-1234", ok: true},
+		{locale: "en", text: "", ok: false},
+		{locale: "en", text: "-", ok: false},
+		{locale: "en", text: "abc", ok: false},
+		{locale: "en", text: "1.5", ok: false},
+		{locale: "en", text: ",12", ok: false},
+		{locale: "en", text: "12,", ok: false},
+		{locale: "en", text: "1,,2", ok: false},
+		{locale: "en", text: "--12", ok: false},
+		{locale: "en", text: "-12-", ok: false},
+		{locale: "en", text: "1-2", ok: false},
+		{locale: "en", text: "1,5", ok: false},
+		{locale: "en", text: "12,34", ok: false},
+		{locale: "en", text: "1234,567", ok: false},
+		{locale: "en", text: "1,23,456", ok: false},
+		{locale: "en", text: "1 5", ok: false},
+		{locale: "de", text: "1.5", ok: false},
+		{locale: "de", text: "1.2345", ok: false},
+		{locale: "hi-IN", text: "12,34,567", want: "1234567", ok: true},
+		{locale: "hi-IN", text: "1,234,567", ok: false},
+	}
+	for _, tc := range testCases {
+		f := basicwidget.NewLocaleNumberFormat(language.MustParse(tc.locale), true)
+		got, ok := f.ParseNumber(tc.text)
+		if ok != tc.ok {
+			t.Errorf("ParseNumber(%q) in %s: got ok: %t, want: %t", tc.text, tc.locale, ok, tc.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got.String() != tc.want {
+			t.Errorf("ParseNumber(%q) in %s: got: %s, want: %s", tc.text, tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestLocaleNumberFormatRoundTrip(t *testing.T) {
+	for _, locale := range []string{"en", "de", "fr", "de-CH", "hi-IN", "bn", "ar", "fa", "he", "ja", "th-u-nu-thai"} {
+		f := basicwidget.NewLocaleNumberFormat(language.MustParse(locale), true)
+		for _, value := range []int64{0, 7, -7, 1000, -1234567, 9876543210} {
+			v := big.NewInt(value)
+			text := f.FormatNumber(v)
+			got, ok := f.ParseNumber(text)
+			if !ok || got.Cmp(v) != 0 {
+				t.Errorf("ParseNumber(FormatNumber(%d)) in %s: got: %v, %t (text: %q)", value, locale, got, ok, text)
+			}
+		}
+	}
+}
@@ -14,10 +14,10 @@ import (
 )
 
 var (
-	numberInputEventValueChanged       guigui.EventKey = guigui.GenerateEventKey()
-	numberInputEventValueChangedBigInt guigui.EventKey = guigui.GenerateEventKey()
-	numberInputEventValueChangedInt64  guigui.EventKey = guigui.GenerateEventKey()
-	numberInputEventValueChangedUint64 guigui.EventKey = guigui.GenerateEventKey()
+	numberInputEventValueChanged       = guigui.GenerateEventKey2[int, bool]()
+	numberInputEventValueChangedBigInt = guigui.GenerateEventKey2[*big.Int, bool]()
+	numberInputEventValueChangedInt64  = guigui.GenerateEventKey2[int64, bool]()
+	numberInputEventValueChangedUint64 = guigui.GenerateEventKey2[uint64, bool]()
 )
 
 var (
@@ -44,6 +44,7 @@ type NumberInput struct {
 	downButton Button
 
 	abstractNumberInput abstractNumberInput
+	numberFormat        NumberFormat
 
 	onValueChanged       func(value int, committed bool)
 	onValueChangedBigInt func(value *big.Int, committed bool)
@@ -88,19 +89,19 @@ func (n *NumberInput) SetSupportText(text string) {
 }
 
 func (n *NumberInput) OnValueChanged(f func(context *guigui.Context, value int, committed bool)) {
-	guigui.SetEventHandler(n, numberInputEventValueChanged, f)
+	numberInputEventValueChanged.SetHandler(n, f)
 }
 
 func (n *NumberInput) OnValueChangedBigInt(f func(context *guigui.Context, value *big.Int, committed bool)) {
-	guigui.SetEventHandler(n, numberInputEventValueChangedBigInt, f)
+	numberInputEventValueChangedBigInt.SetHandler(n, f)
 }
 
 func (n *NumberInput) OnValueChangedInt64(f func(context *guigui.Context, value int64, committed bool)) {
-	guigui.SetEventHandler(n, numberInputEventValueChangedInt64, f)
+	numberInputEventValueChangedInt64.SetHandler(n, f)
 }
 
 func (n *NumberInput) OnValueChangedUint64(f func(context *guigui.Context, value uint64, committed bool)) {
-	guigui.SetEventHandler(n, numberInputEventValueChangedUint64, f)
+	numberInputEventValueChangedUint64.SetHandler(n, f)
 }
 
 func (n *NumberInput) OnHandleButtonInput(f func(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult) {
@@ -211,6 +212,19 @@ func (n *NumberInput) SetStepUint64(step uint64) {
 	n.abstractNumberInput.SetStepUint64(step)
 }
 
+// NumberFormat returns the format set by [NumberInput.SetNumberFormat], or nil if none is set.
+func (n *NumberInput) NumberFormat() NumberFormat {
+	return n.numberFormat
+}
+
+// SetNumberFormat sets the format to display the value and to parse the input text.
+//
+// If format is nil, which is the default, the format of the context's first
+// locale with grouping separators is used. See [NewLocaleNumberFormat].
+func (n *NumberInput) SetNumberFormat(format NumberFormat) {
+	n.numberFormat = format
+}
+
 func (n *NumberInput) CommitWithCurrentInputValue() {
 	n.textInput.CommitWithCurrentInputValue()
 }
@@ -234,28 +248,28 @@ func (n *NumberInput) Build(context *guigui.Context, adder *guigui.ChildAdder) e
 
 	if n.onValueChanged == nil {
 		n.onValueChanged = func(value int, committed bool) {
-			guigui.DispatchEvent(n, numberInputEventValueChanged, value, committed)
+			numberInputEventValueChanged.Dispatch(n, value, committed)
 		}
 	}
 	n.abstractNumberInput.OnValueChanged(n.onValueChanged)
 
 	if n.onValueChangedBigInt == nil {
 		n.onValueChangedBigInt = func(value *big.Int, committed bool) {
-			guigui.DispatchEvent(n, numberInputEventValueChangedBigInt, value, committed)
+			numberInputEventValueChangedBigInt.Dispatch(n, value, committed)
 		}
 	}
 	n.abstractNumberInput.OnValueChangedBigInt(n.onValueChangedBigInt)
 
 	if n.onValueChangedInt64 == nil {
 		n.onValueChangedInt64 = func(value int64, committed bool) {
-			guigui.DispatchEvent(n, numberInputEventValueChangedInt64, value, committed)
+			numberInputEventValueChangedInt64.Dispatch(n, value, committed)
 		}
 	}
 	n.abstractNumberInput.OnValueChangedInt64(n.onValueChangedInt64)
 
 	if n.onValueChangedUint64 == nil {
 		n.onValueChangedUint64 = func(value uint64, committed bool) {
-			guigui.DispatchEvent(n, numberInputEventValueChangedUint64, value, committed)
+			numberInputEventValueChangedUint64.Dispatch(n, value, committed)
 		}
 	}
 	n.abstractNumberInput.OnValueChangedUint64(n.onValueChangedUint64)
@@ -271,6 +285,12 @@ func (n *NumberInput) Build(context *guigui.Context, adder *guigui.ChildAdder) e
 	}
 	n.abstractNumberInput.OnValueChangedString(n.onValueChangedString)
 
+	format := n.numberFormat
+	if format == nil {
+		format = NewLocaleNumberFormat(context.FirstLocale(), true)
+	}
+	n.abstractNumberInput.setNumberFormat(format)
+
 	n.textInput.SetValue(n.abstractNumberInput.ValueString())
 	n.textInput.SetHorizontalAlign(HorizontalAlignRight)
 	var style TextStyle
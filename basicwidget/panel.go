@@ -12,10 +12,11 @@ import (
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	panelEventScroll guigui.EventKey = guigui.GenerateEventKey()
+	panelEventScroll = guigui.GenerateEventKey2[float64, float64]()
 )
 
 type PanelStyle int
@@ -169,7 +170,7 @@ type panel struct {
 }
 
 func (p *panel) OnScroll(callback func(context *guigui.Context, offsetX, offsetY float64)) {
-	guigui.SetEventHandler(p, panelEventScroll, callback)
+	panelEventScroll.SetHandler(p, callback)
 }
 
 func (p *panel) SetContent(widget guigui.Widget) {
@@ -363,7 +364,7 @@ func (p *panel) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBound
 	}
 
 	if p.offsetX != oldOffsetX || p.offsetY != oldOffsetY {
-		guigui.DispatchEvent(p, panelEventScroll, p.offsetX, p.offsetY)
+		panelEventScroll.Dispatch(p, p.offsetX, p.offsetY)
 	}
 
 	p.border.setPanelBounds(bounds)
@@ -381,7 +382,7 @@ func (p *panel) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds,
 	p.onceDraw = true
 	switch p.backgroundStyle {
 	case PanelBackgroundStyleSecondary:
-		dst.Fill(draw.BackgroundSecondaryColor(context.ColorMode()))
+		dst.Fill(draw.BackgroundSecondaryColor(colorScheme(context, p)))
 	}
 }
 
@@ -427,7 +428,7 @@ func (p *panel) isHBarVisible(context *guigui.Context, widgetBounds *guigui.Widg
 	if !widgetBounds.IsHitAtCursor() {
 		return false
 	}
-	pt := image.Pt(ebiten.CursorPosition())
+	pt := image.Pt(inputsource.CursorPosition())
 	return pt.In(p.horizontalBarBounds(context, widgetBounds))
 }
 
@@ -441,7 +442,7 @@ func (p *panel) isVBarVisible(context *guigui.Context, widgetBounds *guigui.Widg
 	if !widgetBounds.IsHitAtCursor() {
 		return false
 	}
-	pt := image.Pt(ebiten.CursorPosition())
+	pt := image.Pt(inputsource.CursorPosition())
 	return pt.In(p.verticalBarBounds(context, widgetBounds))
 }
 
@@ -522,7 +523,7 @@ func (p *panel) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds)
 	if offsetChanged {
 		p.offsetX, p.offsetY = p.adjustOffset(context, widgetBounds, p.offsetX, p.offsetY)
 		if p.offsetX != oldOffsetX || p.offsetY != oldOffsetY {
-			guigui.DispatchEvent(p, panelEventScroll, p.offsetX, p.offsetY)
+			panelEventScroll.Dispatch(p, p.offsetX, p.offsetY)
 			// offsetX/offsetY are in the panel's WriteStateKey, so the rebuild
 			// that re-invokes Layout (see #298) is triggered automatically.
 		}
@@ -649,7 +650,7 @@ func (p *panelBorder) Draw(context *guigui.Context, widgetBounds *guigui.WidgetB
 	}
 
 	// Render borders.
-	strokeWidth := float32(1 * context.Scale())
+	strokeWidth := borderWidth(context)
 	bounds := widgetBounds.Bounds()
 	x0 := float32(bounds.Min.X)
 	x1 := float32(bounds.Max.X)
@@ -661,7 +662,7 @@ func (p *panelBorder) Draw(context *guigui.Context, widgetBounds *guigui.WidgetB
 		offsetX, offsetY = p.panel.offset()
 		r = p.panel.scrollRange(context, p.panelBoundsRect)
 	}
-	clr := draw.DividerColor(context.ColorMode())
+	clr := draw.DividerColor(colorScheme(context, p))
 	if (p.panel != nil && p.autoBorder && offsetX < float64(r.Max.X)) || p.borders.Start {
 		vector.StrokeLine(dst, x0+strokeWidth/2, y0, x0+strokeWidth/2, y1, strokeWidth, clr, false)
 	}
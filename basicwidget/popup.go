@@ -8,16 +8,17 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	popupEventOpen  guigui.EventKey = guigui.GenerateEventKey()
-	popupEventClose guigui.EventKey = guigui.GenerateEventKey()
+	popupEventOpen  = guigui.GenerateEventKey0()
+	popupEventClose = guigui.GenerateEventKey1[PopupCloseReason]()
 )
 
 // openPopups holds every currently-open popup, used to coordinate subordinate
@@ -36,6 +37,10 @@ func easeOutQuad(t float64) float64 {
 }
 
 func popupMaxOpeningCount() int {
+	// Finish the animation in one tick when the desktop asks to reduce motion.
+	if desktopsettings.Get().AnimationsDisabled {
+		return 1
+	}
 	return ebiten.TPS() / 10
 }
 
@@ -255,7 +260,7 @@ func (p *popup) recordSubordinateTrigger() {
 }
 
 func (p *popup) setOnOpen(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(p, popupEventOpen, f)
+	popupEventOpen.SetHandler(p, f)
 }
 
 func (p *popup) SetContent(widget guigui.Widget) {
@@ -279,14 +284,14 @@ func (p *popup) actualContentBounds(context *guigui.Context, bgBounds image.Rect
 			pt = pt.Add(image.Pt(0, dy))
 		} else {
 			srcPt := p.contentBounds.Min
-			switch p.drawerEdge {
-			case DrawerEdgeStart:
+			switch {
+			case p.drawerEdge.isLeft(context, p):
 				srcPt.X = bgBounds.Min.X - p.contentBounds.Dx()
-			case DrawerEdgeTop:
+			case p.drawerEdge == DrawerEdgeTop:
 				srcPt.Y = bgBounds.Min.Y - p.contentBounds.Dy()
-			case DrawerEdgeEnd:
+			case p.drawerEdge.isRight(context, p):
 				srcPt.X = bgBounds.Max.X
-			case DrawerEdgeBottom:
+			case p.drawerEdge == DrawerEdgeBottom:
 				srcPt.Y = bgBounds.Max.Y
 			}
 			dstPt := p.contentPosition
@@ -338,7 +343,7 @@ func (p *popup) SetDrawerEdge(edge DrawerEdge) {
 }
 
 func (p *popup) OnClose(f func(context *guigui.Context, reason PopupCloseReason)) {
-	guigui.SetEventHandler(p, popupEventClose, f)
+	popupEventClose.SetHandler(p, f)
 }
 
 func (p *popup) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -406,14 +411,14 @@ func (p *popup) tryCloseByClickingOutside(context *guigui.Context) bool {
 	if !p.closeByClickingOutside {
 		return false
 	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		if image.Pt(ebiten.CursorPosition()).In(p.closeByClickingOutsideExcludedRect) {
+	if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) || inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		if image.Pt(inputsource.CursorPosition()).In(p.closeByClickingOutsideExcludedRect) {
 			return false
 		}
 		p.close(context, PopupCloseReasonClickOutside)
 		// Continue handling inputs so that clicking a right button can be handled by other widgets.
 		// This is a little tricky, but this is needed to reopen context menu popups.
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		if inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 			return true
 		}
 	}
@@ -426,7 +431,7 @@ func (p *popup) HandlePointingInput(context *guigui.Context, widgetBounds *guigu
 	}
 
 	bounds := p.bounds(context)
-	if !image.Pt(ebiten.CursorPosition()).In(bounds) {
+	if !image.Pt(inputsource.CursorPosition()).In(bounds) {
 		return guigui.HandleInputResult{}
 	}
 
@@ -538,7 +543,7 @@ func (p *popup) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds)
 				p.close(context, PopupCloseReasonReopen)
 				p.openAfterClose = true
 			} else {
-				guigui.DispatchEvent(p, popupEventOpen)
+				popupEventOpen.Dispatch(p)
 				p.showing = true
 				p.hiding = false
 			}
@@ -583,7 +588,7 @@ func (p *popup) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds)
 			p.hiding = false
 			// The popup is now fully closed, so drop it from the open set.
 			delete(openPopups, p)
-			guigui.DispatchEvent(p, popupEventClose, p.closeReason)
+			popupEventClose.Dispatch(p, p.closeReason)
 			p.closeReason = PopupCloseReasonNone
 			if p.openAfterClose {
 				if p.hasNextContentPosition {
@@ -703,7 +708,7 @@ func (p *popupContent) HandlePointingInput(context *guigui.Context, widgetBounds
 
 func (p *popupContent) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
-	clr := draw.PopupBackgroundColorFromTint(context.ColorMode(), p.tint)
+	clr := draw.PopupBackgroundColorFromTint(colorScheme(context, p), p.tint)
 	if p.style != popupStyleDrawer {
 		draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
 	} else {
@@ -729,29 +734,29 @@ func (p *popupFrame) setDrawerEdge(edge DrawerEdge) {
 func (p *popupFrame) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
 
-	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset)
+	clr1, clr2 := draw.BorderColors(colorScheme(context, p), draw.RoundedRectBorderTypeOutset)
 	if p.style == popupStyleDrawer {
 		u := UnitSize(context)
-		switch p.drawerEdge {
-		case DrawerEdgeStart:
+		switch {
+		case p.drawerEdge.isLeft(context, p):
 			bounds.Min.X -= u
 			bounds.Min.Y -= u
 			bounds.Max.Y += u
-		case DrawerEdgeTop:
+		case p.drawerEdge == DrawerEdgeTop:
 			bounds.Min.X -= u
 			bounds.Min.Y -= u
 			bounds.Max.X += u
-		case DrawerEdgeEnd:
+		case p.drawerEdge.isRight(context, p):
 			bounds.Max.X += u
 			bounds.Min.Y -= u
 			bounds.Max.Y += u
-		case DrawerEdgeBottom:
+		case p.drawerEdge == DrawerEdgeBottom:
 			bounds.Min.X -= u
 			bounds.Max.Y += u
 			bounds.Max.X += u
 		}
 	}
-	width := float32(1 * context.Scale())
+	width := borderWidth(context)
 	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), width, draw.RoundedRectBorderTypeOutset)
 }
 
@@ -840,7 +845,7 @@ func (p *popupDarkBackground) SetOpeningRate(rate float64) {
 func (p *popupDarkBackground) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
 
-	clr := draw.PopupDarkBackgroundColor(context.ColorMode(), p.openingRate)
+	clr := draw.PopupDarkBackgroundColor(colorScheme(context, p), p.openingRate)
 	vector.FillRect(dst, float32(bounds.Min.X), float32(bounds.Min.Y), float32(bounds.Dx()), float32(bounds.Dy()), clr, false)
 }
 
@@ -876,7 +881,7 @@ func (p *popupShadow) Draw(context *guigui.Context, widgetBounds *guigui.WidgetB
 	bounds.Min.Y -= int(8 * context.Scale())
 	bounds.Max.Y += int(16 * context.Scale())
 	// TODO: When openingRate < 1, only the edges should be rendered.
-	clr := draw.ShadowColor(context.ColorMode())
+	clr := draw.ShadowColor(colorScheme(context, p))
 	alpha := 0.25
 	if p.style != popupStyleDrawer {
 		// As the popup itself is also transparent, the shadow should be more transparent.
@@ -7,13 +7,13 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	popupMenuEventItemSelected guigui.EventKey = guigui.GenerateEventKey()
+	popupMenuEventItemSelected = guigui.GenerateEventKey1[int]()
 )
 
 type PopupMenuItem[T comparable] struct {
@@ -45,7 +45,7 @@ type PopupMenu[T comparable] struct {
 }
 
 func (p *PopupMenu[T]) OnItemSelected(f func(context *guigui.Context, index int)) {
-	guigui.SetEventHandler(p, popupMenuEventItemSelected, f)
+	popupMenuEventItemSelected.SetHandler(p, f)
 }
 
 func (p *PopupMenu[T]) OnClose(f func(context *guigui.Context, reason PopupCloseReason)) {
@@ -82,7 +82,7 @@ func (p *PopupMenu[T]) Build(context *guigui.Context, adder *guigui.ChildAdder)
 	if p.onItemSelected == nil {
 		p.onItemSelected = func(context *guigui.Context, index int) {
 			p.popup.SetOpen(false)
-			guigui.DispatchEvent(p, popupMenuEventItemSelected, index)
+			popupMenuEventItemSelected.Dispatch(p, index)
 		}
 	}
 	list.OnItemSelected(p.onItemSelected)
@@ -96,7 +96,7 @@ func (p *PopupMenu[T]) Build(context *guigui.Context, adder *guigui.ChildAdder)
 
 // HandleButtonInput implements [guigui.Widget.HandleButtonInput].
 func (p *PopupMenu[T]) HandleButtonInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
-	if p.popup.IsOpen() && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+	if p.popup.IsOpen() && inputsource.IsKeyJustPressed(ebiten.KeyEscape) {
 		p.popup.SetOpen(false)
 		return guigui.HandleInputByWidget(p)
 	}
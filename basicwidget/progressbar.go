@@ -106,10 +106,10 @@ func (p *ProgressBar) Draw(context *guigui.Context, widgetBounds *guigui.WidgetB
 	cy := (b.Min.Y + b.Max.Y) / 2
 	barBounds := image.Rect(b.Min.X, cy-r, b.Max.X, cy+r)
 
-	cm := context.ColorMode()
-	draw.DrawRoundedRect(context, dst, barBounds, draw.TrackColor(cm, false, false), r)
-	borderClr1, borderClr2 := draw.BorderColors(cm, draw.RoundedRectBorderTypeInset)
-	draw.DrawRoundedRectBorder(context, dst, barBounds, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+	cs := colorScheme(context, p)
+	draw.DrawRoundedRect(context, dst, barBounds, draw.TrackColor(cs, false, false), r)
+	borderClr1, borderClr2 := draw.BorderColors(cs, draw.RoundedRectBorderTypeInset)
+	draw.DrawRoundedRectBorder(context, dst, barBounds, borderClr1, borderClr2, r, borderWidth(context), draw.RoundedRectBorderTypeInset)
 
 	rate := p.abstractNumberInput.Rate()
 	if math.IsNaN(rate) {
@@ -121,7 +121,7 @@ func (p *ProgressBar) Draw(context *guigui.Context, widgetBounds *guigui.WidgetB
 	}
 	filledBounds := barBounds
 	filledBounds.Max.X = filledBounds.Min.X + w
-	draw.DrawRoundedRect(context, dst, filledBounds, draw.TrackFillColor(cm, context.IsEnabled(p)), r)
+	draw.DrawRoundedRect(context, dst, filledBounds, draw.TrackFillColor(cs, context.IsEnabled(p)), r)
 }
 
 func (p *ProgressBar) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
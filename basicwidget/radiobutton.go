@@ -11,14 +11,14 @@ import (
 	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	radioButtonGroupEventItemSelected guigui.EventKey = guigui.GenerateEventKey()
+	radioButtonGroupEventItemSelected = guigui.GenerateEventKey1[int]()
 )
 
 // RadioButtonGroup is a group of radio buttons.
@@ -56,7 +56,7 @@ func (r *RadioButtonGroup[T]) SelectItemByIndex(index int) {
 		return
 	}
 	r.indexPlus1 = index + 1
-	guigui.DispatchEvent(r, radioButtonGroupEventItemSelected, r.indexPlus1-1)
+	radioButtonGroupEventItemSelected.Dispatch(r, r.indexPlus1-1)
 }
 
 func (r *RadioButtonGroup[T]) SelectItemByValue(value T) {
@@ -73,7 +73,7 @@ func (r *RadioButtonGroup[T]) SelectedValue() (T, bool) {
 }
 
 func (r *RadioButtonGroup[T]) OnItemSelected(f func(context *guigui.Context, index int)) {
-	guigui.SetEventHandler(r, radioButtonGroupEventItemSelected, f)
+	radioButtonGroupEventItemSelected.SetHandler(r, f)
 }
 
 // SetValues sets the values of the radio buttons.
@@ -139,18 +139,18 @@ func (r *RadioButton[T]) setPressed(pressed bool) {
 
 func (r *RadioButton[T]) HandlePointingInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
 	if context.IsEnabled(r) && widgetBounds.IsHitAtCursor() {
-		if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
 			r.group.SelectItemByIndex(r.index)
 			r.setPressed(false)
 			return guigui.HandleInputByWidget(r)
 		}
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 			context.SetFocused(r, true)
 			r.setPressed(true)
 			return guigui.HandleInputByWidget(r)
 		}
 	}
-	if !context.IsEnabled(r) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !context.IsEnabled(r) || !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		r.setPressed(false)
 	}
 	return guigui.HandleInputResult{}
@@ -172,11 +172,11 @@ func (r *RadioButton[T]) CursorShape(context *guigui.Context, widgetBounds *guig
 }
 
 func (r *RadioButton[T]) canPress(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(r) && widgetBounds.IsHitAtCursor() && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return context.IsEnabled(r) && widgetBounds.IsHitAtCursor() && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft)
 }
 
 func (r *RadioButton[T]) isActive(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(r) && widgetBounds.IsHitAtCursor() && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && r.pressed
+	return context.IsEnabled(r) && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && r.pressed
 }
 
 func (r *RadioButton[T]) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -192,20 +192,20 @@ func (r *RadioButton[T]) Draw(context *guigui.Context, widgetBounds *guigui.Widg
 		Min: pt,
 		Max: pt.Add(image.Pt(LineHeight(context), LineHeight(context))),
 	}
-	cm := context.ColorMode()
+	cs := colorScheme(context, r)
 	radius := LineHeight(context) / 2
 
 	isSelected := r.group.SelectedIndex() == r.index
-	backgroundColor := draw.CheckableControlBackgroundColor(cm, isSelected, r.isActive(context, widgetBounds), context.IsEnabled(r))
+	backgroundColor := draw.CheckableControlBackgroundColor(cs, isSelected, r.isActive(context, widgetBounds), context.IsEnabled(r))
 	draw.DrawRoundedRect(context, dst, bounds, backgroundColor, radius)
 
 	// Border
-	strokeWidth := float32(1 * context.Scale())
+	strokeWidth := borderWidth(context)
 	var borderClr1, borderClr2 color.Color
 	if isSelected && context.IsEnabled(r) {
-		borderClr1, borderClr2 = draw.BorderAccentSecondaryColors(cm, draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 = draw.BorderAccentSecondaryColors(cs, draw.RoundedRectBorderTypeInset)
 	} else {
-		borderClr1, borderClr2 = draw.BorderColors(cm, draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 = draw.BorderColors(cs, draw.RoundedRectBorderTypeInset)
 	}
 	draw.DrawRoundedRectBorder(context, dst, bounds, borderClr1, borderClr2, radius, strokeWidth, draw.RoundedRectBorderTypeInset)
 
@@ -217,7 +217,7 @@ func (r *RadioButton[T]) Draw(context *guigui.Context, widgetBounds *guigui.Widg
 		innerBounds.Max.X = innerBounds.Min.X + innerRadius*2
 		innerBounds.Max.Y = innerBounds.Min.Y + innerRadius*2
 
-		innerColor := draw.RadioButtonMarkColor(cm, context.IsEnabled(r))
+		innerColor := draw.RadioButtonMarkColor(cs, context.IsEnabled(r))
 		draw.DrawRoundedRect(context, dst, innerBounds, innerColor, innerRadius)
 	}
 }
@@ -58,7 +58,7 @@ func (i *resourceImages) Get(name string, colorMode ebiten.ColorMode) (*ebiten.I
 }
 
 func CreateMonochromeImage(colorMode ebiten.ColorMode, img image.Image) image.Image {
-	base := draw.IconColor(colorMode)
+	base := draw.IconColor(draw.NewColorScheme(colorMode, nil))
 	r, g, b, _ := base.RGBA()
 
 	bounds := img.Bounds()
@@ -8,14 +8,14 @@ import (
 	"runtime"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 func adjustedWheel() (float64, float64) {
-	x, y := ebiten.Wheel()
+	x, y := inputsource.Wheel()
 	switch runtime.GOOS {
 	case "darwin":
 		x *= 2
@@ -269,9 +269,9 @@ func (s *scrollBar) HandlePointingInput(context *guigui.Context, widgetBounds *g
 		return guigui.HandleInputResult{}
 	}
 
-	if !s.dragging && widgetBounds.IsHitAtCursor() && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if !s.dragging && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		if tb := s.thumbBounds; !tb.Empty() {
-			x, y := ebiten.CursorPosition()
+			x, y := inputsource.CursorPosition()
 			offsetX, offsetY := s.offsetGetSetter.scrollOffset()
 
 			var pos, thumbMin, thumbMax int
@@ -323,10 +323,10 @@ func (s *scrollBar) HandlePointingInput(context *guigui.Context, widgetBounds *g
 		s.dragging = false
 	}
 
-	if s.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if s.dragging && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		var dx, dy float64
 		if s.dragging {
-			x, y := ebiten.CursorPosition()
+			x, y := inputsource.CursorPosition()
 			if s.horizontal {
 				dx = float64(x - s.draggingStartPosition)
 			} else {
@@ -354,7 +354,7 @@ func (s *scrollBar) HandlePointingInput(context *guigui.Context, widgetBounds *g
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if s.dragging && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if s.dragging && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		s.dragging = false
 	}
 	return guigui.HandleInputResult{}
@@ -372,7 +372,7 @@ func (s *scrollBar) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBou
 		return
 	}
 	s.onceDraw = true
-	barColor := draw.ScrollBarColor(context.ColorMode())
+	barColor := draw.ScrollBarColor(colorScheme(context, s))
 	barColor = draw.ScaleAlpha(barColor, s.alpha)
 	draw.DrawRoundedRect(context, dst, s.thumbBounds, barColor, RoundedCornerRadius(context))
 }
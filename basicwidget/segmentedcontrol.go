@@ -13,8 +13,8 @@ import (
 )
 
 var (
-	segmentedControlEventItemSelected  guigui.EventKey = guigui.GenerateEventKey()
-	segmentedControlEventItemsSelected guigui.EventKey = guigui.GenerateEventKey()
+	segmentedControlEventItemSelected  = guigui.GenerateEventKey1[int]()
+	segmentedControlEventItemsSelected = guigui.GenerateEventKey1[[]int]()
 )
 
 type SegmentedControlDirection int
@@ -73,11 +73,11 @@ func (s *SegmentedControl[T]) SetMultiSelection(multi bool) {
 }
 
 func (s *SegmentedControl[T]) OnItemSelected(f func(context *guigui.Context, index int)) {
-	guigui.SetEventHandler(s, segmentedControlEventItemSelected, f)
+	segmentedControlEventItemSelected.SetHandler(s, f)
 }
 
 func (s *SegmentedControl[T]) OnItemsSelected(f func(context *guigui.Context, indices []int)) {
-	guigui.SetEventHandler(s, segmentedControlEventItemsSelected, f)
+	segmentedControlEventItemsSelected.SetHandler(s, f)
 }
 
 func (s *SegmentedControl[T]) SetItems(items []SegmentedControlItem[T]) {
@@ -131,14 +131,14 @@ func (s *SegmentedControl[T]) Build(context *guigui.Context, adder *guigui.Child
 
 	if s.onItemSelected == nil {
 		s.onItemSelected = func(index int) {
-			guigui.DispatchEvent(s, segmentedControlEventItemSelected, index)
+			segmentedControlEventItemSelected.Dispatch(s, index)
 		}
 	}
 	s.abstractList.OnItemSelected(s.onItemSelected)
 
 	if s.onItemsSelected == nil {
 		s.onItemsSelected = func(indices []int) {
-			guigui.DispatchEvent(s, segmentedControlEventItemsSelected, indices)
+			segmentedControlEventItemsSelected.Dispatch(s, indices)
 		}
 	}
 	s.abstractList.OnItemsSelected(s.onItemsSelected)
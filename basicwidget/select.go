@@ -11,7 +11,7 @@ import (
 )
 
 var (
-	selectEventItemSelected guigui.EventKey = guigui.GenerateEventKey()
+	selectEventItemSelected = guigui.GenerateEventKey1[int]()
 )
 
 type SelectItem[T comparable] struct {
@@ -45,7 +45,7 @@ type Select[T comparable] struct {
 }
 
 func (s *Select[T]) OnItemSelected(f func(context *guigui.Context, index int)) {
-	guigui.SetEventHandler(s, selectEventItemSelected, f)
+	selectEventItemSelected.SetHandler(s, f)
 }
 
 func (s *Select[T]) updatePopupMenuItems() {
@@ -118,7 +118,7 @@ func (s *Select[T]) Build(context *guigui.Context, adder *guigui.ChildAdder) err
 	if s.onPopupMenuItemSelected == nil {
 		s.onPopupMenuItemSelected = func(context *guigui.Context, index int) {
 			s.itemSelectedDispatchedFromPopupMenu = true
-			guigui.DispatchEvent(s, selectEventItemSelected, index)
+			selectEventItemSelected.Dispatch(s, index)
 		}
 	}
 	s.popupMenu.OnItemSelected(s.onPopupMenuItemSelected)
@@ -184,7 +184,7 @@ func (s *Select[T]) SelectItemByIndex(index int) {
 	s.itemSelectedDispatchedFromPopupMenu = false
 	s.popupMenu.SelectItemByIndex(index)
 	if cur := s.popupMenu.SelectedItemIndex(); cur != prev && cur >= 0 && !s.itemSelectedDispatchedFromPopupMenu {
-		guigui.DispatchEvent(s, selectEventItemSelected, cur)
+		selectEventItemSelected.Dispatch(s, cur)
 	}
 }
 
@@ -196,7 +196,7 @@ func (s *Select[T]) SelectItemByValue(value T) {
 	s.itemSelectedDispatchedFromPopupMenu = false
 	s.popupMenu.SelectItemByValue(value)
 	if cur := s.popupMenu.SelectedItemIndex(); cur != prev && cur >= 0 && !s.itemSelectedDispatchedFromPopupMenu {
-		guigui.DispatchEvent(s, selectEventItemSelected, cur)
+		selectEventItemSelected.Dispatch(s, cur)
 	}
 }
 
@@ -279,7 +279,7 @@ func (s *selectButtonContent) layout(context *guigui.Context) guigui.LinearLayou
 	iconSize := defaultIconSize(context)
 	s.layoutItems = append(s.layoutItems,
 		guigui.LinearLayoutItem{
-			Size: guigui.FixedSize(buttonTextAndImagePadding(context)),
+			Size: guigui.FixedSize(buttonTextAndImagePadding(context, s)),
 		},
 		guigui.LinearLayoutItem{
 			Widget: &s.icon,
@@ -288,7 +288,7 @@ func (s *selectButtonContent) layout(context *guigui.Context) guigui.LinearLayou
 
 	// Add paddings. Paddings are calculated as if the content is a text widget.
 	// Even if the content is not a text widget, this padding should look good enough.
-	padding := defaultButtonSize(context).Y - LineHeight(context)
+	padding := defaultButtonSize(context, s).Y - LineHeight(context)
 	paddingTop := padding / 2
 	paddingBottom := padding - paddingTop
 
@@ -296,9 +296,9 @@ func (s *selectButtonContent) layout(context *guigui.Context) guigui.LinearLayou
 		Direction: guigui.LayoutDirectionHorizontal,
 		Items:     s.layoutItems,
 		Padding: guigui.Padding{
-			Start:  buttonEdgeAndTextPadding(context),
+			Start:  buttonEdgeAndTextPadding(context, s),
 			Top:    paddingTop,
-			End:    buttonTextAndImagePadding(context),
+			End:    buttonTextAndImagePadding(context, s),
 			Bottom: paddingBottom,
 		},
 	}
@@ -11,18 +11,38 @@ import (
 
 const baseUnitSize = 24
 
+// FontSize returns the default font size, half the unit size of the theme set by [SetTheme], in the current scale.
 func FontSize(context *guigui.Context) float64 {
-	return baseUnitSize * context.Scale() * 1 / 2
+	return appUnitSize() * context.Scale() * 1 / 2
 }
 
+// LineHeight returns the default line height, three quarters of the unit size of the theme set by [SetTheme],
+// in the current scale.
 func LineHeight(context *guigui.Context) int {
-	return int(math.Ceil(baseUnitSize * context.Scale() * 3 / 4))
+	return int(math.Ceil(appUnitSize() * context.Scale() * 3 / 4))
 }
 
+// UnitSize returns the spacing unit of the theme set by [SetTheme], in the current scale.
 func UnitSize(context *guigui.Context) int {
-	return int(baseUnitSize * context.Scale())
+	return int(appUnitSize() * context.Scale())
 }
 
+// appUnitSize returns the unit size of the theme set by [SetTheme] at the scale 1.
+func appUnitSize() float64 {
+	if u := appTheme.UnitSize; u != 0 {
+		return u
+	}
+	return baseUnitSize
+}
+
+// RoundedCornerRadius returns the corner radius of the theme set by [SetTheme], in the current scale.
 func RoundedCornerRadius(context *guigui.Context) int {
-	return int(baseUnitSize * context.Scale() / 4)
+	r := appTheme.CornerRadius
+	if r == 0 {
+		r = baseUnitSize / 4
+	}
+	if r < 0 {
+		return 0
+	}
+	return int(r * context.Scale())
 }
@@ -9,18 +9,18 @@ import (
 	"math/big"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	sliderEventValueChanged       guigui.EventKey = guigui.GenerateEventKey()
-	sliderEventValueChangedBigInt guigui.EventKey = guigui.GenerateEventKey()
-	sliderEventValueChangedInt64  guigui.EventKey = guigui.GenerateEventKey()
-	sliderEventValueChangedUint64 guigui.EventKey = guigui.GenerateEventKey()
+	sliderEventValueChanged       = guigui.GenerateEventKey2[int, bool]()
+	sliderEventValueChangedBigInt = guigui.GenerateEventKey2[*big.Int, bool]()
+	sliderEventValueChangedInt64  = guigui.GenerateEventKey2[int64, bool]()
+	sliderEventValueChangedUint64 = guigui.GenerateEventKey2[uint64, bool]()
 )
 
 type Slider struct {
@@ -43,25 +43,25 @@ type Slider struct {
 }
 
 func (s *Slider) OnValueChanged(f func(context *guigui.Context, value int)) {
-	guigui.SetEventHandler(s, sliderEventValueChanged, func(context *guigui.Context, value int, committed bool) {
+	sliderEventValueChanged.SetHandler(s, func(context *guigui.Context, value int, committed bool) {
 		f(context, value)
 	})
 }
 
 func (s *Slider) OnValueChangedBigInt(f func(context *guigui.Context, value *big.Int)) {
-	guigui.SetEventHandler(s, sliderEventValueChangedBigInt, func(context *guigui.Context, value *big.Int, committed bool) {
+	sliderEventValueChangedBigInt.SetHandler(s, func(context *guigui.Context, value *big.Int, committed bool) {
 		f(context, value)
 	})
 }
 
 func (s *Slider) OnValueChangedInt64(f func(context *guigui.Context, value int64)) {
-	guigui.SetEventHandler(s, sliderEventValueChangedInt64, func(context *guigui.Context, value int64, committed bool) {
+	sliderEventValueChangedInt64.SetHandler(s, func(context *guigui.Context, value int64, committed bool) {
 		f(context, value)
 	})
 }
 
 func (s *Slider) OnValueChangedUint64(f func(context *guigui.Context, value uint64)) {
-	guigui.SetEventHandler(s, sliderEventValueChangedUint64, func(context *guigui.Context, value uint64, committed bool) {
+	sliderEventValueChangedUint64.SetHandler(s, func(context *guigui.Context, value uint64, committed bool) {
 		f(context, value)
 	})
 }
@@ -190,28 +190,28 @@ func roundDivBigInt(z, x, y *big.Int) {
 func (s *Slider) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 	if s.onValueChanged == nil {
 		s.onValueChanged = func(value int, committed bool) {
-			guigui.DispatchEvent(s, sliderEventValueChanged, value, committed)
+			sliderEventValueChanged.Dispatch(s, value, committed)
 		}
 	}
 	s.abstractNumberInput.OnValueChanged(s.onValueChanged)
 
 	if s.onValueChangedBigInt == nil {
 		s.onValueChangedBigInt = func(value *big.Int, committed bool) {
-			guigui.DispatchEvent(s, sliderEventValueChangedBigInt, value, committed)
+			sliderEventValueChangedBigInt.Dispatch(s, value, committed)
 		}
 	}
 	s.abstractNumberInput.OnValueChangedBigInt(s.onValueChangedBigInt)
 
 	if s.onValueChangedInt64 == nil {
 		s.onValueChangedInt64 = func(value int64, committed bool) {
-			guigui.DispatchEvent(s, sliderEventValueChangedInt64, value, committed)
+			sliderEventValueChangedInt64.Dispatch(s, value, committed)
 		}
 	}
 	s.abstractNumberInput.OnValueChangedInt64(s.onValueChangedInt64)
 
 	if s.onValueChangedUint64 == nil {
 		s.onValueChangedUint64 = func(value uint64, committed bool) {
-			guigui.DispatchEvent(s, sliderEventValueChangedUint64, value, committed)
+			sliderEventValueChangedUint64.Dispatch(s, value, committed)
 		}
 	}
 	s.abstractNumberInput.OnValueChangedUint64(s.onValueChangedUint64)
@@ -231,26 +231,26 @@ func (s *Slider) HandlePointingInput(context *guigui.Context, widgetBounds *guig
 		return guigui.HandleInputResult{}
 	}
 
-	if context.IsEnabled(s) && widgetBounds.IsHitAtCursor() && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !s.dragging {
+	if context.IsEnabled(s) && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !s.dragging {
 		context.SetFocused(s, true)
 		if !s.isThumbHovered(context, widgetBounds) {
 			s.setValueFromCursor(context, widgetBounds)
 		}
 		s.dragging = true
-		x, _ := ebiten.CursorPosition()
+		x, _ := inputsource.CursorPosition()
 		s.draggingStartX = x
 		s.draggingStartValue.Set(s.abstractNumberInput.ValueBigInt())
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if !context.IsEnabled(s) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !context.IsEnabled(s) || !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		s.dragging = false
 		s.draggingStartX = 0
 		s.draggingStartValue = big.Int{}
 		return guigui.HandleInputResult{}
 	}
 
-	if context.IsEnabled(s) && s.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if context.IsEnabled(s) && s.dragging && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		s.setValueFromCursorDelta(context, widgetBounds)
 		return guigui.HandleInputByWidget(s)
 	}
@@ -270,6 +270,9 @@ func (s *Slider) setValueFromCursor(context *guigui.Context, widgetBounds *guigu
 
 	b := widgetBounds.Bounds()
 	minX := b.Min.X + (b.Dx()-s.barWidth(context, widgetBounds))/2
+	if context.IsRightToLeft(s) {
+		minX = b.Max.X - (b.Dx()-s.barWidth(context, widgetBounds))/2
+	}
 	s.setValue(context, widgetBounds, min, minX)
 }
 
@@ -284,7 +287,12 @@ func (s *Slider) setValue(context *guigui.Context, widgetBounds *guigui.WidgetBo
 	if barWidth <= 0 {
 		return
 	}
-	c := image.Pt(ebiten.CursorPosition())
+	c := image.Pt(inputsource.CursorPosition())
+	// In a right-to-left direction, the value increases leftward.
+	dx := int64(c.X - originX)
+	if context.IsRightToLeft(s) {
+		dx = -dx
+	}
 
 	var v big.Int
 	if s.snapOnly && s.hasSnaps() && s.abstractNumberInput.step.Sign() > 0 {
@@ -292,7 +300,7 @@ func (s *Slider) setValue(context *guigui.Context, widgetBounds *guigui.WidgetBo
 		step := &s.abstractNumberInput.step
 		var num, tmp big.Int
 		num.Sub(max, min)
-		num.Mul(&num, big.NewInt(int64(c.X-originX)))
+		num.Mul(&num, big.NewInt(dx))
 		tmp.Sub(originValue, min)
 		tmp.Mul(&tmp, big.NewInt(barWidth))
 		num.Add(&num, &tmp)
@@ -311,7 +319,7 @@ func (s *Slider) setValue(context *guigui.Context, widgetBounds *guigui.WidgetBo
 		// shrinking the rightmost value's zone to a sliver at the bar end.
 		var num big.Int
 		num.Sub(max, min)
-		num.Mul(&num, big.NewInt(int64(c.X-originX)))
+		num.Mul(&num, big.NewInt(dx))
 		roundDivBigInt(&v, &num, big.NewInt(barWidth))
 		v.Add(&v, originValue)
 	}
@@ -320,11 +328,11 @@ func (s *Slider) setValue(context *guigui.Context, widgetBounds *guigui.WidgetBo
 
 func (s *Slider) barWidth(context *guigui.Context, widgetBounds *guigui.WidgetBounds) int {
 	w := widgetBounds.Bounds().Dx()
-	return w - 2*sliderThumbRadius(context)
+	return w - 2*sliderThumbRadius(context, s)
 }
 
-func sliderThumbRadius(context *guigui.Context) int {
-	return int(UnitSize(context) * 7 / 16)
+func sliderThumbRadius(context *guigui.Context, widget guigui.Widget) int {
+	return int(controlUnitSize(context, widget) * 7 / 16)
 }
 
 func (s *Slider) thumbBounds(context *guigui.Context, widgetBounds *guigui.WidgetBounds) image.Rectangle {
@@ -333,21 +341,21 @@ func (s *Slider) thumbBounds(context *guigui.Context, widgetBounds *guigui.Widge
 		return image.Rectangle{}
 	}
 	bounds := widgetBounds.Bounds()
-	radius := sliderThumbRadius(context)
+	radius := sliderThumbRadius(context, s)
 
 	if s.hasSnaps() {
 		w := radius
 		h := 2 * radius
 		x := bounds.Min.X + int(rate*float64(s.barWidth(context, widgetBounds))) + radius - w/2
 		y := bounds.Min.Y + (bounds.Dy()-h)/2
-		return image.Rect(x, y, x+w, y+h)
+		return context.MirrorBounds(s, image.Rect(x, y, x+w, y+h), bounds)
 	}
 
 	x := bounds.Min.X + int(rate*float64(s.barWidth(context, widgetBounds)))
 	y := bounds.Min.Y + (bounds.Dy()-2*radius)/2
 	w := 2 * radius
 	h := 2 * radius
-	return image.Rect(x, y, x+w, y+h)
+	return context.MirrorBounds(s, image.Rect(x, y, x+w, y+h), bounds)
 }
 
 func (s *Slider) CursorShape(context *guigui.Context, widgetBounds *guigui.WidgetBounds) (ebiten.CursorShapeType, bool) {
@@ -366,27 +374,27 @@ func (s *Slider) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 	barY0 := (b.Min.Y+b.Max.Y)/2 - r
 	barY1 := (b.Min.Y+b.Max.Y)/2 + r
 
-	barX0 := b.Min.X + sliderThumbRadius(context)*3/4
+	barX0 := b.Min.X + sliderThumbRadius(context, s)*3/4
 	barX1 := barX0
 	if !math.IsNaN(rate) {
-		barX1 = b.Min.X + sliderThumbRadius(context) + int(float64(s.barWidth(context, widgetBounds))*float64(rate))
+		barX1 = b.Min.X + sliderThumbRadius(context, s) + int(float64(s.barWidth(context, widgetBounds))*float64(rate))
 	}
-	barX2 := b.Max.X - sliderThumbRadius(context)*3/4
+	barX2 := b.Max.X - sliderThumbRadius(context, s)*3/4
 
-	fillColor := draw.TrackFillColor(context.ColorMode(), context.IsEnabled(s))
-	trackColor := draw.TrackColor(context.ColorMode(), false, false)
+	fillColor := draw.TrackFillColor(colorScheme(context, s), context.IsEnabled(s))
+	trackColor := draw.TrackColor(colorScheme(context, s), false, false)
 
 	if barX0 < barX1 {
-		b := image.Rect(barX0, barY0, barX1, barY1)
+		b := context.MirrorBounds(s, image.Rect(barX0, barY0, barX1, barY1), b)
 		draw.DrawRoundedRect(context, dst, b, fillColor, r)
 	}
 
 	if barX1 < barX2 {
-		b := image.Rect(barX1, barY0, barX2, barY1)
+		b := context.MirrorBounds(s, image.Rect(barX1, barY0, barX2, barY1), b)
 		draw.DrawRoundedRect(context, dst, b, trackColor, r)
 
-		borderClr1, borderClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset)
-		draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 := draw.BorderColors(colorScheme(context, s), draw.RoundedRectBorderTypeInset)
+		draw.DrawRoundedRectBorder(context, dst, b, borderClr1, borderClr2, r, borderWidth(context), draw.RoundedRectBorderTypeInset)
 	}
 
 	// Draw gauge marks at snap positions.
@@ -396,14 +404,14 @@ func (s *Slider) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 		max := &s.abstractNumberInput.max
 
 		barW := s.barWidth(context, widgetBounds)
-		barStartX := b.Min.X + sliderThumbRadius(context)
-		radius := sliderThumbRadius(context)
+		barStartX := b.Min.X + sliderThumbRadius(context, s)
+		radius := sliderThumbRadius(context, s)
 		gap := float32(2 * context.Scale())
 
 		barTop := float32(barY0)
 		barBottom := float32(barY1)
 
-		tickColor := draw.SliderTickColor(context.ColorMode())
+		tickColor := draw.SliderTickColor(colorScheme(context, s))
 		tickWidth := float32(2 * context.Scale())
 		tickHeight := float32(radius) / 2
 
@@ -420,6 +428,9 @@ func (s *Slider) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 				).Float64()
 
 				tx := float32(barStartX) + float32(float64(barW)*tickRate)
+				if context.IsRightToLeft(s) {
+					tx = float32(b.Min.X+b.Max.X) - tx
+				}
 				vector.StrokeLine(dst,
 					tx, barTop-tickHeight,
 					tx, barTop-gap,
@@ -433,35 +444,35 @@ func (s *Slider) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 	}
 
 	if thumbBounds := s.thumbBounds(context, widgetBounds); !thumbBounds.Empty() {
-		cm := context.ColorMode()
-		thumbColor := draw.ThumbColor(cm, context.IsEnabled(s))
+		cs := colorScheme(context, s)
+		thumbColor := draw.ThumbColor(cs, context.IsEnabled(s))
 		if s.isActive(context, widgetBounds) {
-			thumbColor = draw.ThumbPressedColor(cm)
+			thumbColor = draw.ThumbPressedColor(cs)
 		} else if s.canPress(context, widgetBounds) {
-			thumbColor = draw.ThumbHoveredColor(cm)
+			thumbColor = draw.ThumbHoveredColor(cs)
 		}
-		thumbClr1, thumbClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset)
+		thumbClr1, thumbClr2 := draw.BorderColors(colorScheme(context, s), draw.RoundedRectBorderTypeOutset)
 		r := thumbBounds.Dy() / 2
 		if s.hasSnaps() {
 			r = thumbBounds.Dx() / 2
 		}
 		draw.DrawRoundedRect(context, dst, thumbBounds, thumbColor, r)
-		draw.DrawRoundedRectBorder(context, dst, thumbBounds, thumbClr1, thumbClr2, r, float32(1*context.Scale()), draw.RoundedRectBorderTypeOutset)
+		draw.DrawRoundedRectBorder(context, dst, thumbBounds, thumbClr1, thumbClr2, r, borderWidth(context), draw.RoundedRectBorderTypeOutset)
 	}
 }
 
 func (s *Slider) canPress(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(s) && s.isThumbHovered(context, widgetBounds) && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !s.dragging
+	return context.IsEnabled(s) && s.isThumbHovered(context, widgetBounds) && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && !s.dragging
 }
 
 func (s *Slider) isThumbHovered(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return widgetBounds.IsHitAtCursor() && image.Pt(ebiten.CursorPosition()).In(s.thumbBounds(context, widgetBounds))
+	return widgetBounds.IsHitAtCursor() && image.Pt(inputsource.CursorPosition()).In(s.thumbBounds(context, widgetBounds))
 }
 
 func (s *Slider) isActive(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(s) && s.isThumbHovered(context, widgetBounds) && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && s.dragging
+	return context.IsEnabled(s) && s.isThumbHovered(context, widgetBounds) && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && s.dragging
 }
 
 func (s *Slider) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
-	return image.Pt(6*UnitSize(context), UnitSize(context))
+	return image.Pt(6*UnitSize(context), controlUnitSize(context, s))
 }
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget
+
+import (
+	"image/color"
+
+	"github.com/guigui-gui/guigui"
+)
+
+// Density is how tightly controls are laid out: their heights and the paddings around their contents.
+//
+// The density applies to the heights and the paddings of Button, Select, TextInput, Slider, Menubar,
+// and the items of List, Table and Menubar.
+type Density int
+
+const (
+	// DensityDefault inherits the density from the ancestors,
+	// or is the application density set by [SetDensity] if none provides one.
+	DensityDefault Density = iota
+
+	// DensityStandard is the standard density for mice and touchpads.
+	DensityStandard
+
+	// DensityCompact makes controls shorter and their paddings smaller, for dense tool panels and sidebars.
+	DensityCompact
+
+	// DensityTouch makes controls and list items taller, so that each is large enough to tap with a finger.
+	DensityTouch
+)
+
+// appDensity is the density set by SetDensity.
+var appDensity = DensityStandard
+
+// SetDensity sets the density of the application. A subtree can override it with [Style.Density].
+// If density is [DensityDefault], [DensityStandard] is used.
+//
+// SetDensity does not rebuild the widgets by itself. To apply the density to the whole screen,
+// write [AppDensity] in the root widget's [guigui.Widget.WriteStateKey].
+func SetDensity(density Density) {
+	if density == DensityDefault {
+		density = DensityStandard
+	}
+	appDensity = density
+}
+
+// AppDensity returns the density of the application set by [SetDensity].
+func AppDensity() Density {
+	return appDensity
+}
+
+// Style is a set of style properties inherited by the descendants of a widget.
+//
+// A widget provides its style to its descendants by returning the result of [Style.Env] from its
+// [guigui.Widget.Env], and writes the style in its [guigui.Widget.WriteStateKey] with [Style.WriteStateKeyTo]
+// so that the descendants are rebuilt when the style changes:
+//
+//	func (s *Sidebar) Env(context *guigui.Context, key guigui.EnvKey, source *guigui.EnvSource) (any, bool) {
+//		return s.style.Env(key)
+//	}
+//
+//	func (s *Sidebar) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+//		s.style.WriteStateKeyTo(w)
+//	}
+//
+// Each property is inherited separately: a zero property is inherited from the farther ancestors.
+// A property set on a widget itself takes precedence over the inherited one,
+// such as [Text.SetFontSize] and the font family and the color of [Text.SetBaseStyle].
+type Style struct {
+	// FontFamily is the font family of text.
+	FontFamily *FontFamily
+
+	// FontSize is the font size of text at scale 1. Unlike [Text.SetFontSize], it is multiplied by
+	// [guigui.Context.Scale]. The line height is scaled in proportion to it.
+	FontSize float64
+
+	// TextColor is the color of enabled text. Disabled text is drawn in the theme's color.
+	TextColor color.Color
+
+	// Density is the density of controls.
+	Density Density
+}
+
+var (
+	envKeyFontFamily = guigui.GenerateEnvKey()
+	envKeyFontSize   = guigui.GenerateEnvKey()
+	envKeyTextColor  = guigui.GenerateEnvKey()
+	envKeyDensity    = guigui.GenerateEnvKey()
+)
+
+var _ guigui.StateKeyWriterTo = (*Style)(nil)
+
+// Env returns the value of the style property for the key, and reports whether the style has the property.
+// Env is intended to be called from [guigui.Widget.Env].
+func (s *Style) Env(key guigui.EnvKey) (any, bool) {
+	switch key {
+	case envKeyFontFamily:
+		if s.FontFamily != nil {
+			return s.FontFamily, true
+		}
+	case envKeyFontSize:
+		if s.FontSize > 0 {
+			return s.FontSize, true
+		}
+	case envKeyTextColor:
+		if s.TextColor != nil {
+			return s.TextColor, true
+		}
+	case envKeyDensity:
+		if s.Density != DensityDefault {
+			return s.Density, true
+		}
+	}
+	return nil, false
+}
+
+// WriteStateKeyTo implements [guigui.StateKeyWriterTo].
+func (s *Style) WriteStateKeyTo(w *guigui.StateKeyWriter) {
+	var familyID uint64
+	if s.FontFamily != nil && s.FontFamily.f != nil {
+		familyID = s.FontFamily.f.ID()
+	}
+	w.WriteUint64(familyID)
+	w.WriteFloat64(s.FontSize)
+	writeColor(w, s.TextColor)
+	w.WriteInt(int(s.Density))
+}
+
+// InheritedStyle returns the style properties that the widget inherits, including the ones the widget
+// provides itself. A property that no widget provides is zero.
+func InheritedStyle(context *guigui.Context, widget guigui.Widget) Style {
+	return Style{
+		FontFamily: inheritedFontFamily(context, widget),
+		FontSize:   inheritedFontSize(context, widget),
+		TextColor:  inheritedTextColor(context, widget),
+		Density:    inheritedDensity(context, widget),
+	}
+}
+
+func inheritedFontFamily(context *guigui.Context, widget guigui.Widget) *FontFamily {
+	if v, ok := context.Env(widget, envKeyFontFamily); ok {
+		return v.(*FontFamily)
+	}
+	return nil
+}
+
+func inheritedFontSize(context *guigui.Context, widget guigui.Widget) float64 {
+	if v, ok := context.Env(widget, envKeyFontSize); ok {
+		return v.(float64)
+	}
+	return 0
+}
+
+func inheritedTextColor(context *guigui.Context, widget guigui.Widget) color.Color {
+	if v, ok := context.Env(widget, envKeyTextColor); ok {
+		return v.(color.Color)
+	}
+	return nil
+}
+
+func inheritedDensity(context *guigui.Context, widget guigui.Widget) Density {
+	if v, ok := context.Env(widget, envKeyDensity); ok {
+		return v.(Density)
+	}
+	return DensityDefault
+}
+
+// densityOf returns the density of the widget: the inherited one, or the application's.
+func densityOf(context *guigui.Context, widget guigui.Widget) Density {
+	if d := inheritedDensity(context, widget); d != DensityDefault {
+		return d
+	}
+	return appDensity
+}
+
+// ControlHeight returns the height of a single-line control like a button in the widget's density.
+// Use it to size custom controls consistently with the built-in ones.
+func ControlHeight(context *guigui.Context, widget guigui.Widget) int {
+	return controlUnitSize(context, widget)
+}
+
+// controlUnitSize returns the unit size that the heights and the paddings of the widget's controls derive from,
+// with the density applied.
+func controlUnitSize(context *guigui.Context, widget guigui.Widget) int {
+	u := UnitSize(context)
+	switch densityOf(context, widget) {
+	case DensityCompact:
+		return u * 5 / 6
+	case DensityTouch:
+		return u * 3 / 2
+	}
+	return u
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestStyleEnv(t *testing.T) {
+	keys := []guigui.EnvKey{
+		basicwidget.EnvKeyFontFamily,
+		basicwidget.EnvKeyFontSize,
+		basicwidget.EnvKeyTextColor,
+		basicwidget.EnvKeyDensity,
+	}
+
+	// The zero style provides nothing, so that every property is inherited from the farther ancestors.
+	var zero basicwidget.Style
+	for _, key := range keys {
+		if v, ok := zero.Env(key); ok {
+			t.Errorf("zero Style.Env(%v): got: %v, want: none", key, v)
+		}
+	}
+
+	style := basicwidget.Style{
+		FontSize: 10,
+		Density:  basicwidget.DensityCompact,
+	}
+	if v, ok := style.Env(basicwidget.EnvKeyFontSize); !ok || v != 10.0 {
+		t.Errorf("Style.Env(EnvKeyFontSize): got: %v, %t, want: 10, true", v, ok)
+	}
+	if v, ok := style.Env(basicwidget.EnvKeyDensity); !ok || v != basicwidget.DensityCompact {
+		t.Errorf("Style.Env(EnvKeyDensity): got: %v, %t, want: DensityCompact, true", v, ok)
+	}
+	if _, ok := style.Env(basicwidget.EnvKeyTextColor); ok {
+		t.Errorf("Style.Env(EnvKeyTextColor): got: ok, want: none")
+	}
+
+	style.TextColor = color.Black
+	if v, ok := style.Env(basicwidget.EnvKeyTextColor); !ok || v != color.Color(color.Black) {
+		t.Errorf("Style.Env(EnvKeyTextColor): got: %v, %t, want: black, true", v, ok)
+	}
+}
+
+func TestSetDensity(t *testing.T) {
+	orig := basicwidget.AppDensity()
+	defer basicwidget.SetDensity(orig)
+
+	basicwidget.SetDensity(basicwidget.DensityTouch)
+	if got, want := basicwidget.AppDensity(), basicwidget.DensityTouch; got != want {
+		t.Errorf("AppDensity(): got: %v, want: %v", got, want)
+	}
+
+	// DensityDefault falls back to the standard density, as there is nothing to inherit from.
+	basicwidget.SetDensity(basicwidget.DensityDefault)
+	if got, want := basicwidget.AppDensity(), basicwidget.DensityStandard; got != want {
+		t.Errorf("AppDensity(): got: %v, want: %v", got, want)
+	}
+}
@@ -155,7 +155,7 @@ func (t *Table[T]) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 
 	context.SetClipChildren(&t.tableHeader, true)
 
-	t.list.SetHeaderHeight(tableHeaderHeight(context))
+	t.list.SetHeaderHeight(tableHeaderHeight(context, t))
 	t.list.SetStyle(ListStyleNormal)
 	t.list.SetStripeVisible(true)
 
@@ -208,9 +208,8 @@ func (t *Table[T]) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBo
 	layouter.LayoutWidget(&t.tableHeader, bounds)
 }
 
-func tableHeaderHeight(context *guigui.Context) int {
-	u := UnitSize(context)
-	return u
+func tableHeaderHeight(context *guigui.Context, widget guigui.Widget) int {
+	return controlUnitSize(context, widget)
 }
 
 func (t *Table[T]) SelectedItemCount() int {
@@ -400,7 +399,7 @@ func (t *tableRowWidget[T]) Layout(context *guigui.Context, widgetBounds *guigui
 			t.textColumnLayouts = append(t.textColumnLayouts, guigui.LinearLayout{
 				Direction: guigui.LayoutDirectionHorizontal,
 				Items:     t.textColumnLayoutItems[len(t.textColumnLayoutItems)-1 : len(t.textColumnLayoutItems)],
-				Padding:   ListItemTextPadding(context),
+				Padding:   listItemTextPadding(context, t),
 			})
 			t.linearLayoutItems = append(t.linearLayoutItems,
 				guigui.LinearLayoutItem{
@@ -417,7 +416,12 @@ func (t *tableRowWidget[T]) Layout(context *guigui.Context, widgetBounds *guigui
 	// Set text colors based on the list item color type provided by the parent list widget.
 	if v, ok := context.Env(t, EnvKeyListItemColorType); ok {
 		ct := v.(ListItemColorType)
-		t.resolvedTextColor = ct.TextColor(context)
+		t.resolvedTextColor = ct.TextColor(context, t)
+		if ct == ListItemColorTypeDefault {
+			if c := inheritedTextColor(context, t); c != nil {
+				t.resolvedTextColor = c
+			}
+		}
 		for i := range t.row.Cells {
 			if i >= t.texts.Len() {
 				break
@@ -445,7 +449,7 @@ func (t *tableRowWidget[T]) Measure(context *guigui.Context, constraints guigui.
 			s = cell.Content.Measure(context, guigui.FixedWidthConstraints(t.table.columnWidthsInPixels[i]))
 		} else {
 			// Assume that every item can use a bold font.
-			p := ListItemTextPadding(context)
+			p := listItemTextPadding(context, t)
 			w := t.table.columnWidthsInPixels[i] - p.Start - p.End
 			s = t.texts.At(i).Measure(context, guigui.FixedWidthConstraints(w))
 			s = s.Add(image.Pt(p.Start+p.End, p.Top+p.Bottom))
@@ -512,7 +516,7 @@ func (t *tableHeader[T]) Layout(context *guigui.Context, widgetBounds *guigui.Wi
 		width := t.table.columnWidthsInPixels[i] - UnitSize(context)/2
 		textBounds := image.Rectangle{
 			Min: textMin,
-			Max: textMin.Add(image.Pt(width, tableHeaderHeight(context))),
+			Max: textMin.Add(image.Pt(width, tableHeaderHeight(context, t))),
 		}
 		layouter.LayoutWidget(t.columnTexts.At(i), textBounds)
 		pt.X += t.table.columnWidthsInPixels[i]
@@ -533,8 +537,8 @@ func (t *tableHeader[T]) Draw(context *guigui.Context, widgetBounds *guigui.Widg
 		x0 := float32(x)
 		x1 := x0
 		y0 := float32(b.Min.Y + u/4)
-		y1 := float32(b.Min.Y + tableHeaderHeight(context) - u/4)
-		clr := draw.HeaderSeparatorColor(context.ColorMode(), context.IsEnabled(t))
+		y1 := float32(b.Min.Y + tableHeaderHeight(context, t) - u/4)
+		clr := draw.HeaderSeparatorColor(colorScheme(context, t), context.IsEnabled(t))
 		vector.StrokeLine(dst, x0, y0, x1, y1, float32(context.Scale()), clr, false)
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"image"
 	"image/color"
 	"io"
+	"math"
 	"slices"
 	"strings"
 
@@ -18,8 +19,16 @@ import (
 	"github.com/guigui-gui/guigui/basicwidget/internal/textstyle"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textutil"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textwidget"
+	"github.com/guigui-gui/guigui/internal/debugmode"
 )
 
+// TextEventValueChanged is the event dispatched when the value of a [Text] or a text-editing widget
+// such as [TextInput] is changed. The arguments are the current text and whether the change is committed.
+// An ancestor can observe the event with a capturing or bubbling handler.
+//
+// The target of the event is an internal widget of the text-editing widget.
+var TextEventValueChanged = textwidget.EventValueChanged
+
 type HorizontalAlign int
 
 const (
@@ -135,6 +144,10 @@ type Text struct {
 	// lineHeight is the line height at scale 1. A non-positive value selects
 	// the default line height ([LineHeight]).
 	lineHeight float64
+
+	// reportedContrastRatio is the contrast ratio last reported by GUIGUI_DEBUG=checkcontrast,
+	// or 0 if the contrast is sufficient.
+	reportedContrastRatio float64
 }
 
 // OnValueChanged sets the event handler that is called when the text value changes.
@@ -195,12 +208,12 @@ func (t *Text) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 	t.applyFontProperties(context)
 
 	t.core.SetTextColor(t.resolveTextColor(context))
-	t.core.SetSelectionColor(draw.TextSelectionColor(context.ColorMode()))
+	t.core.SetSelectionColor(draw.TextSelectionColor(colorScheme(context, t)))
 	t.core.SetCompositionColors(
-		draw.TextInactiveCompositionColor(context.ColorMode()),
-		draw.TextActiveCompositionColor(context.ColorMode()),
+		draw.TextInactiveCompositionColor(colorScheme(context, t)),
+		draw.TextActiveCompositionColor(colorScheme(context, t)),
 	)
-	t.core.SetCaretColor(draw.TextCaretColor(context.ColorMode()))
+	t.core.SetCaretColor(draw.TextCaretColor(colorScheme(context, t)))
 
 	return nil
 }
@@ -209,19 +222,16 @@ func (t *Text) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 // and pushes them into the core, so that measuring is independent of whether
 // [Text.Build] has run.
 func (t *Text) applyFontProperties(context *guigui.Context) {
-	family, _ := t.baseStyle.Family()
+	family, ok := t.baseStyle.Family()
+	if !ok {
+		if f := inheritedFontFamily(context, t); f != nil {
+			family = f.f
+		}
+	}
 	t.core.SetFontFamily(family)
 
-	fontSize := FontSize(context)
-	if t.fontSize > 0 {
-		fontSize = t.fontSize
-	}
+	fontSize, lineHeight := t.resolveFontSize(context)
 	t.core.SetFontSize(fontSize)
-
-	lineHeight := float64(LineHeight(context))
-	if t.lineHeight > 0 {
-		lineHeight = t.lineHeight
-	}
 	t.core.SetLineHeight(lineHeight)
 
 	var lang language.Tag
@@ -233,6 +243,25 @@ func (t *Text) applyFontProperties(context *guigui.Context) {
 	t.core.SetLang(lang)
 }
 
+// resolveFontSize returns the font size and the line height: the ones set to the text,
+// or the ones inherited from the ancestors, or the defaults.
+func (t *Text) resolveFontSize(context *guigui.Context) (fontSize, lineHeight float64) {
+	fontSize = FontSize(context)
+	lineHeight = float64(LineHeight(context))
+	if s := inheritedFontSize(context, t); s > 0 {
+		// Keep the ratio of the default line height to the default font size.
+		lineHeight = math.Ceil(lineHeight * s * context.Scale() / fontSize)
+		fontSize = s * context.Scale()
+	}
+	if t.fontSize > 0 {
+		fontSize = t.fontSize
+	}
+	if t.lineHeight > 0 {
+		lineHeight = t.lineHeight
+	}
+	return fontSize, lineHeight
+}
+
 // scaledLineHeight returns the line height in pixels, with the text scale
 // applied.
 func (t *Text) scaledLineHeight(context *guigui.Context) float64 {
@@ -241,12 +270,15 @@ func (t *Text) scaledLineHeight(context *guigui.Context) float64 {
 }
 
 // resolveTextColor returns the concrete color the value is drawn in: the
-// color of the base style, or the theme color for the enabled state, with the
-// opacity applied.
+// color of the base style, the inherited color while enabled, or the theme
+// color for the enabled state, with the opacity applied.
 func (t *Text) resolveTextColor(context *guigui.Context) color.Color {
 	clr, _ := t.baseStyle.Color()
+	if clr == nil && context.IsEnabled(t) {
+		clr = inheritedTextColor(context, t)
+	}
 	if clr == nil {
-		clr = draw.TextColor(context.ColorMode(), context.IsEnabled(t))
+		clr = draw.TextColor(colorScheme(context, t), context.IsEnabled(t))
 	}
 	if t.transparent > 0 {
 		clr = draw.ScaleAlpha(clr, 1-t.transparent)
@@ -259,12 +291,17 @@ func (t *Text) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 }
 
 func (t *Text) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	// The background is already drawn, while the glyphs are not yet.
+	if debugmode.CheckContrast() {
+		t.checkTextContrast(context, widgetBounds, dst)
+	}
+
 	// The placeholder is drawn beneath the core, which renders nothing while
 	// the value is visually empty.
 	if t.placeholder == "" || !t.core.IsEditable() || !t.core.IsVisuallyEmpty() {
 		return
 	}
-	clr := draw.TextColor(context.ColorMode(), false)
+	clr := draw.TextColor(colorScheme(context, t), false)
 	if t.transparent > 0 {
 		clr = draw.ScaleAlpha(clr, 1-t.transparent)
 	}
@@ -669,6 +706,7 @@ func (t *Text) SetWrapMode(wrapMode WrapMode) {
 
 // SetCaretBlinking sets whether the caret blinks.
 // The default value is true.
+// Even if caretBlinking is true, the caret does not blink when the desktop disables caret blinking.
 func (t *Text) SetCaretBlinking(caretBlinking bool) {
 	t.core.SetCaretBlinking(caretBlinking)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
 	"github.com/guigui-gui/guigui/basicwidget/internal/textwidget"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 type TextInputStyle int
@@ -182,6 +183,7 @@ func (t *TextInput) SetLineHeightMode(lineHeightMode LineHeightMode) {
 
 // SetCaretBlinking sets whether the caret blinks.
 // The default value is true.
+// Even if caretBlinking is true, the caret does not blink when the desktop disables caret blinking.
 func (t *TextInput) SetCaretBlinking(caretBlinking bool) {
 	t.textInput.SetCaretBlinking(caretBlinking)
 }
@@ -435,11 +437,12 @@ func (t *TextInput) Build(context *guigui.Context, adder *guigui.ChildAdder) err
 		t.supportText.SetScale(0.85)
 		t.supportText.SetMultiline(true)
 		t.supportText.SetHorizontalAlign(t.textInput.text.Text().HorizontalAlign())
+		cs := colorScheme(context, t)
 		var style TextStyle
 		if t.hasError {
-			style.SetColor(draw.TextColorFromTint(context.ColorMode(), draw.DangerTintColor()))
+			style.SetColor(draw.TextColorFromTint(cs, cs.TintColor(draw.TintDanger)))
 		} else {
-			style.SetColor(draw.TextColor(context.ColorMode(), false))
+			style.SetColor(draw.TextColor(cs, false))
 		}
 		t.supportText.SetBaseStyle(&style)
 	}
@@ -720,7 +723,7 @@ func (t *textInput) SetIcon(icon *ebiten.Image) {
 }
 
 func (t *textInput) textInputPaddingInScrollableContent(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.Padding {
-	u := UnitSize(context)
+	u := controlUnitSize(context, t)
 	var start, end, y int
 	if t.compactPadding {
 		start = u / 4
@@ -735,6 +738,10 @@ func (t *textInput) textInputPaddingInScrollableContent(context *guigui.Context,
 	}
 	start += t.paddingStart
 	end += t.paddingEnd
+	// The text's padding is physical: the start is at the left, where the icon is in a left-to-right direction.
+	if context.IsRightToLeft(t) {
+		start, end = end, start
+	}
 	return guigui.Padding{
 		Start:  start,
 		Top:    y,
@@ -800,10 +807,14 @@ func (t *textInput) Layout(context *guigui.Context, widgetBounds *guigui.WidgetB
 		iconBounds.Max = iconBounds.Min.Add(image.Pt(iconSize, iconSize))
 		bgBounds := bounds
 		bgBounds.Max.X = iconBounds.Max.X + UnitSize(context)/4
+		panelBounds.Min.X = iconBounds.Max.X
+
+		// In a right-to-left direction, the icon is at the right.
+		iconBounds = context.MirrorBounds(t, iconBounds, bounds)
+		bgBounds = context.MirrorBounds(t, bgBounds, bounds)
+		panelBounds = context.MirrorBounds(t, panelBounds, bounds)
 		layouter.LayoutWidget(&t.iconBackground, bgBounds)
 		layouter.LayoutWidget(&t.icon, iconBounds)
-
-		panelBounds.Min.X = iconBounds.Max.X
 	}
 	// Use the panel area (excluding any icon) as the container so that
 	// width-related decisions inside textInputText - in particular the
@@ -814,7 +825,7 @@ func (t *textInput) Layout(context *guigui.Context, widgetBounds *guigui.WidgetB
 }
 
 func (t *textInput) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
-	u := UnitSize(context)
+	u := controlUnitSize(context, t)
 	if t.intrinsicSize {
 		// WidgetBounds is not needed for intrinsic sizing.
 		padding := t.textInputPaddingInScrollableContent(context, nil)
@@ -892,7 +903,7 @@ func (t *textInputBackground) setEditable(editable bool) {
 
 func (t *textInputBackground) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
-	clr := draw.ContentBackgroundColor(context.ColorMode(), context.IsEnabled(t) && t.editable)
+	clr := draw.ContentBackgroundColor(colorScheme(context, t), context.IsEnabled(t) && t.editable)
 	draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
 }
 
@@ -912,7 +923,7 @@ func (t *textInputIconBackground) setEditable(editable bool) {
 
 func (t *textInputIconBackground) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
-	clr := draw.ContentBackgroundColor(context.ColorMode(), context.IsEnabled(t) && t.editable)
+	clr := draw.ContentBackgroundColor(colorScheme(context, t), context.IsEnabled(t) && t.editable)
 	draw.DrawRoundedRect(context, dst, bounds, clr, RoundedCornerRadius(context))
 }
 
@@ -932,11 +943,11 @@ func (t *textInputFrame) setError(hasError bool) {
 
 func (t *textInputFrame) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
-	clr1, clr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset)
-	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), float32(1*context.Scale()), draw.RoundedRectBorderTypeInset)
+	clr1, clr2 := draw.BorderColors(colorScheme(context, t), draw.RoundedRectBorderTypeInset)
+	draw.DrawRoundedRectBorder(context, dst, bounds, clr1, clr2, RoundedCornerRadius(context), borderWidth(context), draw.RoundedRectBorderTypeInset)
 	if t.hasError {
-		dclr1, dclr2 := draw.BorderDangerColors(context.ColorMode())
-		draw.DrawRoundedRectBorder(context, dst, bounds, dclr1, dclr2, RoundedCornerRadius(context), float32(1*context.Scale()), draw.RoundedRectBorderTypeRegular)
+		dclr1, dclr2 := draw.BorderDangerColors(colorScheme(context, t))
+		draw.DrawRoundedRectBorder(context, dst, bounds, dclr1, dclr2, RoundedCornerRadius(context), borderWidth(context), draw.RoundedRectBorderTypeRegular)
 	}
 }
 
@@ -1139,7 +1150,7 @@ func (t *textInputText) HandleButtonInput(context *guigui.Context, widgetBounds
 	// On macOS, Home/End scroll to the text edges without moving the caret. The
 	// focused Text handles every selection-changing key (including
 	// Shift+Home/End) and declines plain Home/End, which then bubble up here.
-	if context.KeyBindingMode() != guigui.KeyBindingModeCommand || ebiten.IsKeyPressed(ebiten.KeyShift) || t.panel == nil {
+	if context.KeyBindingMode() != guigui.KeyBindingModeCommand || inputsource.IsKeyPressed(ebiten.KeyShift) || t.panel == nil {
 		return guigui.HandleInputResult{}
 	}
 	switch {
@@ -1396,6 +1407,6 @@ type textInputFocus struct {
 func (t *textInputFocus) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
 	bounds := widgetBounds.Bounds()
 	w := textInputFocusBorderWidth(context)
-	clr := draw.FocusBorderColor(context.ColorMode())
+	clr := draw.FocusBorderColor(colorScheme(context, t))
 	draw.DrawRoundedRectBorder(context, dst, bounds, clr, clr, w+RoundedCornerRadius(context), float32(w), draw.RoundedRectBorderTypeRegular)
 }
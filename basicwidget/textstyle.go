@@ -23,6 +23,13 @@ type TextStyle struct {
 	s textstyle.Style
 }
 
+var _ guigui.StateKeyWriterTo = (*TextStyle)(nil)
+
+// WriteStateKeyTo implements [guigui.StateKeyWriterTo].
+func (s *TextStyle) WriteStateKeyTo(w *guigui.StateKeyWriter) {
+	s.writeStateKey(w)
+}
+
 // writeStateKey writes the style's properties into w.
 func (s *TextStyle) writeStateKey(w *guigui.StateKeyWriter) {
 	var familyID uint64
@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+)
+
+// Theme is a set of colors and metrics the widgets of this package are drawn with.
+//
+// The zero value is the default theme. Each field overrides a part of the default theme.
+// The accent tint follows the desktop's accent color, if any, unless Tints overrides it.
+//
+// A Theme is applied to the application by [SetTheme], or to a subtree by providing it
+// from an ancestor's [guigui.Widget.Env] with [EnvKeyTheme].
+// The colors follow the theme of each subtree, but the metrics, UnitSize, CornerRadius and BorderWidth,
+// are application-wide: they are taken only from the theme set by [SetTheme].
+// Do not modify a Theme after it is used. Create a new Theme instead.
+type Theme struct {
+	// Tints overrides the tints by name.
+	// The names are "neutral", "accent", "info", "success", "warning" and "danger".
+	// Most color tokens derive from the neutral or the accent tint,
+	// so overriding the accent tint recolors the focused, selected and primary widgets.
+	Tints map[string]color.Color
+
+	// Colors overrides the color tokens by name, such as "text" or "border-accent".
+	// See [ColorTokenNames] for the names.
+	Colors map[string]ThemeColor
+
+	// UnitSize is the spacing unit in device-independent pixels at the application scale 1.
+	// This is application-wide, and is ignored in a theme provided by [EnvKeyTheme].
+	// Paddings, gaps, the heights of controls, the font size and the line height derive from it.
+	// See [UnitSize], [FontSize] and [LineHeight].
+	// If UnitSize is 0, 24 is used.
+	UnitSize float64
+
+	// CornerRadius is the corner radius of rounded rectangles in device-independent pixels
+	// at the application scale 1. See [RoundedCornerRadius].
+	// This is application-wide, and is ignored in a theme provided by [EnvKeyTheme].
+	// If CornerRadius is 0, a quarter of the default unit size, 6, is used.
+	// Use a negative value for square corners.
+	CornerRadius float64
+
+	// BorderWidth is the width of borders in device-independent pixels at the application scale 1.
+	// This is application-wide, and is ignored in a theme provided by [EnvKeyTheme].
+	// If BorderWidth is 0, 1 is used.
+	BorderWidth float64
+
+	compiled   *draw.Theme
+	compileErr error
+
+	// invalidReported is true after the error of an invalid theme provided by [EnvKeyTheme] is logged.
+	invalidReported bool
+}
+
+// Validate returns an error if the theme has an unknown tint or color token, or an invalid color.
+//
+// [SetTheme] and [LoadTheme] validate the theme. Validate a theme built in code before providing it
+// with [EnvKeyTheme]: an invalid theme provided by EnvKeyTheme is logged, and the theme set by
+// [SetTheme] is used instead.
+func (t *Theme) Validate() error {
+	_, err := t.compile()
+	return err
+}
+
+// ThemeColor is a color token of a [Theme]: a tint and a lightness for each color mode.
+type ThemeColor struct {
+	// Tint is the name of a tint of the theme, such as "neutral" or "accent",
+	// or a color in the form "#rrggbb" or "#rrggbbaa".
+	Tint string `json:"tint"`
+
+	// Light is the lightness in the light color mode, in the range [0, 1].
+	// 0 is the darkest and 1 is the lightest.
+	Light float64 `json:"light"`
+
+	// Dark is the lightness in the dark color mode, in the range [0, 1].
+	Dark float64 `json:"dark"`
+}
+
+// EnvKeyTheme is the environment key for a *[Theme] applied to a subtree.
+//
+// An ancestor widget provides a theme to its descendants by returning it from [guigui.Widget.Env].
+// The metrics of the theme, UnitSize, CornerRadius and BorderWidth, are application-wide
+// and are taken only from the theme set by [SetTheme].
+//
+// The provided theme should be validated with [Theme.Validate] beforehand.
+// An invalid theme is logged, and the theme set by [SetTheme] is used instead.
+//
+// After changing the provided theme, call [guigui.RequestRedraw] with the ancestor.
+var EnvKeyTheme guigui.EnvKey = guigui.GenerateEnvKey()
+
+var defaultTheme = &Theme{}
+
+// appTheme is the theme set by SetTheme.
+var appTheme = defaultTheme
+
+// SetTheme sets the theme of the application. If theme is nil, the default theme is used.
+//
+// SetTheme returns an error if the theme has an unknown tint or color token.
+// After SetTheme, call [guigui.RequestRebuild] and [guigui.RequestRedraw] with the root widget
+// to apply the theme to the whole screen.
+func SetTheme(theme *Theme) error {
+	if theme == nil {
+		theme = defaultTheme
+	}
+	if err := theme.Validate(); err != nil {
+		return err
+	}
+	appTheme = theme
+	draw.SetAppTheme(theme.compiled)
+	return nil
+}
+
+// ColorTokenNames returns the names of the color tokens of a [Theme].
+func ColorTokenNames() []string {
+	return slices.Clone(draw.TokenNames())
+}
+
+// DefaultThemeColor returns the color token of the default theme by name.
+func DefaultThemeColor(name string) (ThemeColor, bool) {
+	spec, ok := draw.DefaultTokenSpec(name)
+	if !ok {
+		return ThemeColor{}, false
+	}
+	return ThemeColor{
+		Tint:  draw.TintNames[spec.Tint],
+		Light: spec.Light,
+		Dark:  spec.Dark,
+	}, true
+}
+
+// LoadTheme reads a theme in JSON from r.
+//
+// The JSON object has the following optional members, which correspond to the fields of [Theme]:
+//
+//	{
+//	  "tints": {"accent": "#7c3aed"},
+//	  "colors": {"text": {"tint": "neutral", "light": 0.15, "dark": 0.85}},
+//	  "unitSize": 24,
+//	  "cornerRadius": 4,
+//	  "borderWidth": 1
+//	}
+func LoadTheme(r io.Reader) (*Theme, error) {
+	var j struct {
+		Tints        map[string]string     `json:"tints"`
+		Colors       map[string]ThemeColor `json:"colors"`
+		UnitSize     float64               `json:"unitSize"`
+		CornerRadius float64               `json:"cornerRadius"`
+		BorderWidth  float64               `json:"borderWidth"`
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&j); err != nil {
+		return nil, fmt.Errorf("basicwidget: decoding a theme failed: %w", err)
+	}
+	t := &Theme{
+		Colors:       j.Colors,
+		UnitSize:     j.UnitSize,
+		CornerRadius: j.CornerRadius,
+		BorderWidth:  j.BorderWidth,
+	}
+	if len(j.Tints) > 0 {
+		t.Tints = map[string]color.Color{}
+		for name, str := range j.Tints {
+			c, err := parseHexColor(str)
+			if err != nil {
+				return nil, fmt.Errorf("basicwidget: tint %q: %w", name, err)
+			}
+			t.Tints[name] = c
+		}
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Theme) compile() (*draw.Theme, error) {
+	if t.compiled != nil || t.compileErr != nil {
+		return t.compiled, t.compileErr
+	}
+	if t == defaultTheme {
+		t.compiled = draw.DesktopTheme()
+		return t.compiled, nil
+	}
+
+	tints := draw.DesktopTints()
+	if len(t.Tints) > 0 {
+		if tints == nil {
+			tints = make([]color.Color, len(draw.TintNames))
+		}
+		for name, c := range t.Tints {
+			tint, ok := tintFromName(name)
+			if !ok {
+				t.compileErr = fmt.Errorf("basicwidget: unknown tint: %q", name)
+				return nil, t.compileErr
+			}
+			tints[tint] = c
+		}
+	}
+	var tokens map[string]draw.TokenSpec
+	if len(t.Colors) > 0 {
+		tokens = make(map[string]draw.TokenSpec, len(t.Colors))
+		for name, c := range t.Colors {
+			spec := draw.TokenSpec{
+				Light: c.Light,
+				Dark:  c.Dark,
+			}
+			if tint, ok := tintFromName(c.Tint); ok {
+				spec.Tint = tint
+			} else {
+				clr, err := parseHexColor(c.Tint)
+				if err != nil {
+					t.compileErr = fmt.Errorf("basicwidget: color token %q: %w", name, err)
+					return nil, t.compileErr
+				}
+				spec.Color = clr
+			}
+			tokens[name] = spec
+		}
+	}
+	compiled, err := draw.NewTheme(tints, tokens)
+	if err != nil {
+		t.compileErr = err
+		return nil, err
+	}
+	t.compiled = compiled
+	return compiled, nil
+}
+
+func tintFromName(name string) (draw.Tint, bool) {
+	idx := slices.Index(draw.TintNames[:], name)
+	if idx < 0 {
+		return 0, false
+	}
+	return draw.Tint(idx), true
+}
+
+// parseHexColor parses a color in the form "#rrggbb" or "#rrggbbaa".
+func parseHexColor(str string) (color.Color, error) {
+	hex, ok := strings.CutPrefix(str, "#")
+	if !ok || (len(hex) != 6 && len(hex) != 8) {
+		return nil, fmt.Errorf("invalid color: %q", str)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color: %q", str)
+	}
+	if len(hex) == 6 {
+		v = v<<8 | 0xff
+	}
+	// color.NRGBA as the components in the notation are not premultiplied.
+	return color.NRGBA{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, nil
+}
+
+// themeOf returns the theme applied to the widget.
+func themeOf(context *guigui.Context, widget guigui.Widget) *Theme {
+	if v, ok := context.Env(widget, EnvKeyTheme); ok {
+		if t, ok := v.(*Theme); ok && t != nil {
+			return t
+		}
+	}
+	return appTheme
+}
+
+// colorScheme returns the color scheme to draw the widget with.
+func colorScheme(context *guigui.Context, widget guigui.Widget) draw.ColorScheme {
+	theme := themeOf(context, widget)
+	t, err := theme.compile()
+	if err != nil {
+		// Only a theme provided by EnvKeyTheme can be invalid, as SetTheme validates the theme.
+		if !theme.invalidReported {
+			theme.invalidReported = true
+			slog.Error("basicwidget: invalid theme provided by EnvKeyTheme", "error", err)
+		}
+		return appColorScheme(context)
+	}
+	return draw.NewColorScheme(context.ColorMode(), t)
+}
+
+// appColorScheme returns the color scheme of the application theme.
+// Use colorScheme instead where a widget is available, to respect the theme of the subtree.
+func appColorScheme(context *guigui.Context) draw.ColorScheme {
+	return draw.NewColorScheme(context.ColorMode(), nil)
+}
+
+// borderWidth returns the width of regular borders.
+func borderWidth(context *guigui.Context) float32 {
+	w := appTheme.BorderWidth
+	if w == 0 {
+		w = 1
+	}
+	return float32(w * context.Scale())
+}
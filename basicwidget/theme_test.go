@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package basicwidget_test
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/guigui-gui/guigui/basicwidget"
+)
+
+func TestLoadTheme(t *testing.T) {
+	theme, err := basicwidget.LoadTheme(strings.NewReader(`{
+  "tints": {"accent": "#7c3aed", "danger": "#e11d4880"},
+  "colors": {"text": {"tint": "neutral", "light": 0.15, "dark": 0.85}, "focus-border": {"tint": "#ff00aa", "light": 0.7, "dark": 0.3}},
+  "unitSize": 20,
+  "cornerRadius": -1,
+  "borderWidth": 2
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := theme.Tints["accent"], (color.NRGBA{R: 0x7c, G: 0x3a, B: 0xed, A: 0xff}); got != want {
+		t.Errorf("accent tint: got: %v, want: %v", got, want)
+	}
+	if got, want := theme.Tints["danger"], (color.NRGBA{R: 0xe1, G: 0x1d, B: 0x48, A: 0x80}); got != want {
+		t.Errorf("danger tint: got: %v, want: %v", got, want)
+	}
+	if got, want := theme.Colors["text"], (basicwidget.ThemeColor{Tint: "neutral", Light: 0.15, Dark: 0.85}); got != want {
+		t.Errorf("text color: got: %v, want: %v", got, want)
+	}
+	if theme.UnitSize != 20 || theme.CornerRadius != -1 || theme.BorderWidth != 2 {
+		t.Errorf("metrics: got: (%g, %g, %g), want: (20, -1, 2)", theme.UnitSize, theme.CornerRadius, theme.BorderWidth)
+	}
+}
+
+func TestLoadThemeInvalid(t *testing.T) {
+	for _, input := range []string{
+		`{"tints": {"primary": "#000000"}}`,
+		`{"tints": {"accent": "blue"}}`,
+		`{"colors": {"no-such-token": {"tint": "neutral", "light": 0, "dark": 1}}}`,
+		`{"colors": {"text": {"tint": "purple", "light": 0, "dark": 1}}}`,
+		`{"unknownField": 1}`,
+	} {
+		if _, err := basicwidget.LoadTheme(strings.NewReader(input)); err == nil {
+			t.Errorf("LoadTheme(%q) must return an error", input)
+		}
+	}
+}
+
+func TestColorTokenNames(t *testing.T) {
+	names := basicwidget.ColorTokenNames()
+	for _, name := range []string{"text", "accent", "border", "border-accent-inset-1", "focus-border"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("ColorTokenNames() must contain %q", name)
+		}
+		if _, ok := basicwidget.DefaultThemeColor(name); !ok {
+			t.Errorf("DefaultThemeColor(%q) must be found", name)
+		}
+	}
+	if got, ok := basicwidget.DefaultThemeColor("accent"); !ok || got.Tint != "accent" {
+		t.Errorf("DefaultThemeColor(%q): got: %v, want: the accent tint", "accent", got)
+	}
+}
+
+func TestThemeValidate(t *testing.T) {
+	valid := []*basicwidget.Theme{
+		{},
+		{Tints: map[string]color.Color{"accent": color.NRGBA{R: 0x7c, G: 0x3a, B: 0xed, A: 0xff}}},
+		{Colors: map[string]basicwidget.ThemeColor{"text": {Tint: "#ff00aa", Light: 0.2, Dark: 0.8}}},
+	}
+	for _, theme := range valid {
+		if err := theme.Validate(); err != nil {
+			t.Errorf("Validate(%+v): got: %v, want: nil", theme, err)
+		}
+	}
+
+	invalid := []*basicwidget.Theme{
+		{Tints: map[string]color.Color{"primary": color.Black}},
+		{Colors: map[string]basicwidget.ThemeColor{"no-such-token": {Tint: "neutral"}}},
+		{Colors: map[string]basicwidget.ThemeColor{"text": {Tint: "purple"}}},
+	}
+	for _, theme := range invalid {
+		if err := theme.Validate(); err == nil {
+			t.Errorf("Validate(%+v): got: nil, want: an error", theme)
+		}
+		// The error is kept.
+		if err := theme.Validate(); err == nil {
+			t.Errorf("Validate(%+v) again: got: nil, want: an error", theme)
+		}
+		if err := basicwidget.SetTheme(theme); err == nil {
+			t.Errorf("SetTheme(%+v): got: nil, want: an error", theme)
+		}
+	}
+}
@@ -8,14 +8,16 @@ import (
 	"image/color"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var (
-	toggleEventValueChanged guigui.EventKey = guigui.GenerateEventKey()
+	// ToggleEventValueChanged is the event dispatched when a [Toggle]'s value is changed.
+	// An ancestor can observe the event with a capturing or bubbling handler.
+	ToggleEventValueChanged = guigui.GenerateEventKey1[bool]()
 )
 
 type Toggle struct {
@@ -30,7 +32,7 @@ type Toggle struct {
 }
 
 func (t *Toggle) OnValueChanged(f func(context *guigui.Context, value bool)) {
-	guigui.SetEventHandler(t, toggleEventValueChanged, f)
+	ToggleEventValueChanged.SetHandler(t, f)
 }
 
 func (t *Toggle) Value() bool {
@@ -50,7 +52,7 @@ func (t *Toggle) SetValue(value bool) {
 	if t.onceDraw {
 		t.count = toggleMaxCount() - t.count
 	}
-	guigui.DispatchEvent(t, toggleEventValueChanged, value)
+	ToggleEventValueChanged.Dispatch(t, value)
 }
 
 func toggleMaxCount() int {
@@ -58,13 +60,13 @@ func toggleMaxCount() int {
 }
 
 func (t *Toggle) HandlePointingInput(context *guigui.Context, widgetBounds *guigui.WidgetBounds) guigui.HandleInputResult {
-	if context.IsEnabled(t) && widgetBounds.IsHitAtCursor() && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	if context.IsEnabled(t) && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		context.SetFocused(t, true)
 		t.pressed = true
 		t.SetValue(!t.value)
 		return guigui.HandleInputByWidget(t)
 	}
-	if !context.IsEnabled(t) || !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if !context.IsEnabled(t) || !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		t.pressed = false
 	}
 	return guigui.HandleInputResult{}
@@ -94,18 +96,18 @@ func (t *Toggle) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 
 	bounds := widgetBounds.Bounds()
 
-	cm := context.ColorMode()
-	thumbColor := draw.ThumbColor(cm, context.IsEnabled(t))
+	cs := colorScheme(context, t)
+	thumbColor := draw.ThumbColor(cs, context.IsEnabled(t))
 	if t.isActive(context, widgetBounds) {
-		thumbColor = draw.ThumbPressedColor(cm)
+		thumbColor = draw.ThumbPressedColor(cs)
 	} else if t.canPress(context, widgetBounds) {
-		thumbColor = draw.ThumbHoveredColor(cm)
+		thumbColor = draw.ThumbHoveredColor(cs)
 	}
 
 	// Background
 	pressed := t.isActive(context, widgetBounds)
-	bgColorOff := draw.TrackColor(cm, false, pressed)
-	bgColorOn := draw.TrackColor(cm, true, pressed)
+	bgColorOff := draw.TrackColor(cs, false, pressed)
+	bgColorOn := draw.TrackColor(cs, true, pressed)
 	var bgColor color.Color
 	if t.value && context.IsEnabled(t) {
 		bgColor = draw.MixColors(bgColorOff, bgColorOn, rate)
@@ -119,12 +121,12 @@ func (t *Toggle) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 	b := bounds
 	halfHeight := b.Dy() / 2
 	b.Max.Y = b.Min.Y + halfHeight
-	strokeWidth := float32(1 * context.Scale())
+	strokeWidth := borderWidth(context)
 	var borderClr1, borderClr2 color.Color
 	if t.value && context.IsEnabled(t) {
-		borderClr1, borderClr2 = draw.BorderAccentSecondaryColors(context.ColorMode(), draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 = draw.BorderAccentSecondaryColors(colorScheme(context, t), draw.RoundedRectBorderTypeInset)
 	} else {
-		borderClr1, borderClr2 = draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeInset)
+		borderClr1, borderClr2 = draw.BorderColors(colorScheme(context, t), draw.RoundedRectBorderTypeInset)
 	}
 	dstSub := dst.RecyclableSubImage(b)
 	defer dstSub.Recycle()
@@ -140,7 +142,7 @@ func (t *Toggle) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 		cx = int((1-rate)*cxOn + rate*cxOff)
 	}
 	cy := bounds.Min.Y + r
-	thumbClr1, thumbClr2 := draw.BorderColors(context.ColorMode(), draw.RoundedRectBorderTypeOutset)
+	thumbClr1, thumbClr2 := draw.BorderColors(colorScheme(context, t), draw.RoundedRectBorderTypeOutset)
 	thumbBounds := image.Rect(cx-r, cy-r, cx+r, cy+r)
 	draw.DrawRoundedRect(context, dst, thumbBounds, thumbColor, r)
 	draw.DrawRoundedRectBorder(context, dst, thumbBounds, thumbClr1, thumbClr2, r, strokeWidth, draw.RoundedRectBorderTypeOutset)
@@ -156,11 +158,11 @@ func (t *Toggle) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 }
 
 func (t *Toggle) canPress(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(t) && widgetBounds.IsHitAtCursor() && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	return context.IsEnabled(t) && widgetBounds.IsHitAtCursor() && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft)
 }
 
 func (t *Toggle) isActive(context *guigui.Context, widgetBounds *guigui.WidgetBounds) bool {
-	return context.IsEnabled(t) && widgetBounds.IsHitAtCursor() && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && t.pressed
+	return context.IsEnabled(t) && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) && t.pressed
 }
 
 func (t *Toggle) Measure(context *guigui.Context, constraints guigui.Constraints) image.Point {
@@ -10,6 +10,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // TooltipArea is a standalone widget that shows a balloon popup when the mouse cursor hovers
@@ -67,7 +68,7 @@ func (t *TooltipArea) hitAreaAtCursor(context *guigui.Context, widgetBounds *gui
 	if !t.hasHitAreas {
 		return widgetBounds.Bounds(), true
 	}
-	cursorPosition := image.Pt(ebiten.CursorPosition())
+	cursorPosition := image.Pt(inputsource.CursorPosition())
 	for _, area := range t.hitAreas {
 		if cursorPosition.In(area) {
 			return area, true
@@ -155,7 +156,7 @@ func (t *TooltipArea) Tick(context *guigui.Context, widgetBounds *guigui.WidgetB
 		}
 		// Freeze the position and the anchor once the tooltip is shown.
 		if !t.toShowTooltip && !t.popup.IsOpen() {
-			t.showPosition = image.Pt(ebiten.CursorPosition())
+			t.showPosition = image.Pt(inputsource.CursorPosition())
 			t.showArea = area
 		}
 		t.hoverTicks++
@@ -7,13 +7,13 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget/internal/draw"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
-var virtualScrollPanelEventScroll guigui.EventKey = guigui.GenerateEventKey()
+var virtualScrollPanelEventScroll = guigui.GenerateEventKey3[int, int, float64]()
 
 // virtualScrollContent is the interface that [virtualScrollPanel] requires
 // from its content widget.
@@ -156,7 +156,7 @@ func (p *virtualScrollPanel) scrollOffset() (float64, float64) {
 
 // OnScroll sets the handler invoked when the scroll position changes.
 func (p *virtualScrollPanel) OnScroll(callback func(context *guigui.Context, topItemIndex, topItemOffset int, offsetX float64)) {
-	guigui.SetEventHandler(p, virtualScrollPanelEventScroll, callback)
+	virtualScrollPanelEventScroll.SetHandler(p, callback)
 }
 
 // forceSetScrollOffsetX sets the horizontal scroll offset.
@@ -623,7 +623,7 @@ func (p *virtualScrollPanel) isHBarVisible(context *guigui.Context, widgetBounds
 	if !widgetBounds.IsHitAtCursor() {
 		return false
 	}
-	pt := image.Pt(ebiten.CursorPosition())
+	pt := image.Pt(inputsource.CursorPosition())
 	return pt.In(p.horizontalBarBounds(context, widgetBounds))
 }
 
@@ -637,7 +637,7 @@ func (p *virtualScrollPanel) isVBarVisible(context *guigui.Context, widgetBounds
 	if !widgetBounds.IsHitAtCursor() {
 		return false
 	}
-	pt := image.Pt(ebiten.CursorPosition())
+	pt := image.Pt(inputsource.CursorPosition())
 	return pt.In(p.verticalBarBounds(context, widgetBounds))
 }
 
@@ -767,7 +767,7 @@ func (p *virtualScrollPanel) dispatchScrollEventIfNeeded() {
 	p.scrolledTopItemIndex = p.topItemIndex
 	p.scrolledTopItemOffset = p.topItemOffset
 	p.scrolledOffsetX = p.offsetX
-	guigui.DispatchEvent(p, virtualScrollPanelEventScroll, p.topItemIndex, p.topItemOffset, p.offsetX)
+	virtualScrollPanelEventScroll.Dispatch(p, p.topItemIndex, p.topItemOffset, p.offsetX)
 }
 
 // vThumbHeight returns the vertical thumb height.
@@ -969,8 +969,8 @@ func (s *virtualScrollVBar) HandlePointingInput(context *guigui.Context, widgetB
 	}
 	trackHeight := float64(bounds.Dy()) - 2*padding - barHeight
 
-	if !s.dragging && widgetBounds.IsHitAtCursor() && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
+	if !s.dragging && widgetBounds.IsHitAtCursor() && inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := inputsource.CursorPosition()
 		tb := s.thumbBounds
 		topIdx, topOff := s.panel.topItem()
 
@@ -1008,8 +1008,8 @@ func (s *virtualScrollVBar) HandlePointingInput(context *guigui.Context, widgetB
 		s.dragging = false
 	}
 
-	if s.dragging && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		_, y := ebiten.CursorPosition()
+	if s.dragging && inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		_, y := inputsource.CursorPosition()
 		dy := y - s.draggingStartPosition
 		if dy != 0 && trackHeight > 0 {
 			if s.panel.allHeightsMeasured {
@@ -1070,7 +1070,7 @@ func (s *virtualScrollVBar) HandlePointingInput(context *guigui.Context, widgetB
 		return guigui.HandleInputByWidget(s)
 	}
 
-	if s.dragging && !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	if s.dragging && !inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
 		s.dragging = false
 	}
 
@@ -1089,7 +1089,7 @@ func (s *virtualScrollVBar) Draw(context *guigui.Context, widgetBounds *guigui.W
 		return
 	}
 	s.onceDraw = true
-	barColor := draw.ScrollBarColor(context.ColorMode())
+	barColor := draw.ScrollBarColor(colorScheme(context, s))
 	barColor = draw.ScaleAlpha(barColor, s.alpha)
 	draw.DrawRoundedRect(context, dst, s.thumbBounds, barColor, RoundedCornerRadius(context))
 }
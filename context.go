@@ -56,6 +56,16 @@ type Context struct {
 	envSource               EnvSource
 	preferredKeyBindingMode KeyBindingMode
 
+	// colorMode is the color mode of the tree rendered by [Context.RenderWidget], or [ebiten.ColorModeUnknown]
+	// to follow Ebitengine's color mode.
+	colorMode ebiten.ColorMode
+
+	// localeReadingDirectionCache is the reading direction of the first locale, or ReadingDirectionAuto if not cached.
+	localeReadingDirectionCache ReadingDirection
+
+	// callingWidget is the widget whose Layout, Draw, HandlePointingInput, HandleButtonInput or Tick is being called.
+	callingWidget Widget
+
 	defaultTickMethodCalled bool
 }
 
@@ -89,6 +99,9 @@ func (c *Context) SetAppScale(scale float64) {
 //
 // ColorMode never returns [ebiten.ColorModeUnknown].
 func (c *Context) ColorMode() ebiten.ColorMode {
+	if c.colorMode != ebiten.ColorModeUnknown {
+		return c.colorMode
+	}
 	if mode := ebiten.PreferredColorMode(); mode != ebiten.ColorModeUnknown {
 		return mode
 	}
@@ -102,6 +115,9 @@ func (c *Context) ColorMode() ebiten.ColorMode {
 //
 // PreferredColorMode might return [ebiten.ColorModeUnknown] if the color mode is not set.
 func (c *Context) PreferredColorMode() ebiten.ColorMode {
+	if c.colorMode != ebiten.ColorModeUnknown {
+		return c.colorMode
+	}
 	return ebiten.PreferredColorMode()
 }
 
@@ -235,6 +251,7 @@ func (c *Context) SetAppLocales(locales []language.Tag) {
 	c.locales = slices.Delete(c.locales, 0, len(c.locales))
 	c.locales = append(c.locales, locales...)
 	c.allLocales = slices.Delete(c.allLocales, 0, len(c.allLocales))
+	c.localeReadingDirectionCache = ReadingDirectionAuto
 
 	c.app.enqueueRedrawRegion(c.app.bounds(), redrawReasonsOf(requestRedrawReasonLocale), nil)
 }
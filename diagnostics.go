@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"github.com/guigui-gui/guigui/internal/debugmode"
+)
+
+const (
+	rebuildReasonEventDispatched  = "event dispatched"
+	rebuildReasonInputHandled     = "input handled"
+	rebuildReasonRedrawRequested  = "redraw requested"
+	rebuildReasonRebuildRequested = "rebuild requested"
+	rebuildReasonPostedFuncsRan   = "posted functions ran"
+)
+
+var theDiagnosticsLogger atomic.Pointer[slog.Logger]
+
+// SetDiagnosticsHandler sets the handler to receive the diagnostic records explaining why the widget tree is rebuilt
+// and why regions are redrawn.
+//
+// The records are at [slog.LevelInfo]. A record with the message "rebuild" is emitted when a rebuild is scheduled,
+// and a record with the message "redraw" is emitted when a region is requested to be redrawn.
+// The records have these attributes:
+//
+//   - reason: the reason, such as "input handled" or "state key changed for build".
+//   - widget: the type of the widget causing the rebuild or the redraw, if any.
+//   - path: the types of the widget and its ancestors from the root, separated by " > ", if any.
+//   - region: the region to redraw, for a redraw.
+//   - at: the call site of [RequestRebuild] or [RequestRedraw], if any.
+//
+// A rebuild requested by [RequestRebuild] from several call sites emits a record for each call site.
+//
+// If h is nil, the diagnostic records are emitted to the default logger only when GUIGUI_DEBUG includes showbuildlogs
+// for rebuilds, and showrenderingregions for redraws.
+//
+// SetDiagnosticsHandler is concurrent-safe.
+func SetDiagnosticsHandler(h slog.Handler) {
+	if h == nil {
+		theDiagnosticsLogger.Store(nil)
+		return
+	}
+	theDiagnosticsLogger.Store(slog.New(h))
+}
+
+// rebuildLogger returns the logger for the rebuild records, or nil if the records are not needed.
+func rebuildLogger() *slog.Logger {
+	if l := theDiagnosticsLogger.Load(); l != nil {
+		return l
+	}
+	if debugmode.ShowBuildLogs() {
+		return slog.Default()
+	}
+	return nil
+}
+
+// redrawLogger returns the logger for the redraw records, or nil if the records are not needed.
+func redrawLogger() *slog.Logger {
+	if l := theDiagnosticsLogger.Load(); l != nil {
+		return l
+	}
+	if debugmode.ShowRenderingRegions() {
+		return slog.Default()
+	}
+	return nil
+}
+
+// logRebuild emits a rebuild record. widget is the widget causing the rebuild, or nil.
+func logRebuild(reason string, widget Widget, attrs ...slog.Attr) {
+	l := rebuildLogger()
+	if l == nil || !l.Enabled(context.Background(), slog.LevelInfo) {
+		return
+	}
+	attrs = append([]slog.Attr{slog.String("reason", reason)}, attrs...)
+	attrs = appendWidgetAttrs(attrs, widget)
+	l.LogAttrs(context.Background(), slog.LevelInfo, "rebuild", attrs...)
+}
+
+// logRedraw emits redraw records for the reasons. widget is the widget requesting the redraw, or nil.
+func logRedraw(region image.Rectangle, reasons requestRedrawReasons, widget Widget) {
+	l := redrawLogger()
+	if l == nil || !l.Enabled(context.Background(), slog.LevelInfo) {
+		return
+	}
+	for reason := range reasons.all() {
+		attrs := []slog.Attr{
+			slog.String("reason", reason.String()),
+		}
+		attrs = appendWidgetAttrs(attrs, widget)
+		attrs = append(attrs, slog.String("region", region.String()))
+		if reason == requestRedrawReasonExplicitRequest && widget != nil {
+			if at := widget.widgetState().redrawRequestedAt; at != "" {
+				attrs = append(attrs, slog.String("at", at))
+			}
+		}
+		l.LogAttrs(context.Background(), slog.LevelInfo, "redraw", attrs...)
+	}
+}
+
+func appendWidgetAttrs(attrs []slog.Attr, widget Widget) []slog.Attr {
+	if widget == nil {
+		return attrs
+	}
+	return append(attrs, slog.String("widget", fmt.Sprintf("%T", widget)), slog.String("path", widgetPath(widget)))
+}
+
+// widgetPath returns the types of the widget and its ancestors from the root, separated by " > ".
+func widgetPath(widget Widget) string {
+	var types []string
+	for w := widget; w != nil; w = w.widgetState().parent {
+		types = append(types, fmt.Sprintf("%T", w))
+	}
+	var sb strings.Builder
+	for i := len(types) - 1; i >= 0; i-- {
+		sb.WriteString(types[i])
+		if i > 0 {
+			sb.WriteString(" > ")
+		}
+	}
+	return sb.String()
+}
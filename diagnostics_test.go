@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"context"
+	"image"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+// recordingHandler is a slog.Handler keeping the records it handles.
+type recordingHandler struct {
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return true
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordingHandler) WithGroup(name string) slog.Handler {
+	return h
+}
+
+// attrs returns the attributes of the records with the message, as maps from keys to values.
+func (h *recordingHandler) attrs(msg string) []map[string]string {
+	var attrs []map[string]string
+	for _, r := range h.records {
+		if r.Message != msg {
+			continue
+		}
+		m := map[string]string{}
+		r.Attrs(func(a slog.Attr) bool {
+			m[a.Key] = a.Value.String()
+			return true
+		})
+		attrs = append(attrs, m)
+	}
+	return attrs
+}
+
+// rebuildRequestedAttrs returns the attributes of the rebuild records by RequestRebuild.
+func (h *recordingHandler) rebuildRequestedAttrs() []map[string]string {
+	var attrs []map[string]string
+	for _, a := range h.attrs("rebuild") {
+		if a["reason"] == "rebuild requested" {
+			attrs = append(attrs, a)
+		}
+	}
+	return attrs
+}
+
+type diagnosticsChild struct {
+	guigui.DefaultWidget
+
+	value int
+}
+
+func (d *diagnosticsChild) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	w.WriteInt(d.value)
+}
+
+type diagnosticsRoot struct {
+	guigui.DefaultWidget
+
+	child diagnosticsChild
+}
+
+func (d *diagnosticsRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&d.child)
+	return nil
+}
+
+func (d *diagnosticsRoot) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	layouter.LayoutWidget(&d.child, widgetBounds.Bounds())
+}
+
+func newDiagnosticsApp(t *testing.T) (*guigui.AppForTesting, *diagnosticsRoot, *recordingHandler) {
+	t.Helper()
+	var root diagnosticsRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	var h recordingHandler
+	guigui.SetDiagnosticsHandler(&h)
+	t.Cleanup(func() {
+		guigui.SetDiagnosticsHandler(nil)
+	})
+	return app, &root, &h
+}
+
+func TestDiagnosticsStateKeyChanged(t *testing.T) {
+	app, root, h := newDiagnosticsApp(t)
+
+	// A timer changes the state after the build, as an input handler would.
+	app.Context().AfterFunc(&root.child, 0, func(context *guigui.Context) {
+		root.child.value++
+	})
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	const (
+		wantReason = "state key changed for build"
+		wantWidget = "*guigui_test.diagnosticsChild"
+		wantPath   = "*guigui_test.diagnosticsRoot > *guigui_test.diagnosticsChild"
+	)
+	var found bool
+	for _, a := range h.attrs("redraw") {
+		if a["reason"] != wantReason {
+			continue
+		}
+		found = true
+		if got := a["widget"]; got != wantWidget {
+			t.Errorf("widget: got: %q, want: %q", got, wantWidget)
+		}
+		if got := a["path"]; got != wantPath {
+			t.Errorf("path: got: %q, want: %q", got, wantPath)
+		}
+		if got, want := a["region"], image.Rect(0, 0, 100, 100).String(); got != want {
+			t.Errorf("region: got: %q, want: %q", got, want)
+		}
+	}
+	if !found {
+		t.Errorf("no redraw record with the reason %q: %v", wantReason, h.attrs("redraw"))
+	}
+	if len(h.attrs("rebuild")) == 0 {
+		t.Errorf("no rebuild record")
+	}
+}
+
+func TestDiagnosticsRebuildRequestedCallSites(t *testing.T) {
+	app, _, h := newDiagnosticsApp(t)
+
+	for range 2 {
+		guigui.RequestRebuild()
+	}
+	guigui.RequestRebuild()
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	var ats []string
+	for _, a := range h.rebuildRequestedAttrs() {
+		if _, ok := a["widget"]; ok {
+			t.Errorf("widget: got: %q, want: none", a["widget"])
+		}
+		ats = append(ats, a["at"])
+	}
+	// The call in the loop is recorded once, and the other call is recorded separately.
+	if len(ats) != 2 {
+		t.Fatalf("call sites: got: %q, want: 2 call sites", ats)
+	}
+	if ats[0] == ats[1] {
+		t.Errorf("call sites: got: %q, want: distinct call sites", ats)
+	}
+	for _, at := range ats {
+		if !strings.HasPrefix(filepath.Base(at), "diagnostics_test.go:") {
+			t.Errorf("at: got: %q, want: a line in diagnostics_test.go", at)
+		}
+	}
+
+	// The call sites are cleared after the rebuild.
+	h.records = nil
+	guigui.RequestRebuild()
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := len(h.rebuildRequestedAttrs()); got != 1 {
+		t.Errorf("rebuild requested records: got: %d, want: 1", got)
+	}
+}
@@ -69,6 +69,23 @@
 //   - showrenderingregions: visualizes the regions that are redrawn.
 //   - showbuildlogs: logs why the widget tree is rebuilt.
 //   - showinputlogs: logs which widget handled an input.
+//   - inspector: enables the widget inspector, which F12 shows and hides. The inspector highlights
+//     the widget under the cursor and shows its type, bounds, layer, states, state key, and parents.
+//     A click pins the widget, and the arrow keys browse the tree from it.
+//     While the inspector is shown, inputs are not dispatched to the widgets.
+//   - checkcontrast: logs text whose contrast ratio against the background beneath it is below
+//     the WCAG 2 AA requirement: 4.5 for normal text and 3 for large text. Only the basicwidget
+//     package's Text checks it, and the check reads pixels, which is slow.
+//   - profile=<file>: records the time each widget spends in each phase (Build, Layout, Measure,
+//     input handling, Tick, and Draw), the rebuild count, and the redraw reasons, and writes them
+//     to the file in the Chrome trace event format. The file can be viewed in Perfetto. Measure is recorded
+//     when it is called by LinearLayout or a wrapper widget like WidgetWithSize; a widget's direct call
+//     of its child's Measure is counted in the widget's own phase.
+//   - record=<file>: records the input of every tick to the file.
+//   - replay=<file>: replays the input recorded by record=<file> instead of the devices' input, tick by tick from
+//     the first tick. The window is resized to the recorded size. After the recording ends, the devices' input is used.
+//     Only the input Guigui and its widget packages read is replayed; input the application reads from Ebitengine
+//     directly is not.
 //   - devicescale=<float>: uses the given device scale factor instead of the monitor's.
 //   - emulateclipboard: replaces the system clipboard with an in-process one, so that copying
 //     and pasting leave the system clipboard untouched. The environment variable
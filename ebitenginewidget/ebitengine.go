@@ -11,24 +11,25 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
-	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/exp/vmhost"
 	"github.com/hajimehoshi/ebiten/v2/exp/vmhost/vmhostutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/inputrecord"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 var _ guigui.Widget = (*Ebitengine)(nil)
 
 var (
-	ebitengineEventLaunched     guigui.EventKey = guigui.GenerateEventKey()
-	ebitengineEventExited       guigui.EventKey = guigui.GenerateEventKey()
-	ebitengineEventError        guigui.EventKey = guigui.GenerateEventKey()
-	ebitengineEventTPSRequested guigui.EventKey = guigui.GenerateEventKey()
+	ebitengineEventLaunched       = guigui.GenerateEventKey0()
+	ebitengineEventExited         = guigui.GenerateEventKey0()
+	ebitengineEventError          = guigui.GenerateEventKey1[error]()
+	ebitengineEventTPSRequested   = guigui.GenerateEventKey1[int]()
+	ebitengineEventReplayFinished = guigui.GenerateEventKey0()
 )
 
 // GuestNotConnectedError is reported to the OnError handler when the launched binary did not connect
@@ -176,12 +177,8 @@ type Ebitengine struct {
 	// manualTicks is the number of updates requested by AdvanceTicks and not yet applied.
 	manualTicks int
 
-	// pendingErrsMu guards pendingErrs.
-	pendingErrsMu sync.Mutex
-
-	// pendingErrs holds errors recorded on other goroutines by dispatchErrorAsync, reported at the
-	// widget's next tick.
-	pendingErrs []error
+	// replay is the recording replayed on the guest instead of the window's input, or nil.
+	replay *inputrecord.Player
 }
 
 // SetBinaryPath sets the path to the guest binary to run. The binary must be an Ebitengine program
@@ -227,6 +224,20 @@ func (e *Ebitengine) SetInputForwardingEnabled(enabled bool) {
 	e.inputForwardingDisabled = !enabled
 }
 
+// SetInputReplay sets a recording to replay on the guest instead of the window's input. Passing nil stops
+// the replay.
+//
+// While a recording is replayed, the window's input is not forwarded, and the guest is advanced by exactly
+// one tick per replayed tick, one each widget tick, regardless of [Ebitengine.SetTPS] and
+// [Ebitengine.AdvanceTicks]. When all the ticks are replayed, the guest is paused and the handler set by
+// [Ebitengine.OnReplayFinished] is called.
+//
+// The replay is faithful only when the guest's outside size matches the recorded window size, reported by
+// [inputrecord.Player.Size], so size the widget accordingly.
+func (e *Ebitengine) SetInputReplay(player *inputrecord.Player) {
+	e.replay = player
+}
+
 // SetAudioEnabled sets whether the audio the guest plays is played on the host. It is enabled by
 // default. While disabled, the guest's audio sources are not consumed, so the guest observes no audio
 // playback progress.
@@ -236,40 +247,48 @@ func (e *Ebitengine) SetAudioEnabled(enabled bool) {
 
 // OnLaunched sets a handler called when a guest has connected and started running.
 func (e *Ebitengine) OnLaunched(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(e, ebitengineEventLaunched, f)
+	ebitengineEventLaunched.SetHandler(e, f)
 }
 
 // OnExited sets a handler called when the guest has terminated normally.
 func (e *Ebitengine) OnExited(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(e, ebitengineEventExited, f)
+	ebitengineEventExited.SetHandler(e, f)
 }
 
 // OnError sets a handler called when launching or driving the guest fails. Without a handler, failures
 // are logged.
 func (e *Ebitengine) OnError(f func(context *guigui.Context, err error)) {
-	guigui.SetEventHandler(e, ebitengineEventError, f)
+	ebitengineEventError.SetHandler(e, f)
 }
 
 // dispatchError reports err to the OnError handler, or logs it when no handler is registered, so a
 // failure is never dropped silently.
 func (e *Ebitengine) dispatchError(err error) {
-	if _, ok := guigui.DispatchEvent(e, ebitengineEventError, err); !ok {
+	if !ebitengineEventError.Dispatch(e, err) {
 		slog.Error(err.Error())
 	}
 }
 
-// dispatchErrorAsync records err to be reported like dispatchError at the widget's next tick. Unlike
-// dispatchError, it may be called from any goroutine.
+// dispatchErrorAsync reports err like dispatchError on the UI goroutine at the next tick, after the tree
+// is built, so err reaches the OnError handler set in that tick. Unlike dispatchError, it may be called
+// from any goroutine. The error is reported even when the widget is
+// closed, since the reaper goroutines can fail after Close.
 func (e *Ebitengine) dispatchErrorAsync(err error) {
-	e.pendingErrsMu.Lock()
-	defer e.pendingErrsMu.Unlock()
-	e.pendingErrs = append(e.pendingErrs, err)
+	guigui.Post(func(context *guigui.Context) {
+		e.dispatchError(err)
+	})
+}
+
+// OnReplayFinished sets a handler called when all the ticks of the recording set by
+// [Ebitengine.SetInputReplay] are replayed.
+func (e *Ebitengine) OnReplayFinished(f func(context *guigui.Context)) {
+	ebitengineEventReplayFinished.SetHandler(e, f)
 }
 
 // OnTPSRequested sets a handler called once per guest, after its first tick, with the ticks-per-second
 // the guest's own game requests via [ebiten.SetTPS] ([ebiten.SyncWithFPS] resolved to the host's rate).
 func (e *Ebitengine) OnTPSRequested(f func(context *guigui.Context, tps int)) {
-	guigui.SetEventHandler(e, ebitengineEventTPSRequested, f)
+	ebitengineEventTPSRequested.SetHandler(e, f)
 }
 
 // Session returns the underlying guest session for advanced control, or nil when no guest is running.
@@ -287,16 +306,6 @@ func (e *Ebitengine) IsRunning() bool {
 }
 
 func (e *Ebitengine) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
-	// Report errors recorded on other goroutines. This runs even when the widget is closed, since the
-	// reaper goroutines can fail after Close.
-	e.pendingErrsMu.Lock()
-	errs := e.pendingErrs
-	e.pendingErrs = nil
-	e.pendingErrsMu.Unlock()
-	for _, err := range errs {
-		e.dispatchError(err)
-	}
-
 	if e.state == ebitengineStateClosed {
 		return nil
 	}
@@ -316,7 +325,7 @@ func (e *Ebitengine) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBo
 			e.audioRateWarned = false
 			// A remainder accumulated for the previous guest must not tick the new one.
 			e.tickAccum = 0
-			guigui.DispatchEvent(e, ebitengineEventLaunched)
+			ebitengineEventLaunched.Dispatch(e)
 		}
 	default:
 	}
@@ -375,7 +384,7 @@ func (e *Ebitengine) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBo
 		e.screenSet = true
 	}
 
-	if !e.inputForwardingDisabled {
+	if !e.inputForwardingDisabled && e.replay == nil {
 		e.forwardInput(context, widgetBounds)
 	}
 	// updateTextInput runs even while input forwarding is disabled: the guest's text-input sessions
@@ -383,12 +392,22 @@ func (e *Ebitengine) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBo
 	e.updateTextInput(context, widgetBounds)
 	n := e.guestTickCount() + e.manualTicks
 	e.manualTicks = 0
+	if e.replay != nil {
+		// The recorded input of a tick must be followed by exactly one guest tick to replay deterministically.
+		n = 0
+		if e.replay.Step(e.gp.session) {
+			n = 1
+			if e.replay.Done() {
+				ebitengineEventReplayFinished.Dispatch(e)
+			}
+		}
+	}
 	e.gp.session.AdvanceTicks(n)
 
 	// The session runs the guest on its own goroutine; a termination or error surfaces here.
 	if err := e.gp.session.Err(); err != nil {
 		if errors.Is(err, ebiten.Termination) {
-			guigui.DispatchEvent(e, ebitengineEventExited)
+			ebitengineEventExited.Dispatch(e)
 		} else {
 			e.dispatchError(err)
 		}
@@ -410,7 +429,7 @@ func (e *Ebitengine) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBo
 		}
 		e.requestedTPS = tps
 		e.tpsReported = true
-		guigui.DispatchEvent(e, ebitengineEventTPSRequested, tps)
+		ebitengineEventTPSRequested.Dispatch(e, tps)
 	}
 
 	if err := e.updateAudio(); err != nil {
@@ -464,7 +483,7 @@ func (e *Ebitengine) HandlePointingInput(context *guigui.Context, widgetBounds *
 	if !widgetBounds.IsHitAtCursor() {
 		return guigui.HandleInputResult{}
 	}
-	if slices.ContainsFunc([]ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle}, inpututil.IsMouseButtonJustPressed) {
+	if slices.ContainsFunc([]ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle}, inputsource.IsMouseButtonJustPressed) {
 		context.SetFocused(e, true)
 		return guigui.HandleInputByWidget(e)
 	}
@@ -9,9 +9,9 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/exp/vmhost"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 // forwardInput sends the window's input to the guest. Positions are translated into the widget's area
@@ -35,7 +35,7 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 	// Key presses and typed characters are forwarded only while the game area is focused, so typing into
 	// surrounding Guigui widgets does not reach the guest.
 	if context.IsFocused(e) {
-		e.keyBuf = inpututil.AppendJustPressedKeys(e.keyBuf[:0])
+		e.keyBuf = inputsource.AppendJustPressedKeys(e.keyBuf[:0])
 		for _, k := range e.keyBuf {
 			s.PressKey(k)
 			if e.pressedKeys == nil {
@@ -43,7 +43,7 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 			}
 			e.pressedKeys[k] = struct{}{}
 		}
-		e.runeBuf = ebiten.AppendInputChars(e.runeBuf[:0])
+		e.runeBuf = inputsource.AppendInputChars(e.runeBuf[:0])
 		for _, r := range e.runeBuf {
 			s.TypeRune(r)
 		}
@@ -51,7 +51,7 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 
 	// Key releases are forwarded regardless of focus, like the mouse button releases below: dropping a
 	// release would leave the guest with a stuck key.
-	e.keyBuf = inpututil.AppendJustReleasedKeys(e.keyBuf[:0])
+	e.keyBuf = inputsource.AppendJustReleasedKeys(e.keyBuf[:0])
 	for _, k := range e.keyBuf {
 		if _, ok := e.pressedKeys[k]; !ok {
 			continue
@@ -65,11 +65,11 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 	hit := widgetBounds.IsHitAtCursor()
 	dragging := len(e.pressedMouseButtons) > 0
 	if hit || dragging {
-		cx, cy := ebiten.CursorPosition()
+		cx, cy := inputsource.CursorPosition()
 		s.MoveCursor(localX(cx), localY(cy))
 	}
 	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
-		if !hit || !inpututil.IsMouseButtonJustPressed(b) {
+		if !hit || !inputsource.IsMouseButtonJustPressed(b) {
 			continue
 		}
 		s.PressMouseButton(b)
@@ -82,7 +82,7 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 	// Mouse button releases are forwarded regardless of the cursor's location, so a drag that ends
 	// outside the game does not leave the guest with a stuck button.
 	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
-		if !inpututil.IsMouseButtonJustReleased(b) {
+		if !inputsource.IsMouseButtonJustReleased(b) {
 			continue
 		}
 		if _, ok := e.pressedMouseButtons[b]; !ok {
@@ -92,7 +92,7 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 		delete(e.pressedMouseButtons, b)
 	}
 	if hit {
-		if wx, wy := ebiten.Wheel(); wx != 0 || wy != 0 {
+		if wx, wy := inputsource.Wheel(); wx != 0 || wy != 0 {
 			s.ScrollWheel(wx, wy)
 		}
 	}
@@ -102,28 +102,28 @@ func (e *Ebitengine) forwardInput(context *guigui.Context, widgetBounds *guigui.
 	if e.forwardedTouches == nil {
 		e.forwardedTouches = map[ebiten.TouchID]struct{}{}
 	}
-	e.touchIDsBuf = inpututil.AppendJustPressedTouchIDs(e.touchIDsBuf[:0])
+	e.touchIDsBuf = inputsource.AppendJustPressedTouchIDs(e.touchIDsBuf[:0])
 	for _, id := range e.touchIDsBuf {
-		x, y := ebiten.TouchPosition(id)
+		x, y := inputsource.TouchPosition(id)
 		if !widgetBounds.IsHitAt(image.Pt(x, y)) {
 			continue
 		}
 		s.PressTouch(id, localX(x), localY(y))
 		e.forwardedTouches[id] = struct{}{}
 	}
-	e.touchIDsBuf = ebiten.AppendTouchIDs(e.touchIDsBuf[:0])
+	e.touchIDsBuf = inputsource.AppendTouchIDs(e.touchIDsBuf[:0])
 	for _, id := range e.touchIDsBuf {
 		if _, ok := e.forwardedTouches[id]; !ok {
 			continue
 		}
 		// A just-pressed touch was already positioned by PressTouch above; only a continuing touch moves.
-		if inpututil.TouchPressDuration(id) == 1 {
+		if inputsource.TouchPressDuration(id) == 1 {
 			continue
 		}
-		x, y := ebiten.TouchPosition(id)
+		x, y := inputsource.TouchPosition(id)
 		s.MoveTouch(id, localX(x), localY(y))
 	}
-	e.touchIDsBuf = inpututil.AppendJustReleasedTouchIDs(e.touchIDsBuf[:0])
+	e.touchIDsBuf = inputsource.AppendJustReleasedTouchIDs(e.touchIDsBuf[:0])
 	for _, id := range e.touchIDsBuf {
 		if _, ok := e.forwardedTouches[id]; !ok {
 			continue
@@ -212,7 +212,7 @@ func (e *Ebitengine) updateTextInput(context *guigui.Context, widgetBounds *guig
 	}
 	// The host IME is driven only while the widget is focused, like key forwarding, so text inputting
 	// into surrounding Guigui widgets does not reach the guest.
-	if e.inputForwardingDisabled || !context.IsFocused(e) {
+	if e.inputForwardingDisabled || e.replay != nil || !context.IsFocused(e) {
 		e.composerForwarder.Reset()
 		return
 	}
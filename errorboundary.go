@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"runtime/debug"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// WidgetError is an error caught by an [ErrorBoundary].
+type WidgetError struct {
+	// Widget is the widget whose method failed.
+	Widget Widget
+
+	// Method is the name of the failed method, such as "Build".
+	Method string
+
+	// Err is the error returned by the method, or a [*PanicError] if the method panicked.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *WidgetError) Error() string {
+	return fmt.Sprintf("guigui: %T.%s failed: %v", e.Widget, e.Method, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *WidgetError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is an error converted from a panic recovered by an [ErrorBoundary].
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace at the panic.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// errorBoundary is the interface implemented by [ErrorBoundary].
+type errorBoundary interface {
+	Widget
+	errorBoundaryState() *errorBoundaryState
+}
+
+type errorBoundaryState struct {
+	err           *WidgetError
+	recoverPanics bool
+}
+
+var errorBoundaryEventError = GenerateEventKey1[error]()
+
+// ErrorBoundary is a widget that catches errors in its subtree.
+//
+// When [Widget.Build] or [Widget.Tick] of a widget in the subtree returns an error,
+// the error is caught by the nearest ErrorBoundary ancestor instead of terminating the application.
+// If panics are recovered by [ErrorBoundary.SetRecoverPanics], panics in [Widget.Build], [Widget.Layout],
+// [Widget.Tick] and [Widget.Draw] are caught as well.
+//
+// After an error is caught, the ErrorBoundary shows the fallback widget instead of the content widget,
+// until [ErrorBoundary.Reset] is called.
+// The caught error is reported by the handler set by [ErrorBoundary.OnError].
+type ErrorBoundary[T Widget] struct {
+	DefaultWidget
+
+	widget   lazyWidget[T]
+	fallback Widget
+	state    errorBoundaryState
+}
+
+// Widget returns the content widget.
+func (e *ErrorBoundary[T]) Widget() T {
+	return e.widget.Widget()
+}
+
+// SetFallback sets the widget shown instead of the content widget after an error is caught.
+// If the fallback widget is nil, nothing is shown.
+//
+// To show the error, the fallback widget can read it by [ErrorBoundary.Err] in its [Widget.Build].
+func (e *ErrorBoundary[T]) SetFallback(widget Widget) {
+	e.fallback = widget
+}
+
+// SetRecoverPanics sets whether panics in the subtree are recovered and caught as errors.
+// The default is false, and panics are propagated.
+func (e *ErrorBoundary[T]) SetRecoverPanics(recoverPanics bool) {
+	e.state.recoverPanics = recoverPanics
+}
+
+// Err returns the caught error, or nil if no error has been caught.
+// The returned error is a [*WidgetError] if not nil.
+func (e *ErrorBoundary[T]) Err() error {
+	if e.state.err == nil {
+		return nil
+	}
+	return e.state.err
+}
+
+// Reset clears the caught error, and shows the content widget again.
+func (e *ErrorBoundary[T]) Reset() {
+	e.state.err = nil
+}
+
+// OnError sets the handler called when an error is caught.
+// The handler is called at the start of the next tick, and err is a [*WidgetError].
+func (e *ErrorBoundary[T]) OnError(f func(context *Context, err error)) {
+	errorBoundaryEventError.SetHandler(e, f)
+}
+
+// Build implements [Widget.Build].
+func (e *ErrorBoundary[T]) Build(context *Context, adder *ChildAdder) error {
+	if e.state.err != nil {
+		if e.fallback != nil {
+			adder.AddWidget(e.fallback)
+		}
+		return nil
+	}
+	adder.AddWidget(e.widget.Widget())
+	context.DelegateFocus(e, e.widget.Widget())
+	return nil
+}
+
+// Layout implements [Widget.Layout].
+func (e *ErrorBoundary[T]) Layout(context *Context, widgetBounds *WidgetBounds, layouter *ChildLayouter) {
+	if w := e.activeWidget(); w != nil {
+		layouter.LayoutWidget(w, widgetBounds.Bounds())
+	}
+}
+
+// Measure implements [Widget.Measure].
+func (e *ErrorBoundary[T]) Measure(context *Context, constraints Constraints) image.Point {
+	if w := e.activeWidget(); w != nil {
+		return measureWidget(context, w, constraints)
+	}
+	return image.Point{}
+}
+
+// WriteStateKey implements [Widget.WriteStateKey].
+func (e *ErrorBoundary[T]) WriteStateKey(context *Context, w *StateKeyWriter) {
+	w.WriteBool(e.state.err != nil)
+	w.WriteWidget(e.fallback)
+}
+
+func (e *ErrorBoundary[T]) activeWidget() Widget {
+	if e.state.err != nil {
+		return e.fallback
+	}
+	return e.widget.Widget()
+}
+
+func (e *ErrorBoundary[T]) errorBoundaryState() *errorBoundaryState {
+	return &e.state
+}
+
+// callRecoveringPanic calls f. If recoverPanics is true, a panic in f is returned as a [*PanicError].
+func callRecoveringPanic(recoverPanics bool, f func() error) (err error) {
+	if recoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+	}
+	return f()
+}
+
+// catchWidgetError records err from the method of widget to the boundary, and requests to show the fallback widget.
+// If the boundary has already caught an error, err is ignored.
+func (a *app) catchWidgetError(boundary errorBoundary, widget Widget, method string, err error) {
+	s := boundary.errorBoundaryState()
+	if s.err != nil {
+		return
+	}
+	s.err = &WidgetError{
+		Widget: widget,
+		Method: method,
+		Err:    err,
+	}
+	a.requestRebuild()
+	a.requestRedraw(boundary.widgetState(), requestRedrawReasonStateKeyChangedForBuild)
+
+	werr := s.err
+	Post(func(context *Context) {
+		errorBoundaryEventError.Dispatch(boundary, werr)
+	})
+}
+
+// buildWidget builds the widget and its descendants.
+// boundary is the nearest error boundary enclosing the widget, or nil.
+func (a *app) buildWidget(widget Widget, adder *ChildAdder, boundary errorBoundary) error {
+	widget.widgetState().errorBoundary = boundary
+
+	b, ok := widget.(errorBoundary)
+	if !ok {
+		return a.doBuildWidget(widget, adder, boundary)
+	}
+
+	n := len(a.widgetList)
+	err := callRecoveringPanic(b.errorBoundaryState().recoverPanics, func() error {
+		return a.doBuildWidget(widget, adder, b)
+	})
+	if err == nil {
+		return nil
+	}
+
+	// Discard the partially built subtree. The widgets added in the subtree are no longer in the tree,
+	// unless they are added again by the fallback.
+	failed := widget
+	if a.buildingWidget != nil {
+		// buildingWidget is the widget whose Build failed.
+		failed = a.buildingWidget
+		for _, c := range failed.widgetState().children {
+			c.widgetState().builtAt = 0
+		}
+		a.buildingWidget = nil
+	}
+	for _, w := range a.widgetList[n:] {
+		ws := w.widgetState()
+		ws.builtAt = 0
+		for _, c := range ws.children {
+			c.widgetState().builtAt = 0
+		}
+	}
+	a.widgetList = a.widgetList[:n]
+	widget.widgetState().builtAt = a.buildCount
+
+	if errors.Is(err, ebiten.Termination) {
+		return err
+	}
+	a.catchWidgetError(b, failed, "Build", err)
+
+	// Build the boundary again to show the fallback.
+	// An error in the fallback is caught by the outer boundary.
+	return a.doBuildWidget(widget, adder, boundary)
+}
+
+func (a *app) doBuildWidget(widget Widget, adder *ChildAdder, boundary errorBoundary) error {
+	widgetState := widget.widgetState()
+	widgetState.children = slices.Delete(widgetState.children, 0, len(widgetState.children))
+	clear(widgetState.observedValues)
+	widgetState.observedValues = widgetState.observedValues[:0]
+	adder.app = a
+	adder.widget = widget
+	a.buildingWidget = widget
+	start := a.profiler.now()
+	if err := widget.Build(&a.context, adder); err != nil {
+		// Keep buildingWidget to let the boundary know the failed widget.
+		return err
+	}
+	a.profiler.widgetSpan("Build", widget, start)
+	a.buildingWidget = nil
+	a.widgetList = append(a.widgetList, widget)
+
+	for _, child := range widgetState.children {
+		if err := a.buildWidget(child, adder, boundary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
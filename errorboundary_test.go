@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+var errTestBuild = errors.New("test build error")
+
+type failingWidget struct {
+	guigui.DefaultWidget
+
+	err        error
+	panicValue any
+	buildCount int
+}
+
+func (f *failingWidget) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	f.buildCount++
+	if f.panicValue != nil {
+		panic(f.panicValue)
+	}
+	return f.err
+}
+
+type countingWidget struct {
+	guigui.DefaultWidget
+
+	buildCount int
+}
+
+func (c *countingWidget) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	c.buildCount++
+	return nil
+}
+
+type errorBoundaryRoot struct {
+	guigui.DefaultWidget
+
+	boundary      guigui.ErrorBoundary[*failingWidget]
+	fallback      countingWidget
+	recoverPanics bool
+
+	errs []error
+}
+
+func (e *errorBoundaryRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&e.boundary)
+	e.boundary.SetFallback(&e.fallback)
+	e.boundary.SetRecoverPanics(e.recoverPanics)
+	e.boundary.OnError(func(context *guigui.Context, err error) {
+		e.errs = append(e.errs, err)
+	})
+	return nil
+}
+
+func (e *errorBoundaryRoot) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	layouter.LayoutWidget(&e.boundary, widgetBounds.Bounds())
+}
+
+// updateRecoveringPanic calls app.Update, and returns the recovered panic value if any.
+func updateRecoveringPanic(app *guigui.AppForTesting) (panicValue any, err error) {
+	defer func() {
+		panicValue = recover()
+	}()
+	return nil, app.Update()
+}
+
+func TestErrorBoundaryBuildError(t *testing.T) {
+	var root errorBoundaryRoot
+	root.boundary.Widget().err = errTestBuild
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if app.IsInTree(root.boundary.Widget()) {
+		t.Errorf("the failing widget is in the tree")
+	}
+	if !app.IsInTree(&root.fallback) {
+		t.Errorf("the fallback widget is not in the tree")
+	}
+
+	var werr *guigui.WidgetError
+	if !errors.As(root.boundary.Err(), &werr) {
+		t.Fatalf("Err: got: %v, want: a *WidgetError", root.boundary.Err())
+	}
+	if werr.Widget != root.boundary.Widget() {
+		t.Errorf("WidgetError.Widget: got: %T, want: the failing widget", werr.Widget)
+	}
+	if got, want := werr.Method, "Build"; got != want {
+		t.Errorf("WidgetError.Method: got: %q, want: %q", got, want)
+	}
+	if !errors.Is(werr, errTestBuild) {
+		t.Errorf("errors.Is(WidgetError, errTestBuild): got: false, want: true")
+	}
+
+	// The handler is called at the start of the next tick.
+	if got := len(root.errs); got != 0 {
+		t.Errorf("the number of OnError calls before the next tick: got: %d, want: 0", got)
+	}
+	for range 3 {
+		if err := app.Update(); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+	if got := len(root.errs); got != 1 {
+		t.Fatalf("the number of OnError calls: got: %d, want: 1", got)
+	}
+	if !errors.Is(root.errs[0], errTestBuild) {
+		t.Errorf("the error passed to OnError: got: %v, want: %v", root.errs[0], errTestBuild)
+	}
+	if got := root.boundary.Widget().buildCount; got != 1 {
+		t.Errorf("the failing widget's build count: got: %d, want: 1", got)
+	}
+}
+
+func TestErrorBoundaryPanicNotRecovered(t *testing.T) {
+	var root errorBoundaryRoot
+	root.boundary.Widget().panicValue = "test panic"
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+
+	panicValue, _ := updateRecoveringPanic(app)
+	if panicValue != "test panic" {
+		t.Errorf("panic value: got: %v, want: %q", panicValue, "test panic")
+	}
+}
+
+func TestErrorBoundaryPanicRecovered(t *testing.T) {
+	var root errorBoundaryRoot
+	root.recoverPanics = true
+	root.boundary.Widget().panicValue = "test panic"
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+
+	panicValue, err := updateRecoveringPanic(app)
+	if panicValue != nil {
+		t.Fatalf("Update panicked: %v", panicValue)
+	}
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	var perr *guigui.PanicError
+	if !errors.As(root.boundary.Err(), &perr) {
+		t.Fatalf("Err: got: %v, want: a *PanicError", root.boundary.Err())
+	}
+	if perr.Value != "test panic" {
+		t.Errorf("PanicError.Value: got: %v, want: %q", perr.Value, "test panic")
+	}
+	if len(perr.Stack) == 0 {
+		t.Errorf("PanicError.Stack is empty")
+	}
+	if !app.IsInTree(&root.fallback) {
+		t.Errorf("the fallback widget is not in the tree")
+	}
+}
+
+func TestErrorBoundaryReset(t *testing.T) {
+	var root errorBoundaryRoot
+	root.boundary.Widget().err = errTestBuild
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if root.boundary.Err() == nil {
+		t.Fatalf("Err: got: nil, want: an error")
+	}
+
+	guigui.Post(func(context *guigui.Context) {
+		root.boundary.Widget().err = nil
+		root.boundary.Reset()
+	})
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := root.boundary.Err(); err != nil {
+		t.Errorf("Err after Reset: got: %v, want: nil", err)
+	}
+	if !app.IsInTree(root.boundary.Widget()) {
+		t.Errorf("the content widget is not in the tree after Reset")
+	}
+	if app.IsInTree(&root.fallback) {
+		t.Errorf("the fallback widget is in the tree after Reset")
+	}
+	if got := root.boundary.Widget().buildCount; got != 2 {
+		t.Errorf("the content widget's build count: got: %d, want: 2", got)
+	}
+}
+
+type failingRoot struct {
+	guigui.DefaultWidget
+
+	child failingWidget
+}
+
+func (f *failingRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&f.child)
+	return nil
+}
+
+func TestBuildErrorWithoutErrorBoundary(t *testing.T) {
+	var root failingRoot
+	root.child.err = errTestBuild
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+
+	if err := app.Update(); !errors.Is(err, errTestBuild) {
+		t.Errorf("Update: got: %v, want: %v", err, errTestBuild)
+	}
+}
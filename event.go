@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"fmt"
+	"slices"
+)
+
+//go:generate go run gen.go
+
+// EventPhase is a phase of an event propagation.
+type EventPhase int
+
+const (
+	// EventPhaseTarget is the phase where the handler on the target widget is invoked.
+	EventPhaseTarget EventPhase = iota
+
+	// EventPhaseCapturing is the phase where the capturing handlers on the target's ancestors are invoked,
+	// from the root to the target's parent.
+	EventPhaseCapturing
+
+	// EventPhaseBubbling is the phase where the bubbling handlers on the target's ancestors are invoked,
+	// from the target's parent to the root.
+	EventPhaseBubbling
+)
+
+// Event represents an event propagating in the widget tree.
+//
+// An event dispatched with a type-safe event key such as [EventKey1] propagates in three phases.
+// First, capturing handlers registered on the target's ancestors are invoked from the root downwards.
+// Second, the handler registered on the target widget is invoked.
+// Finally, bubbling handlers registered on the target's ancestors are invoked from the target's parent upwards.
+// A capturing or bubbling handler can stop the propagation by [Event.StopPropagation].
+//
+// Events dispatched by [DispatchEvent] and events with a result, such as [EventKey1R], do not propagate.
+//
+// Like other event handlers, capturing and bubbling handlers are reset before the build phase starts.
+type Event struct {
+	target        Widget
+	currentTarget Widget
+	phase         EventPhase
+	stopped       bool
+}
+
+// Target returns the widget on which the event was dispatched.
+//
+// Note that the target might be an internal widget of a composite widget.
+func (e *Event) Target() Widget {
+	return e.target
+}
+
+// CurrentTarget returns the widget whose handler is being invoked.
+func (e *Event) CurrentTarget() Widget {
+	return e.currentTarget
+}
+
+// Phase returns the current phase of the event propagation.
+func (e *Event) Phase() EventPhase {
+	return e.phase
+}
+
+// StopPropagation stops the event from propagating further.
+// If StopPropagation is called in the capturing phase, the handler on the target widget is not invoked.
+func (e *Event) StopPropagation() {
+	e.stopped = true
+}
+
+// typedEventKey is the common part of the type-safe event keys such as [EventKey1].
+//
+// A type-safe event key shares the registration with [SetEventHandler] and [DispatchEvent],
+// but its handler is checked at compile time and invoked without reflection.
+type typedEventKey struct {
+	key EventKey
+}
+
+// EventKey returns the underlying untyped [EventKey].
+func (k typedEventKey) EventKey() EventKey {
+	return k.key
+}
+
+// HasHandler reports whether dispatching the event on the widget would invoke any handler,
+// including capturing and bubbling handlers on the widget's ancestors.
+//
+// HasHandler is useful to skip expensive work to construct the event arguments when no handler is registered.
+func (k typedEventKey) HasHandler(widget Widget) bool {
+	widgetState := widget.widgetState()
+	if _, ok := findEventHandler(widgetState, k.key, EventPhaseTarget); ok {
+		return true
+	}
+	if currentApp().propagatingEventHandlerCount == 0 {
+		return false
+	}
+	for w := widgetState.parent; w != nil; w = w.widgetState().parent {
+		for _, h := range w.widgetState().eventHandlers {
+			if h.key == k.key && h.phase != EventPhaseTarget {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func setEventHandler(widget Widget, eventKey EventKey, phase EventPhase, handler any) {
+	removeEventHandler(widget, eventKey, phase)
+	widgetState := widget.widgetState()
+	widgetState.eventHandlers = append(widgetState.eventHandlers, eventHandler{
+		key:     eventKey,
+		phase:   phase,
+		handler: handler,
+	})
+	if phase != EventPhaseTarget {
+		currentApp().propagatingEventHandlerCount++
+	}
+}
+
+func removeEventHandler(widget Widget, eventKey EventKey, phase EventPhase) {
+	widgetState := widget.widgetState()
+	n := len(widgetState.eventHandlers)
+	widgetState.eventHandlers = slices.DeleteFunc(widgetState.eventHandlers, func(h eventHandler) bool {
+		return h.key == eventKey && h.phase == phase
+	})
+	if phase != EventPhaseTarget {
+		currentApp().propagatingEventHandlerCount -= n - len(widgetState.eventHandlers)
+	}
+}
+
+func findEventHandler(widgetState *widgetState, eventKey EventKey, phase EventPhase) (any, bool) {
+	for _, h := range widgetState.eventHandlers {
+		if h.key == eventKey && h.phase == phase {
+			return h.handler, true
+		}
+	}
+	return nil, false
+}
+
+func eventHandlerOf[F any](handler any) F {
+	f, ok := handler.(F)
+	if !ok {
+		panic(fmt.Sprintf("guigui: event handler type mismatch: got %T, want %T", handler, f))
+	}
+	return f
+}
+
+// eventArgs is the arguments of an event dispatched with a type-safe event key such as [EventKey1].
+//
+// The arguments are passed as a value of a type parameter rather than in closures,
+// so that dispatching an event does not allocate.
+type eventArgs interface {
+	// invokeTarget invokes the handler on the target widget.
+	invokeTarget(context *Context, handler any)
+
+	// invokeAncestor invokes the capturing or bubbling handler on an ancestor.
+	invokeAncestor(context *Context, handler any, event *Event)
+}
+
+// resultEventArgs is the arguments of an event with a result dispatched with a type-safe event key such as [EventKey1R].
+type resultEventArgs[R any] interface {
+	// invoke invokes the handler on the target widget, and returns its result.
+	invoke(context *Context, handler any) R
+}
+
+// setHandler registers handler for the event in the phase on the widget.
+// If isNil is true, the registered handler is removed instead.
+func (k typedEventKey) setHandler(widget Widget, phase EventPhase, handler any, isNil bool) {
+	if isNil {
+		removeEventHandler(widget, k.key, phase)
+		return
+	}
+	setEventHandler(widget, k.key, phase, handler)
+}
+
+// dispatchEvent dispatches an event on the widget through the capturing, target, and bubbling phases.
+// dispatchEvent reports whether a handler on the target widget was found.
+func dispatchEvent[E eventArgs](widget Widget, eventKey EventKey, args E) bool {
+	widgetState := widget.widgetState()
+	context := &currentApp().context
+
+	// Fast path: no capturing or bubbling handler exists in the tree.
+	if currentApp().propagatingEventHandlerCount == 0 {
+		h, ok := findEventHandler(widgetState, eventKey, EventPhaseTarget)
+		if !ok {
+			return false
+		}
+		args.invokeTarget(context, h)
+		markEventDispatched(widgetState)
+		return true
+	}
+
+	event := Event{
+		target: widget,
+		phase:  EventPhaseCapturing,
+	}
+	if capturePropagatingEvent(widgetState.parent, eventKey, &event, args) {
+		return false
+	}
+
+	event.phase = EventPhaseTarget
+	event.currentTarget = widget
+	h, found := findEventHandler(widgetState, eventKey, EventPhaseTarget)
+	if found {
+		args.invokeTarget(context, h)
+		markEventDispatched(widgetState)
+	}
+
+	event.phase = EventPhaseBubbling
+	for w := widgetState.parent; w != nil; w = w.widgetState().parent {
+		if invokePropagatingEventHandler(w, eventKey, &event, args) {
+			break
+		}
+	}
+	return found
+}
+
+// capturePropagatingEvent invokes the capturing handlers from the root to widget,
+// and reports whether the propagation was stopped.
+func capturePropagatingEvent[E eventArgs](widget Widget, eventKey EventKey, event *Event, args E) bool {
+	if widget == nil {
+		return false
+	}
+	if capturePropagatingEvent(widget.widgetState().parent, eventKey, event, args) {
+		return true
+	}
+	return invokePropagatingEventHandler(widget, eventKey, event, args)
+}
+
+// invokePropagatingEventHandler invokes the handler for event's phase on the widget if exists,
+// and reports whether the propagation was stopped.
+func invokePropagatingEventHandler[E eventArgs](widget Widget, eventKey EventKey, event *Event, args E) bool {
+	widgetState := widget.widgetState()
+	h, ok := findEventHandler(widgetState, eventKey, event.phase)
+	if !ok {
+		return false
+	}
+	event.currentTarget = widget
+	args.invokeAncestor(&currentApp().context, h, event)
+	markEventDispatched(widgetState)
+	return event.stopped
+}
+
+// dispatchResultEvent invokes the handler registered for the event on the widget.
+// It returns the handler's result and true if a handler was found, or the zero value and false otherwise.
+// An event with a result does not propagate.
+func dispatchResultEvent[R any, E resultEventArgs[R]](widget Widget, eventKey EventKey, args E) (R, bool) {
+	widgetState := widget.widgetState()
+	h, ok := findEventHandler(widgetState, eventKey, EventPhaseTarget)
+	if !ok {
+		var zero R
+		return zero, false
+	}
+	ret := args.invoke(&currentApp().context, h)
+	markEventDispatched(widgetState)
+	return ret, true
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+func TestEventKeyDispatch(t *testing.T) {
+	key := guigui.GenerateEventKey2[int, string]()
+
+	var w dummyWidget
+	if key.HasHandler(&w) {
+		t.Errorf("HasHandler: got: true, want: false")
+	}
+	if key.Dispatch(&w, 1, "a") {
+		t.Errorf("Dispatch without a handler: got: true, want: false")
+	}
+
+	var gotInt int
+	var gotString string
+	key.SetHandler(&w, func(context *guigui.Context, i int, s string) {
+		gotInt = i
+		gotString = s
+	})
+	if !key.HasHandler(&w) {
+		t.Errorf("HasHandler: got: false, want: true")
+	}
+	if !key.Dispatch(&w, 1, "a") {
+		t.Errorf("Dispatch: got: false, want: true")
+	}
+	if gotInt != 1 || gotString != "a" {
+		t.Errorf("got: (%d, %q), want: (1, %q)", gotInt, gotString, "a")
+	}
+
+	key.SetHandler(&w, nil)
+	if key.HasHandler(&w) {
+		t.Errorf("HasHandler after removal: got: true, want: false")
+	}
+}
+
+func TestEventKeyDispatchResult(t *testing.T) {
+	key := guigui.GenerateEventKey1R[int, bool]()
+
+	var w dummyWidget
+	if got, ok := key.Dispatch(&w, 1); got || ok {
+		t.Errorf("Dispatch without a handler: got: (%t, %t), want: (false, false)", got, ok)
+	}
+
+	key.SetHandler(&w, func(context *guigui.Context, i int) bool {
+		return i > 0
+	})
+	if got, ok := key.Dispatch(&w, 1); !got || !ok {
+		t.Errorf("Dispatch: got: (%t, %t), want: (true, true)", got, ok)
+	}
+	if got, ok := key.Dispatch(&w, -1); got || !ok {
+		t.Errorf("Dispatch: got: (%t, %t), want: (false, true)", got, ok)
+	}
+}
+
+func TestEventKeyDispatchAllocs(t *testing.T) {
+	key := guigui.GenerateEventKey2[int, string]()
+	resultKey := guigui.GenerateEventKey1R[int, bool]()
+
+	var w dummyWidget
+	var sum int
+	key.SetHandler(&w, func(context *guigui.Context, i int, s string) {
+		sum += i + len(s)
+	})
+	resultKey.SetHandler(&w, func(context *guigui.Context, i int) bool {
+		return i > 0
+	})
+
+	if got := testing.AllocsPerRun(100, func() {
+		key.Dispatch(&w, 1, "a")
+	}); got != 0 {
+		t.Errorf("allocations in Dispatch: got: %g, want: 0", got)
+	}
+	if got := testing.AllocsPerRun(100, func() {
+		resultKey.Dispatch(&w, 1)
+	}); got != 0 {
+		t.Errorf("allocations in Dispatch with a result: got: %g, want: 0", got)
+	}
+}
+
+func TestEventKeyUntypedInterop(t *testing.T) {
+	key := guigui.GenerateEventKey1[int]()
+
+	var w dummyWidget
+	var got int
+	guigui.SetEventHandler(&w, key.EventKey(), func(context *guigui.Context, i int) {
+		got = i
+	})
+	key.Dispatch(&w, 1)
+	if got != 1 {
+		t.Errorf("typed dispatch: got: %d, want: 1", got)
+	}
+	guigui.DispatchEvent(&w, key.EventKey(), 2)
+	if got != 2 {
+		t.Errorf("untyped dispatch: got: %d, want: 2", got)
+	}
+
+	guigui.SetEventHandler(&w, key.EventKey(), func(context *guigui.Context, s string) {})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Dispatch with a mismatched handler must panic")
+		}
+	}()
+	key.Dispatch(&w, 3)
+}
+
+func TestEventPropagation(t *testing.T) {
+	key := guigui.GenerateEventKey1[int]()
+
+	var root, parent, target dummyWidget
+	guigui.SetParentForTesting(&parent, &root)
+	guigui.SetParentForTesting(&target, &parent)
+
+	var log []string
+	key.SetCapturingHandler(&root, func(context *guigui.Context, event *guigui.Event, i int) {
+		if event.Target() != &target {
+			t.Errorf("Target: got: %p, want: %p", event.Target(), &target)
+		}
+		log = append(log, "root capturing")
+	})
+	key.SetCapturingHandler(&parent, func(context *guigui.Context, event *guigui.Event, i int) {
+		log = append(log, "parent capturing")
+	})
+	key.SetHandler(&target, func(context *guigui.Context, i int) {
+		log = append(log, "target")
+	})
+	key.SetBubblingHandler(&parent, func(context *guigui.Context, event *guigui.Event, i int) {
+		if event.Phase() != guigui.EventPhaseBubbling {
+			t.Errorf("Phase: got: %d, want: %d", event.Phase(), guigui.EventPhaseBubbling)
+		}
+		log = append(log, "parent bubbling")
+	})
+	key.SetBubblingHandler(&root, func(context *guigui.Context, event *guigui.Event, i int) {
+		log = append(log, "root bubbling")
+	})
+
+	if !key.Dispatch(&target, 1) {
+		t.Errorf("Dispatch: got: false, want: true")
+	}
+	want := []string{"root capturing", "parent capturing", "target", "parent bubbling", "root bubbling"}
+	if !slices.Equal(log, want) {
+		t.Errorf("got: %v, want: %v", log, want)
+	}
+
+	// Stop the propagation in the capturing phase.
+	log = log[:0]
+	key.SetCapturingHandler(&parent, func(context *guigui.Context, event *guigui.Event, i int) {
+		log = append(log, "parent capturing")
+		event.StopPropagation()
+	})
+	if key.Dispatch(&target, 1) {
+		t.Errorf("Dispatch: got: true, want: false")
+	}
+	want = []string{"root capturing", "parent capturing"}
+	if !slices.Equal(log, want) {
+		t.Errorf("got: %v, want: %v", log, want)
+	}
+
+	// Stop the propagation in the bubbling phase.
+	log = log[:0]
+	key.SetCapturingHandler(&parent, nil)
+	key.SetBubblingHandler(&parent, func(context *guigui.Context, event *guigui.Event, i int) {
+		log = append(log, "parent bubbling")
+		event.StopPropagation()
+	})
+	key.Dispatch(&target, 1)
+	want = []string{"root capturing", "target", "parent bubbling"}
+	if !slices.Equal(log, want) {
+		t.Errorf("got: %v, want: %v", log, want)
+	}
+
+	// A widget without its own handler still propagates the event to its ancestors.
+	var sibling dummyWidget
+	guigui.SetParentForTesting(&sibling, &root)
+	if !key.HasHandler(&sibling) {
+		t.Errorf("HasHandler: got: false, want: true")
+	}
+	log = log[:0]
+	if key.Dispatch(&sibling, 1) {
+		t.Errorf("Dispatch: got: true, want: false")
+	}
+	want = []string{"root capturing", "root bubbling"}
+	if !slices.Equal(log, want) {
+		t.Errorf("got: %v, want: %v", log, want)
+	}
+}
+
+func TestEventPropagatingHandlerCount(t *testing.T) {
+	key := guigui.GenerateEventKey1[int]()
+
+	var root, target dummyWidget
+	guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	guigui.SetParentForTesting(&target, &root)
+
+	count := func() int {
+		return guigui.PropagatingEventHandlerCountForTesting()
+	}
+	handler := func(context *guigui.Context, event *guigui.Event, i int) {}
+
+	// Removing a handler that is not registered counts nothing.
+	key.SetBubblingHandler(&root, nil)
+	key.SetCapturingHandler(&root, nil)
+	if got, want := count(), 0; got != want {
+		t.Errorf("count after removing no handler: got: %d, want: %d", got, want)
+	}
+
+	// A target handler is not counted.
+	key.SetHandler(&target, func(context *guigui.Context, i int) {})
+	if got, want := count(), 0; got != want {
+		t.Errorf("count after SetHandler: got: %d, want: %d", got, want)
+	}
+
+	key.SetBubblingHandler(&root, handler)
+	key.SetCapturingHandler(&root, handler)
+	if got, want := count(), 2; got != want {
+		t.Errorf("count after setting handlers: got: %d, want: %d", got, want)
+	}
+
+	// Replacing a handler does not count it twice.
+	key.SetBubblingHandler(&root, handler)
+	if got, want := count(), 2; got != want {
+		t.Errorf("count after replacing a handler: got: %d, want: %d", got, want)
+	}
+
+	key.SetBubblingHandler(&root, nil)
+	key.SetCapturingHandler(&root, nil)
+	if got, want := count(), 0; got != want {
+		t.Errorf("count after removing the handlers: got: %d, want: %d", got, want)
+	}
+}
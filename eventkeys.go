@@ -0,0 +1,352 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Code generated by gen.go. DO NOT EDIT.
+
+package guigui
+
+// EventKey0 is a type-safe key for an event with no arguments.
+// The handler type is func(context *Context).
+type EventKey0 struct {
+	typedEventKey
+}
+
+// GenerateEventKey0 generates a new EventKey0.
+func GenerateEventKey0() EventKey0 {
+	return EventKey0{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey0) SetHandler(widget Widget, handler func(context *Context)) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// SetCapturingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the capturing phase, before the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey0) SetCapturingHandler(widget Widget, handler func(context *Context, event *Event)) {
+	k.setHandler(widget, EventPhaseCapturing, handler, handler == nil)
+}
+
+// SetBubblingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the bubbling phase, after the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey0) SetBubblingHandler(widget Widget, handler func(context *Context, event *Event)) {
+	k.setHandler(widget, EventPhaseBubbling, handler, handler == nil)
+}
+
+// Dispatch dispatches the event on the widget.
+// It returns true if a handler registered on the widget was found, or false otherwise.
+//
+// Handlers registered on the ancestors by SetCapturingHandler and SetBubblingHandler are also invoked.
+func (k EventKey0) Dispatch(widget Widget) bool {
+	return dispatchEvent(widget, k.key, eventArgs0{})
+}
+
+// eventArgs0 is the arguments of an event dispatched with EventKey0.
+type eventArgs0 struct{}
+
+func (args eventArgs0) invokeTarget(context *Context, handler any) {
+	eventHandlerOf[func(*Context)](handler)(context)
+}
+
+func (args eventArgs0) invokeAncestor(context *Context, handler any, event *Event) {
+	eventHandlerOf[func(*Context, *Event)](handler)(context, event)
+}
+
+// EventKey1 is a type-safe key for an event with one argument.
+// The handler type is func(context *Context, a A).
+type EventKey1[A any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey1 generates a new EventKey1.
+func GenerateEventKey1[A any]() EventKey1[A] {
+	return EventKey1[A]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey1[A]) SetHandler(widget Widget, handler func(context *Context, a A)) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// SetCapturingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the capturing phase, before the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey1[A]) SetCapturingHandler(widget Widget, handler func(context *Context, event *Event, a A)) {
+	k.setHandler(widget, EventPhaseCapturing, handler, handler == nil)
+}
+
+// SetBubblingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the bubbling phase, after the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey1[A]) SetBubblingHandler(widget Widget, handler func(context *Context, event *Event, a A)) {
+	k.setHandler(widget, EventPhaseBubbling, handler, handler == nil)
+}
+
+// Dispatch dispatches the event on the widget.
+// It returns true if a handler registered on the widget was found, or false otherwise.
+//
+// Handlers registered on the ancestors by SetCapturingHandler and SetBubblingHandler are also invoked.
+func (k EventKey1[A]) Dispatch(widget Widget, a A) bool {
+	return dispatchEvent(widget, k.key, eventArgs1[A]{a: a})
+}
+
+// eventArgs1 is the arguments of an event dispatched with EventKey1.
+type eventArgs1[A any] struct {
+	a A
+}
+
+func (args eventArgs1[A]) invokeTarget(context *Context, handler any) {
+	eventHandlerOf[func(*Context, A)](handler)(context, args.a)
+}
+
+func (args eventArgs1[A]) invokeAncestor(context *Context, handler any, event *Event) {
+	eventHandlerOf[func(*Context, *Event, A)](handler)(context, event, args.a)
+}
+
+// EventKey2 is a type-safe key for an event with two arguments.
+// The handler type is func(context *Context, a A, b B).
+type EventKey2[A, B any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey2 generates a new EventKey2.
+func GenerateEventKey2[A, B any]() EventKey2[A, B] {
+	return EventKey2[A, B]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey2[A, B]) SetHandler(widget Widget, handler func(context *Context, a A, b B)) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// SetCapturingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the capturing phase, before the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey2[A, B]) SetCapturingHandler(widget Widget, handler func(context *Context, event *Event, a A, b B)) {
+	k.setHandler(widget, EventPhaseCapturing, handler, handler == nil)
+}
+
+// SetBubblingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the bubbling phase, after the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey2[A, B]) SetBubblingHandler(widget Widget, handler func(context *Context, event *Event, a A, b B)) {
+	k.setHandler(widget, EventPhaseBubbling, handler, handler == nil)
+}
+
+// Dispatch dispatches the event on the widget.
+// It returns true if a handler registered on the widget was found, or false otherwise.
+//
+// Handlers registered on the ancestors by SetCapturingHandler and SetBubblingHandler are also invoked.
+func (k EventKey2[A, B]) Dispatch(widget Widget, a A, b B) bool {
+	return dispatchEvent(widget, k.key, eventArgs2[A, B]{a: a, b: b})
+}
+
+// eventArgs2 is the arguments of an event dispatched with EventKey2.
+type eventArgs2[A, B any] struct {
+	a A
+	b B
+}
+
+func (args eventArgs2[A, B]) invokeTarget(context *Context, handler any) {
+	eventHandlerOf[func(*Context, A, B)](handler)(context, args.a, args.b)
+}
+
+func (args eventArgs2[A, B]) invokeAncestor(context *Context, handler any, event *Event) {
+	eventHandlerOf[func(*Context, *Event, A, B)](handler)(context, event, args.a, args.b)
+}
+
+// EventKey3 is a type-safe key for an event with three arguments.
+// The handler type is func(context *Context, a A, b B, c C).
+type EventKey3[A, B, C any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey3 generates a new EventKey3.
+func GenerateEventKey3[A, B, C any]() EventKey3[A, B, C] {
+	return EventKey3[A, B, C]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey3[A, B, C]) SetHandler(widget Widget, handler func(context *Context, a A, b B, c C)) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// SetCapturingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the capturing phase, before the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey3[A, B, C]) SetCapturingHandler(widget Widget, handler func(context *Context, event *Event, a A, b B, c C)) {
+	k.setHandler(widget, EventPhaseCapturing, handler, handler == nil)
+}
+
+// SetBubblingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the bubbling phase, after the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k EventKey3[A, B, C]) SetBubblingHandler(widget Widget, handler func(context *Context, event *Event, a A, b B, c C)) {
+	k.setHandler(widget, EventPhaseBubbling, handler, handler == nil)
+}
+
+// Dispatch dispatches the event on the widget.
+// It returns true if a handler registered on the widget was found, or false otherwise.
+//
+// Handlers registered on the ancestors by SetCapturingHandler and SetBubblingHandler are also invoked.
+func (k EventKey3[A, B, C]) Dispatch(widget Widget, a A, b B, c C) bool {
+	return dispatchEvent(widget, k.key, eventArgs3[A, B, C]{a: a, b: b, c: c})
+}
+
+// eventArgs3 is the arguments of an event dispatched with EventKey3.
+type eventArgs3[A, B, C any] struct {
+	a A
+	b B
+	c C
+}
+
+func (args eventArgs3[A, B, C]) invokeTarget(context *Context, handler any) {
+	eventHandlerOf[func(*Context, A, B, C)](handler)(context, args.a, args.b, args.c)
+}
+
+func (args eventArgs3[A, B, C]) invokeAncestor(context *Context, handler any, event *Event) {
+	eventHandlerOf[func(*Context, *Event, A, B, C)](handler)(context, event, args.a, args.b, args.c)
+}
+
+// EventKey0R is a type-safe key for an event with no arguments and a result.
+// The handler type is func(context *Context) R.
+type EventKey0R[R any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey0R generates a new EventKey0R.
+func GenerateEventKey0R[R any]() EventKey0R[R] {
+	return EventKey0R[R]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey0R[R]) SetHandler(widget Widget, handler func(context *Context) R) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// Dispatch invokes the handler registered for the event on the widget.
+// It returns the handler's result and true if a handler was found, or the zero value and false otherwise.
+//
+// An event with a result does not propagate to the ancestors.
+func (k EventKey0R[R]) Dispatch(widget Widget) (R, bool) {
+	return dispatchResultEvent[R](widget, k.key, resultEventArgs0[R]{})
+}
+
+// resultEventArgs0 is the arguments of an event dispatched with EventKey0R.
+type resultEventArgs0[R any] struct{}
+
+func (args resultEventArgs0[R]) invoke(context *Context, handler any) R {
+	return eventHandlerOf[func(*Context) R](handler)(context)
+}
+
+// EventKey1R is a type-safe key for an event with one argument and a result.
+// The handler type is func(context *Context, a A) R.
+type EventKey1R[A, R any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey1R generates a new EventKey1R.
+func GenerateEventKey1R[A, R any]() EventKey1R[A, R] {
+	return EventKey1R[A, R]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey1R[A, R]) SetHandler(widget Widget, handler func(context *Context, a A) R) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// Dispatch invokes the handler registered for the event on the widget.
+// It returns the handler's result and true if a handler was found, or the zero value and false otherwise.
+//
+// An event with a result does not propagate to the ancestors.
+func (k EventKey1R[A, R]) Dispatch(widget Widget, a A) (R, bool) {
+	return dispatchResultEvent[R](widget, k.key, resultEventArgs1[A, R]{a: a})
+}
+
+// resultEventArgs1 is the arguments of an event dispatched with EventKey1R.
+type resultEventArgs1[A, R any] struct {
+	a A
+}
+
+func (args resultEventArgs1[A, R]) invoke(context *Context, handler any) R {
+	return eventHandlerOf[func(*Context, A) R](handler)(context, args.a)
+}
+
+// EventKey2R is a type-safe key for an event with two arguments and a result.
+// The handler type is func(context *Context, a A, b B) R.
+type EventKey2R[A, B, R any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey2R generates a new EventKey2R.
+func GenerateEventKey2R[A, B, R any]() EventKey2R[A, B, R] {
+	return EventKey2R[A, B, R]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey2R[A, B, R]) SetHandler(widget Widget, handler func(context *Context, a A, b B) R) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// Dispatch invokes the handler registered for the event on the widget.
+// It returns the handler's result and true if a handler was found, or the zero value and false otherwise.
+//
+// An event with a result does not propagate to the ancestors.
+func (k EventKey2R[A, B, R]) Dispatch(widget Widget, a A, b B) (R, bool) {
+	return dispatchResultEvent[R](widget, k.key, resultEventArgs2[A, B, R]{a: a, b: b})
+}
+
+// resultEventArgs2 is the arguments of an event dispatched with EventKey2R.
+type resultEventArgs2[A, B, R any] struct {
+	a A
+	b B
+}
+
+func (args resultEventArgs2[A, B, R]) invoke(context *Context, handler any) R {
+	return eventHandlerOf[func(*Context, A, B) R](handler)(context, args.a, args.b)
+}
+
+// EventKey3R is a type-safe key for an event with three arguments and a result.
+// The handler type is func(context *Context, a A, b B, c C) R.
+type EventKey3R[A, B, C, R any] struct {
+	typedEventKey
+}
+
+// GenerateEventKey3R generates a new EventKey3R.
+func GenerateEventKey3R[A, B, C, R any]() EventKey3R[A, B, C, R] {
+	return EventKey3R[A, B, C, R]{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k EventKey3R[A, B, C, R]) SetHandler(widget Widget, handler func(context *Context, a A, b B, c C) R) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// Dispatch invokes the handler registered for the event on the widget.
+// It returns the handler's result and true if a handler was found, or the zero value and false otherwise.
+//
+// An event with a result does not propagate to the ancestors.
+func (k EventKey3R[A, B, C, R]) Dispatch(widget Widget, a A, b B, c C) (R, bool) {
+	return dispatchResultEvent[R](widget, k.key, resultEventArgs3[A, B, C, R]{a: a, b: b, c: c})
+}
+
+// resultEventArgs3 is the arguments of an event dispatched with EventKey3R.
+type resultEventArgs3[A, B, C, R any] struct {
+	a A
+	b B
+	c C
+}
+
+func (args resultEventArgs3[A, B, C, R]) invoke(context *Context, handler any) R {
+	return eventHandlerOf[func(*Context, A, B, C) R](handler)(context, args.a, args.b, args.c)
+}
@@ -48,7 +48,7 @@ func (w *itemWidget) Layout(context *guigui.Context, widgetBounds *guigui.Widget
 	var clr color.Color
 	if v, ok := context.Env(w, basicwidget.EnvKeyListItemColorType); ok {
 		if ct, ok := v.(basicwidget.ListItemColorType); ok {
-			clr = ct.TextColor(context)
+			clr = ct.TextColor(context, w)
 		}
 	}
 	var style basicwidget.TextStyle
@@ -124,11 +124,13 @@ func (r *Root) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 	case basicwidget.DrawerEdgeBottom:
 		drawerBounds.Min.Y = drawerBounds.Max.Y - 4*u
 	}
+	// The start edge is at the right in a right-to-left reading direction.
+	drawerBounds = context.MirrorBounds(r, drawerBounds, context.AppBounds())
 	layouter.LayoutWidget(&r.drawer, drawerBounds)
 }
 
 var (
-	drawerContentEventClose guigui.EventKey = guigui.GenerateEventKey()
+	drawerContentEventClose = guigui.GenerateEventKey0()
 )
 
 type drawerContent struct {
@@ -142,7 +144,7 @@ type drawerContent struct {
 }
 
 func (d *drawerContent) OnClose(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(d, drawerContentEventClose, f)
+	drawerContentEventClose.SetHandler(d, f)
 }
 
 func (d *drawerContent) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -150,7 +152,7 @@ func (d *drawerContent) Build(context *guigui.Context, adder *guigui.ChildAdder)
 
 	d.closeButton.SetText("Close")
 	d.closeButton.OnDown(func(context *guigui.Context) {
-		guigui.DispatchEvent(d, drawerContentEventClose)
+		drawerContentEventClose.Dispatch(d)
 	})
 
 	return nil
@@ -56,6 +56,11 @@ func (r *Root) Env(context *guigui.Context, key guigui.EnvKey, source *guigui.En
 	}
 }
 
+func (r *Root) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	// The density applies to the whole screen.
+	w.WriteInt(int(basicwidget.AppDensity()))
+}
+
 func (r *Root) contentWidget() guigui.Widget {
 	switch r.model.Mode() {
 	case "settings":
@@ -203,7 +203,7 @@ func (s *selectItem) Layout(context *guigui.Context, widgetBounds *guigui.Widget
 	var clr color.Color
 	if v, ok := context.Env(s, basicwidget.EnvKeyListItemColorType); ok {
 		if ct, ok := v.(basicwidget.ListItemColorType); ok {
-			clr = ct.TextColor(context)
+			clr = ct.TextColor(context, s)
 		}
 	}
 	var style basicwidget.TextStyle
@@ -25,6 +25,8 @@ type Settings struct {
 	localeSelect              basicwidget.Select[language.Tag]
 	scaleText                 basicwidget.Text
 	scaleSegmentedControl     basicwidget.SegmentedControl[float64]
+	densityText               basicwidget.Text
+	densitySegmentedControl   basicwidget.SegmentedControl[basicwidget.Density]
 
 	layoutItems []guigui.LinearLayoutItem
 }
@@ -164,6 +166,31 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 	})
 	s.scaleSegmentedControl.SelectItemByValue(context.AppScale())
 
+	s.densityText.SetValue("Density")
+	s.densitySegmentedControl.SetItems([]basicwidget.SegmentedControlItem[basicwidget.Density]{
+		{
+			Text:  "Compact",
+			Value: basicwidget.DensityCompact,
+		},
+		{
+			Text:  "Standard",
+			Value: basicwidget.DensityStandard,
+		},
+		{
+			Text:  "Touch",
+			Value: basicwidget.DensityTouch,
+		},
+	})
+	s.densitySegmentedControl.OnItemSelected(func(context *guigui.Context, index int) {
+		item, ok := s.densitySegmentedControl.ItemByIndex(index)
+		if !ok {
+			basicwidget.SetDensity(basicwidget.DensityDefault)
+			return
+		}
+		basicwidget.SetDensity(item.Value)
+	})
+	s.densitySegmentedControl.SelectItemByValue(basicwidget.AppDensity())
+
 	s.form.SetItems([]basicwidget.FormItem{
 		{
 			PrimaryWidget:   &s.colorModeText,
@@ -177,6 +204,10 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 			PrimaryWidget:   &s.scaleText,
 			SecondaryWidget: &s.scaleSegmentedControl,
 		},
+		{
+			PrimaryWidget:   &s.densityText,
+			SecondaryWidget: &s.densitySegmentedControl,
+		},
 	})
 
 	return nil
@@ -89,6 +89,24 @@ func (r *Root) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 		r.inited = true
 	}
 
+	// Only intercept window close when there's unsaved work. Intercepting
+	// affects the window appearance on some platforms (e.g. macOS shows the
+	// edited-document indicator), so leave it off when the document is clean.
+	if r.doc.IsDirty() {
+		guigui.OnWindowCloseRequested(r, func(context *guigui.Context) bool {
+			// The document might have been saved after the last build.
+			if !r.doc.IsDirty() {
+				return true
+			}
+			if !r.confirmDialog.IsOpen() {
+				r.confirmKind = confirmKindExit
+				r.confirmDialog.SetMessage("You have unsaved changes.")
+				r.confirmDialog.SetOpen(true)
+			}
+			return false
+		})
+	}
+
 	r.editor.OnValueChangedWithoutText(func(context *guigui.Context, _ bool) {
 		// Every dispatch is a real buffer change (the gen-tracker filters
 		// non-mutating events), so mark dirty regardless of the committed
@@ -261,25 +279,7 @@ func (r *Root) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds)
 	}
 
 	if r.exitRequested {
-		return ebiten.Termination
-	}
-
-	// Only intercept window close when there's unsaved work. Calling
-	// SetWindowClosingHandled affects the window appearance on some platforms
-	// (e.g. macOS shows the edited-document indicator), so leave it off when
-	// the document is clean.
-	needHandled := r.doc.IsDirty()
-	ebiten.SetWindowClosingHandled(needHandled)
-
-	if ebiten.IsWindowBeingClosed() {
-		if !needHandled {
-			return ebiten.Termination
-		}
-		if !r.confirmDialog.IsOpen() {
-			r.confirmKind = confirmKindExit
-			r.confirmDialog.SetMessage("You have unsaved changes.")
-			r.confirmDialog.SetOpen(true)
-		}
+		context.CloseWindow()
 	}
 	return nil
 }
@@ -100,7 +100,7 @@ func (t *Toast) SetOpen(open bool) {
 	t.popup.SetOpen(open)
 }
 
-var toastContentEventClose guigui.EventKey = guigui.GenerateEventKey()
+var toastContentEventClose = guigui.GenerateEventKey0()
 
 type toastContent struct {
 	guigui.DefaultWidget
@@ -125,7 +125,7 @@ func (t *toastContent) SetTintColor(tint color.Color) {
 }
 
 func (t *toastContent) OnClose(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(t, toastContentEventClose, f)
+	toastContentEventClose.SetHandler(t, f)
 }
 
 func (t *toastContent) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -142,7 +142,7 @@ func (t *toastContent) Build(context *guigui.Context, adder *guigui.ChildAdder)
 	t.text.SetVerticalAlign(basicwidget.VerticalAlignMiddle)
 	t.closeButton.SetText("Close")
 	t.closeButton.OnUp(func(context *guigui.Context) {
-		guigui.DispatchEvent(t, toastContentEventClose)
+		toastContentEventClose.Dispatch(t)
 	})
 
 	return nil
@@ -136,11 +136,11 @@ type taskWidget struct {
 }
 
 var (
-	taskWidgetEventDoneButtonPressed guigui.EventKey = guigui.GenerateEventKey()
+	taskWidgetEventDoneButtonPressed = guigui.GenerateEventKey0()
 )
 
 func (t *taskWidget) OnDoneButtonPressed(f func(context *guigui.Context)) {
-	guigui.SetEventHandler(t, taskWidgetEventDoneButtonPressed, f)
+	taskWidgetEventDoneButtonPressed.SetHandler(t, f)
 }
 
 func (t *taskWidget) SetText(text string) {
@@ -153,7 +153,7 @@ func (t *taskWidget) Build(context *guigui.Context, adder *guigui.ChildAdder) er
 
 	t.doneButton.SetText("Done")
 	t.doneButton.OnUp(func(context *guigui.Context) {
-		guigui.DispatchEvent(t, taskWidgetEventDoneButtonPressed)
+		taskWidgetEventDoneButtonPressed.Dispatch(t)
 	})
 
 	t.text.SetVerticalAlign(basicwidget.VerticalAlignMiddle)
@@ -194,11 +194,11 @@ type tasksPanelContent struct {
 }
 
 var (
-	tasksPanelContentEventDeleted guigui.EventKey = guigui.GenerateEventKey()
+	tasksPanelContentEventDeleted = guigui.GenerateEventKey1[int]()
 )
 
 func (t *tasksPanelContent) OnDeleted(f func(context *guigui.Context, id int)) {
-	guigui.SetEventHandler(t, tasksPanelContentEventDeleted, f)
+	tasksPanelContentEventDeleted.SetHandler(t, f)
 }
 
 func (t *tasksPanelContent) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
@@ -216,7 +216,7 @@ func (t *tasksPanelContent) Build(context *guigui.Context, adder *guigui.ChildAd
 	for i := range model.TaskCount() {
 		task := model.TaskByIndex(i)
 		t.taskWidgets.At(i).OnDoneButtonPressed(func(context *guigui.Context) {
-			guigui.DispatchEvent(t, tasksPanelContentEventDeleted, task.ID)
+			tasksPanelContentEventDeleted.Dispatch(t, task.ID)
 		})
 		t.taskWidgets.At(i).SetText(task.Text)
 	}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SetParentForTesting makes parent the parent of child without building the tree.
+func SetParentForTesting(child, parent Widget) {
+	child.widgetState().parent = parent
+	ws := parent.widgetState()
+	ws.children = append(ws.children, child)
+}
+
+// StateKeyForTesting returns the hash of what f writes.
+func StateKeyForTesting(f func(w *StateKeyWriter)) uint64 {
+	var w StateKeyWriter
+	w.reset()
+	f(&w)
+	return w.Sum64()
+}
+
+// SetCallingWidgetForTesting makes widget the widget whose method is being called.
+func SetCallingWidgetForTesting(context *Context, widget Widget) {
+	context.callingWidget = widget
+}
+
+// AppForTesting runs the phases of a widget tree without Ebitengine's game loop.
+// It uses the global application state, so tests using it must not run in parallel.
+type AppForTesting struct{}
+
+// NewAppForTesting resets the application state and makes root the root widget of the size.
+func NewAppForTesting(root Widget, size image.Point) *AppForTesting {
+	theApp = app{}
+	a := &theApp
+	a.root = root
+	root.widgetState().root = true
+	a.deviceScale = 1
+	a.screenWidth = float64(size.X)
+	a.screenHeight = float64(size.Y)
+	a.context.app = a
+	a.requiredPhases = a.requiredPhases.addBuild()
+	theWindowSystem = &WindowForTesting{}
+	return &AppForTesting{}
+}
+
+// Update runs one tick of the application without input, in the same order as [app.Update]:
+// the build and layout phases, the posted functions, the build and layout phases again, the window events,
+// Tick, and the timers.
+// Update returns [ebiten.Termination] if the window is closed.
+func (*AppForTesting) Update() error {
+	a := &theApp
+	a.tickCount++
+	focusChanged := a.updateFocused()
+	a.root.widgetState().bounds = a.bounds()
+	if _, err := a.buildAndLayoutWidgets(); err != nil {
+		return err
+	}
+	a.runPostedFuncs()
+	a.settleRebuildAndRedrawState(nil)
+	if _, err := a.buildAndLayoutWidgets(); err != nil {
+		return err
+	}
+	if a.handleWindowEvents(focusChanged) {
+		return ebiten.Termination
+	}
+	if err := a.tickWidgets(); err != nil {
+		return err
+	}
+	a.runTimers()
+	a.settleRebuildAndRedrawState(nil)
+	if a.closeWindowRequested {
+		return ebiten.Termination
+	}
+	return nil
+}
+
+// Window returns the fake window of the application.
+func (*AppForTesting) Window() *WindowForTesting {
+	return theWindowSystem.(*WindowForTesting)
+}
+
+// Context returns the application's context.
+func (*AppForTesting) Context() *Context {
+	return &theApp.context
+}
+
+// BuildCount returns how many times the widget tree has been built.
+func (*AppForTesting) BuildCount() int64 {
+	return theApp.buildCount
+}
+
+// IsInTree reports whether widget is in the widget tree built last.
+func (*AppForTesting) IsInTree(widget Widget) bool {
+	return widget.widgetState().isInTree(theApp.buildCount)
+}
+
+// WindowForTesting is a fake window. Its fields are the window's state, which a test sets
+// to simulate the user's operations.
+type WindowForTesting struct {
+	Focused        bool
+	BeingClosed    bool
+	ClosingHandled bool
+	Position       image.Point
+	Size           image.Point
+	Fullscreen     bool
+	Maximized      bool
+	Minimized      bool
+	Icon           []image.Image
+}
+
+func (w *WindowForTesting) IsFocused() bool {
+	return w.Focused
+}
+
+func (w *WindowForTesting) IsWindowBeingClosed() bool {
+	return w.BeingClosed
+}
+
+func (w *WindowForTesting) SetWindowClosingHandled(handled bool) {
+	w.ClosingHandled = handled
+}
+
+func (w *WindowForTesting) WindowPosition() (x, y int) {
+	return w.Position.X, w.Position.Y
+}
+
+func (w *WindowForTesting) SetWindowPosition(x, y int) {
+	w.Position = image.Pt(x, y)
+}
+
+func (w *WindowForTesting) WindowSize() (width, height int) {
+	return w.Size.X, w.Size.Y
+}
+
+func (w *WindowForTesting) SetWindowSize(width, height int) {
+	w.Size = image.Pt(width, height)
+}
+
+func (w *WindowForTesting) IsFullscreen() bool {
+	return w.Fullscreen
+}
+
+func (w *WindowForTesting) SetFullscreen(fullscreen bool) {
+	w.Fullscreen = fullscreen
+}
+
+func (w *WindowForTesting) IsWindowMaximized() bool {
+	return w.Maximized
+}
+
+func (w *WindowForTesting) MaximizeWindow() {
+	w.Maximized = true
+	w.Minimized = false
+}
+
+func (w *WindowForTesting) IsWindowMinimized() bool {
+	return w.Minimized
+}
+
+func (w *WindowForTesting) MinimizeWindow() {
+	w.Minimized = true
+	w.Maximized = false
+}
+
+func (w *WindowForTesting) RestoreWindow() {
+	w.Maximized = false
+	w.Minimized = false
+}
+
+func (w *WindowForTesting) SetWindowIcon(images []image.Image) {
+	w.Icon = images
+}
+
+// ApplyWindowOptionsForTesting applies the window options of options as [Run] does.
+func ApplyWindowOptionsForTesting(options *RunOptions) {
+	applyWindowOptions(options)
+}
+
+// DurationToTicksForTesting converts d to the number of ticks as a timer does.
+func DurationToTicksForTesting(d time.Duration) int64 {
+	return durationToTicks(d)
+}
+
+// ObservedValuesChangedForTesting reports whether any [Observable] value widget read in its last Build has been changed.
+func ObservedValuesChangedForTesting(widget Widget) bool {
+	return widget.widgetState().observedValuesChanged()
+}
+
+// PropagatingEventHandlerCountForTesting returns the number of the registered capturing and bubbling event handlers.
+func PropagatingEventHandlerCountForTesting() int {
+	return theApp.propagatingEventHandlerCount
+}
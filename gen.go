@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+//go:build ignore
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	if err := xmain(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// eventKey is the parameters of a type-safe event key type.
+type eventKey struct {
+	// N is the number of the arguments.
+	N int
+
+	// Result reports whether the event has a result.
+	Result bool
+}
+
+var argNames = []string{"a", "b", "c"}
+var argTypes = []string{"A", "B", "C"}
+
+func (e eventKey) Name() string {
+	if e.Result {
+		return fmt.Sprintf("EventKey%dR", e.N)
+	}
+	return fmt.Sprintf("EventKey%d", e.N)
+}
+
+// ArgsName returns the name of the type holding the arguments.
+func (e eventKey) ArgsName() string {
+	if e.Result {
+		return fmt.Sprintf("resultEventArgs%d", e.N)
+	}
+	return fmt.Sprintf("eventArgs%d", e.N)
+}
+
+// Count returns the description of the number of the arguments.
+func (e eventKey) Count() string {
+	return []string{"no arguments", "one argument", "two arguments", "three arguments"}[e.N]
+}
+
+// TypeParamNames returns the type parameter names like "A, B, R".
+func (e eventKey) TypeParamNames() string {
+	names := append([]string{}, argTypes[:e.N]...)
+	if e.Result {
+		names = append(names, "R")
+	}
+	return strings.Join(names, ", ")
+}
+
+// TypeParams returns the type parameter list like "[A, B, R any]", or an empty string.
+func (e eventKey) TypeParams() string {
+	if names := e.TypeParamNames(); names != "" {
+		return "[" + names + " any]"
+	}
+	return ""
+}
+
+// TypeArgs returns the type argument list like "[A, B, R]", or an empty string.
+func (e eventKey) TypeArgs() string {
+	if names := e.TypeParamNames(); names != "" {
+		return "[" + names + "]"
+	}
+	return ""
+}
+
+// Params returns the parameters like ", a A, b B".
+func (e eventKey) Params() string {
+	var b strings.Builder
+	for i := range e.N {
+		fmt.Fprintf(&b, ", %s %s", argNames[i], argTypes[i])
+	}
+	return b.String()
+}
+
+// ParamTypes returns the parameter types like ", A, B".
+func (e eventKey) ParamTypes() string {
+	var b strings.Builder
+	for i := range e.N {
+		fmt.Fprintf(&b, ", %s", argTypes[i])
+	}
+	return b.String()
+}
+
+// StructType returns the struct type holding the arguments.
+func (e eventKey) StructType() string {
+	if e.N == 0 {
+		return "struct{}"
+	}
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for i := range e.N {
+		fmt.Fprintf(&b, "\t%s %s\n", argNames[i], argTypes[i])
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// FieldArgs returns the arguments from the fields like ", args.a, args.b".
+func (e eventKey) FieldArgs() string {
+	var b strings.Builder
+	for i := range e.N {
+		fmt.Fprintf(&b, ", args.%s", argNames[i])
+	}
+	return b.String()
+}
+
+// Literal returns the composite literal elements like "a: a, b: b".
+func (e eventKey) Literal() string {
+	var elems []string
+	for i := range e.N {
+		elems = append(elems, argNames[i]+": "+argNames[i])
+	}
+	return strings.Join(elems, ", ")
+}
+
+const tmpl = `// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Code generated by gen.go. DO NOT EDIT.
+
+package guigui
+{{range .}}
+{{- if not .Result}}
+// {{.Name}} is a type-safe key for an event with {{.Count}}.
+// The handler type is func(context *Context{{.Params}}).
+type {{.Name}}{{.TypeParams}} struct {
+	typedEventKey
+}
+
+// Generate{{.Name}} generates a new {{.Name}}.
+func Generate{{.Name}}{{.TypeParams}}() {{.Name}}{{.TypeArgs}} {
+	return {{.Name}}{{.TypeArgs}}{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k {{.Name}}{{.TypeArgs}}) SetHandler(widget Widget, handler func(context *Context{{.Params}})) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// SetCapturingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the capturing phase, before the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k {{.Name}}{{.TypeArgs}}) SetCapturingHandler(widget Widget, handler func(context *Context, event *Event{{.Params}})) {
+	k.setHandler(widget, EventPhaseCapturing, handler, handler == nil)
+}
+
+// SetBubblingHandler registers handler for the events dispatched on the widget's descendants.
+// handler is invoked in the bubbling phase, after the handler on the target widget.
+// See [Event] for details. If handler is nil, the registered handler is removed.
+func (k {{.Name}}{{.TypeArgs}}) SetBubblingHandler(widget Widget, handler func(context *Context, event *Event{{.Params}})) {
+	k.setHandler(widget, EventPhaseBubbling, handler, handler == nil)
+}
+
+// Dispatch dispatches the event on the widget.
+// It returns true if a handler registered on the widget was found, or false otherwise.
+//
+// Handlers registered on the ancestors by SetCapturingHandler and SetBubblingHandler are also invoked.
+func (k {{.Name}}{{.TypeArgs}}) Dispatch(widget Widget{{.Params}}) bool {
+	return dispatchEvent(widget, k.key, {{.ArgsName}}{{.TypeArgs}}{ {{- .Literal -}} })
+}
+
+// {{.ArgsName}} is the arguments of an event dispatched with {{.Name}}.
+type {{.ArgsName}}{{.TypeParams}} {{.StructType}}
+
+func (args {{.ArgsName}}{{.TypeArgs}}) invokeTarget(context *Context, handler any) {
+	eventHandlerOf[func(*Context{{.ParamTypes}})](handler)(context{{.FieldArgs}})
+}
+
+func (args {{.ArgsName}}{{.TypeArgs}}) invokeAncestor(context *Context, handler any, event *Event) {
+	eventHandlerOf[func(*Context, *Event{{.ParamTypes}})](handler)(context, event{{.FieldArgs}})
+}
+{{else}}
+// {{.Name}} is a type-safe key for an event with {{.Count}} and a result.
+// The handler type is func(context *Context{{.Params}}) R.
+type {{.Name}}{{.TypeParams}} struct {
+	typedEventKey
+}
+
+// Generate{{.Name}} generates a new {{.Name}}.
+func Generate{{.Name}}{{.TypeParams}}() {{.Name}}{{.TypeArgs}} {
+	return {{.Name}}{{.TypeArgs}}{typedEventKey: typedEventKey{key: GenerateEventKey()}}
+}
+
+// SetHandler registers handler for the event on the widget.
+// See [SetEventHandler] for details. If handler is nil, the registered handler is removed.
+func (k {{.Name}}{{.TypeArgs}}) SetHandler(widget Widget, handler func(context *Context{{.Params}}) R) {
+	k.setHandler(widget, EventPhaseTarget, handler, handler == nil)
+}
+
+// Dispatch invokes the handler registered for the event on the widget.
+// It returns the handler's result and true if a handler was found, or the zero value and false otherwise.
+//
+// An event with a result does not propagate to the ancestors.
+func (k {{.Name}}{{.TypeArgs}}) Dispatch(widget Widget{{.Params}}) (R, bool) {
+	return dispatchResultEvent[R](widget, k.key, {{.ArgsName}}{{.TypeArgs}}{ {{- .Literal -}} })
+}
+
+// {{.ArgsName}} is the arguments of an event dispatched with {{.Name}}.
+type {{.ArgsName}}{{.TypeParams}} {{.StructType}}
+
+func (args {{.ArgsName}}{{.TypeArgs}}) invoke(context *Context, handler any) R {
+	return eventHandlerOf[func(*Context{{.ParamTypes}}) R](handler)(context{{.FieldArgs}})
+}
+{{end}}
+{{- end}}`
+
+func xmain() error {
+	var keys []eventKey
+	for _, result := range []bool{false, true} {
+		for n := range 4 {
+			keys = append(keys, eventKey{N: n, Result: result})
+		}
+	}
+
+	t, err := template.New("eventkeys").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, keys); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, buf.String())
+	}
+	return os.WriteFile("eventkeys.go", src, 0644)
+}
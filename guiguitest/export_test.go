@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guiguitest
+
+import (
+	"image"
+)
+
+// CompareImagesForTesting returns the number of the different pixels of got and want.
+func CompareImagesForTesting(got, want image.Image, threshold float64) int {
+	n, _ := compareImages(got, want, threshold)
+	return n
+}
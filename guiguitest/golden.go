@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guiguitest
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// updateGolden reports whether the golden images are updated instead of compared,
+// by the environment variable GUIGUI_GOLDEN_UPDATE.
+//
+// This is not a flag, as a flag defined in this package would conflict with the test packages' own flags.
+func updateGolden() bool {
+	v, _ := strconv.ParseBool(os.Getenv("GUIGUI_GOLDEN_UPDATE"))
+	return v
+}
+
+// GoldenOptions represents options for [AssertGolden].
+type GoldenOptions struct {
+	// Threshold is the perceptual color difference for a pixel to be regarded as different, in [0, 1].
+	// The difference is measured in the YIQ color space, which weights luma over chroma as human eyes do.
+	// If Threshold is 0, 0.1 is used, which tolerates anti-aliasing differences between GPUs.
+	// Use a negative value to require exactly the same colors.
+	Threshold float64
+
+	// MaxDiffPixels is the number of the different pixels to tolerate.
+	MaxDiffPixels int
+}
+
+// AssertGolden compares img with the golden image testdata/<name>.png.
+// If they differ, AssertGolden fails the test, and writes the actual image and a diff image
+// to the directory GUIGUI_GOLDEN_OUTPUT_DIR, or a temporary directory if the variable is not set.
+// In the diff image, different pixels are red over a faded copy of the golden image.
+//
+// Translucent pixels are compared as composited over white. Thus, a fully transparent pixel equals
+// a white one, and a widget drawn without a background cannot be told from one drawn on white.
+//
+// If the environment variable GUIGUI_GOLDEN_UPDATE is 1, AssertGolden writes img as the golden image instead.
+func AssertGolden(t testing.TB, img image.Image, name string, options *GoldenOptions) {
+	t.Helper()
+
+	if options == nil {
+		options = &GoldenOptions{}
+	}
+	path := filepath.Join("testdata", name+".png")
+
+	if updateGolden() {
+		if err := writePNG(path, img); err != nil {
+			t.Fatalf("guiguitest: updating the golden image failed: %v", err)
+		}
+		t.Logf("guiguitest: updated %s", path)
+		return
+	}
+
+	want, err := readPNG(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("guiguitest: the golden image %s does not exist; run the test with GUIGUI_GOLDEN_UPDATE=1 to create it", path)
+		}
+		t.Fatalf("guiguitest: reading the golden image failed: %v", err)
+	}
+
+	threshold := options.Threshold
+	if threshold == 0 {
+		threshold = 0.1
+	}
+	if threshold < 0 {
+		threshold = 0
+	}
+
+	if !img.Bounds().Size().Eq(want.Bounds().Size()) {
+		t.Errorf("guiguitest: %s: size: got: %v, want: %v", path, img.Bounds().Size(), want.Bounds().Size())
+		writeFailure(t, name, img, nil)
+		return
+	}
+	n, diff := compareImages(img, want, threshold)
+	if n <= options.MaxDiffPixels {
+		return
+	}
+	t.Errorf("guiguitest: %s: %d pixels differ (%d tolerated)", path, n, options.MaxDiffPixels)
+	writeFailure(t, name, img, diff)
+}
+
+// writeFailure writes the actual image and the diff image for inspection.
+func writeFailure(t testing.TB, name string, img image.Image, diff image.Image) {
+	t.Helper()
+
+	dir := os.Getenv("GUIGUI_GOLDEN_OUTPUT_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "guigui-golden")
+	}
+	actualPath := filepath.Join(dir, name+".png")
+	if err := writePNG(actualPath, img); err != nil {
+		t.Logf("guiguitest: writing the actual image failed: %v", err)
+		return
+	}
+	t.Logf("guiguitest: the actual image is written to %s", actualPath)
+	if diff == nil {
+		return
+	}
+	diffPath := filepath.Join(dir, name+".diff.png")
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Logf("guiguitest: writing the diff image failed: %v", err)
+		return
+	}
+	t.Logf("guiguitest: the diff image is written to %s", diffPath)
+}
+
+// maxColorDelta is the maximum value of colorDelta.
+const maxColorDelta = 35215
+
+// compareImages returns the number of the pixels of got and want whose colors differ more than threshold,
+// and a diff image. got and want must be the same size.
+func compareImages(got, want image.Image, threshold float64) (int, *image.RGBA) {
+	gb := got.Bounds()
+	wb := want.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, gb.Dx(), gb.Dy()))
+	maxDelta := maxColorDelta * threshold * threshold
+	var n int
+	for j := 0; j < gb.Dy(); j++ {
+		for i := 0; i < gb.Dx(); i++ {
+			c0 := color.RGBAModel.Convert(got.At(gb.Min.X+i, gb.Min.Y+j)).(color.RGBA)
+			c1 := color.RGBAModel.Convert(want.At(wb.Min.X+i, wb.Min.Y+j)).(color.RGBA)
+			if c0 != c1 && colorDelta(c0, c1) > maxDelta {
+				n++
+				diff.SetRGBA(i, j, color.RGBA{R: 0xff, A: 0xff})
+				continue
+			}
+			// Fade the unchanged pixels so that the different ones stand out.
+			y := uint8(255 - (255-luma(c1))*0.1)
+			diff.SetRGBA(i, j, color.RGBA{R: y, G: y, B: y, A: 0xff})
+		}
+	}
+	return n, diff
+}
+
+// blendWhite returns the color components of c composited over white.
+// c is premultiplied by alpha, so a fully transparent color becomes white.
+func blendWhite(c color.RGBA) (r, g, b float64) {
+	bg := 255 - float64(c.A)
+	return float64(c.R) + bg, float64(c.G) + bg, float64(c.B) + bg
+}
+
+func luma(c color.RGBA) float64 {
+	r, g, b := blendWhite(c)
+	return r*0.29889531 + g*0.58662247 + b*0.11448223
+}
+
+// colorDelta returns the squared perceptual difference of the colors in the YIQ color space.
+//
+// See Kotsarenko and Ramos, "Measuring perceived color difference using YIQ NTSC transmission color space in mobile applications" (2010).
+func colorDelta(c0, c1 color.RGBA) float64 {
+	r0, g0, b0 := blendWhite(c0)
+	r1, g1, b1 := blendWhite(c1)
+	dr, dg, db := r0-r1, g0-g1, b0-b1
+	y := dr*0.29889531 + dg*0.58662247 + db*0.11448223
+	i := dr*0.59597799 - dg*0.27417610 - db*0.32180189
+	q := dr*0.21147017 - dg*0.52261711 + db*0.31114694
+	return 0.5053*y*y + 0.299*i*i + 0.1957*q*q
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err1 := f.Close(); err1 != nil && err == nil {
+			err = err1
+		}
+	}()
+	return png.Encode(f, img)
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guiguitest_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/guiguitest"
+)
+
+func filledImage(c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for j := range 4 {
+		for i := range 4 {
+			img.SetRGBA(i, j, c)
+		}
+	}
+	return img
+}
+
+func TestCompareImages(t *testing.T) {
+	base := filledImage(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+
+	slight := filledImage(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+	slight.SetRGBA(1, 1, color.RGBA{R: 0x82, G: 0x81, B: 0x80, A: 0xff})
+
+	distinct := filledImage(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+	distinct.SetRGBA(1, 1, color.RGBA{R: 0xff, A: 0xff})
+	distinct.SetRGBA(2, 3, color.RGBA{A: 0xff})
+
+	// A transparent pixel looks white.
+	transparent := filledImage(color.RGBA{})
+	white := filledImage(color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+
+	testCases := []struct {
+		name      string
+		got       image.Image
+		want      image.Image
+		threshold float64
+		diff      int
+	}{
+		{"same", base, base, 0, 0},
+		{"slight", slight, base, 0.1, 0},
+		{"slight exact", slight, base, 0, 1},
+		{"distinct", distinct, base, 0.1, 2},
+		{"transparent over white", transparent, white, 0, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := guiguitest.CompareImagesForTesting(tc.got, tc.want, tc.threshold); got != tc.diff {
+				t.Errorf("got: %d, want: %d", got, tc.diff)
+			}
+		})
+	}
+}
+
+func TestVariants(t *testing.T) {
+	vs := guiguitest.Variants(guiguitest.ColorModes, []float64{1, 1.5}, []language.Tag{language.English, language.Japanese})
+	if got, want := len(vs), 8; got != want {
+		t.Fatalf("len(Variants(...)): got: %d, want: %d", got, want)
+	}
+	if got, want := vs[0].Name(), "light-1x-en"; got != want {
+		t.Errorf("vs[0].Name(): got: %q, want: %q", got, want)
+	}
+	if got, want := vs[7].Name(), "dark-1.5x-ja"; got != want {
+		t.Errorf("vs[7].Name(): got: %q, want: %q", got, want)
+	}
+	if got, want := (guiguitest.Variant{}).Name(), "default"; got != want {
+		t.Errorf("Variant{}.Name(): got: %q, want: %q", got, want)
+	}
+	if got := (guiguitest.Variant{ColorMode: ebiten.ColorModeDark}).RenderOptions().Locales; got != nil {
+		t.Errorf("RenderOptions().Locales: got: %v, want: nil", got)
+	}
+}
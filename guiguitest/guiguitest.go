@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package guiguitest provides helpers to test widgets by rendering them into images.
+//
+// Tests using [Render] must run on a Guigui application. Call [Main] from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		guiguitest.Main(m)
+//	}
+//
+// A rendered image is compared with a golden image in testdata by [AssertGolden].
+// Run the tests with GUIGUI_GOLDEN_UPDATE=1 to create or refresh the golden images:
+//
+//	GUIGUI_GOLDEN_UPDATE=1 go test ./mywidget
+//
+// [Variants] enumerates the combinations of color modes, application scales, and locales,
+// so that a widget is guarded against regressions in all of them:
+//
+//	func TestButton(t *testing.T) {
+//		for _, v := range guiguitest.Variants(guiguitest.ColorModes, []float64{1, 2}, nil) {
+//			t.Run(v.Name(), func(t *testing.T) {
+//				var button basicwidget.Button
+//				button.SetText("OK")
+//				img := guiguitest.Render(t, &button, image.Pt(100, 40), v.RenderOptions())
+//				guiguitest.AssertGolden(t, img, "button-"+v.Name(), nil)
+//			})
+//		}
+//	}
+//
+// As Ebitengine opens a window, the tests need a display or a virtual one like Xvfb.
+// The desktop settings, such as the accent color, are ignored so that the images do not depend on the host.
+package guiguitest
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+)
+
+// requests are the functions run on the application's goroutine.
+var requests = make(chan func(context *guigui.Context))
+
+// running reports whether the application run by Main is running.
+var running atomic.Bool
+
+type root struct {
+	guigui.DefaultWidget
+
+	done chan struct{}
+}
+
+func (r *root) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
+	for {
+		select {
+		case f := <-requests:
+			f(context)
+		case <-r.done:
+			return ebiten.Termination
+		default:
+			return nil
+		}
+	}
+}
+
+// Main runs the tests of m on a Guigui application and exits with the tests' exit code.
+// Main must be called from TestMain, as Ebitengine must run on the main goroutine.
+func Main(m *testing.M) {
+	desktopsettings.Disable()
+
+	r := &root{
+		done: make(chan struct{}),
+	}
+	var code int
+	go func() {
+		defer close(r.done)
+		code = m.Run()
+	}()
+
+	running.Store(true)
+	err := guigui.Run(r, &guigui.RunOptions{
+		Title:      "guiguitest",
+		WindowSize: image.Pt(320, 240),
+		RunGameOptions: &ebiten.RunGameOptions{
+			InitUnfocused: true,
+		},
+	})
+	running.Store(false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "guiguitest:", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// RenderOptions represents options for [Render].
+type RenderOptions struct {
+	// ColorMode is the color mode to render the widget in.
+	// If ColorMode is [ebiten.ColorModeUnknown], the current color mode is used.
+	ColorMode ebiten.ColorMode
+
+	// DeviceScale is the device scale factor to render the widget at.
+	// If DeviceScale is 0, 1 is used so that the result does not depend on the display.
+	DeviceScale float64
+
+	// AppScale is the application scale factor to render the widget at.
+	// If AppScale is 0, 1 is used.
+	AppScale float64
+
+	// Locales are the application locales to render the widget with.
+	// If Locales is nil, the current application locales are used.
+	Locales []language.Tag
+}
+
+// Render renders the widget at the size by [guigui.Context.RenderWidget] and returns the pixels.
+// size is in device-independent pixels multiplied by the device scale factor, i.e. the size of the returned image.
+//
+// Render must be called in a test run by [Main]. Render fails the test if rendering fails.
+func Render(t testing.TB, widget guigui.Widget, size image.Point, options *RenderOptions) *image.RGBA {
+	t.Helper()
+
+	if !running.Load() {
+		t.Fatal("guiguitest: Render must be called in tests run by guiguitest.Main")
+	}
+	if options == nil {
+		options = &RenderOptions{}
+	}
+	renderOptions := &guigui.RenderWidgetOptions{
+		DeviceScale: options.DeviceScale,
+		AppScale:    options.AppScale,
+		ColorMode:   options.ColorMode,
+		Locales:     options.Locales,
+	}
+	if renderOptions.DeviceScale == 0 {
+		renderOptions.DeviceScale = 1
+	}
+	if renderOptions.AppScale == 0 {
+		renderOptions.AppScale = 1
+	}
+
+	type result struct {
+		img *image.RGBA
+		err error
+	}
+	ch := make(chan result, 1)
+	requests <- func(context *guigui.Context) {
+		img, err := context.RenderWidget(widget, size, renderOptions)
+		if err != nil {
+			ch <- result{err: err}
+			return
+		}
+		defer img.Deallocate()
+		// Read the pixels on the application's goroutine while the game is running.
+		// Ebitengine's pixels are premultiplied by alpha, as image.RGBA's are.
+		rgba := image.NewRGBA(img.Bounds())
+		img.ReadPixels(rgba.Pix)
+		ch <- result{img: rgba}
+	}
+	res := <-ch
+	if res.err != nil {
+		t.Fatalf("guiguitest: rendering %T failed: %v", widget, res.err)
+	}
+	return res.img
+}
+
+// ColorModes are the light and dark color modes.
+var ColorModes = []ebiten.ColorMode{ebiten.ColorModeLight, ebiten.ColorModeDark}
+
+// Variant is a combination of the parameters to render a widget.
+type Variant struct {
+	ColorMode ebiten.ColorMode
+	AppScale  float64
+	Locale    language.Tag
+}
+
+// Variants returns all the combinations of the color modes, the application scales, and the locales.
+// A nil slice means the current setting.
+func Variants(colorModes []ebiten.ColorMode, appScales []float64, locales []language.Tag) []Variant {
+	if len(colorModes) == 0 {
+		colorModes = []ebiten.ColorMode{ebiten.ColorModeUnknown}
+	}
+	if len(appScales) == 0 {
+		appScales = []float64{0}
+	}
+	if len(locales) == 0 {
+		locales = []language.Tag{language.Und}
+	}
+	vs := make([]Variant, 0, len(colorModes)*len(appScales)*len(locales))
+	for _, c := range colorModes {
+		for _, s := range appScales {
+			for _, l := range locales {
+				vs = append(vs, Variant{
+					ColorMode: c,
+					AppScale:  s,
+					Locale:    l,
+				})
+			}
+		}
+	}
+	return vs
+}
+
+// Name returns a name of the variant usable for a subtest and a golden image, like "dark-1.5x-ja".
+// The unspecified parameters are omitted.
+func (v Variant) Name() string {
+	var parts []string
+	switch v.ColorMode {
+	case ebiten.ColorModeLight:
+		parts = append(parts, "light")
+	case ebiten.ColorModeDark:
+		parts = append(parts, "dark")
+	}
+	if v.AppScale > 0 {
+		parts = append(parts, strconv.FormatFloat(v.AppScale, 'f', -1, 64)+"x")
+	}
+	if v.Locale != language.Und {
+		parts = append(parts, v.Locale.String())
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, "-")
+}
+
+// RenderOptions returns the options for [Render] to render a widget in the variant.
+func (v Variant) RenderOptions() *RenderOptions {
+	options := &RenderOptions{
+		ColorMode: v.ColorMode,
+		AppScale:  v.AppScale,
+	}
+	if v.Locale != language.Und {
+		options.Locales = []language.Tag{v.Locale}
+	}
+	return options
+}
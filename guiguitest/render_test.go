@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guiguitest_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/guiguitest"
+)
+
+func TestMain(m *testing.M) {
+	guiguitest.Main(m)
+}
+
+var renderTestEvent = guigui.GenerateEventKey0()
+
+type fillWidget struct {
+	guigui.DefaultWidget
+
+	color *guigui.Observable[color.RGBA]
+	fill  color.RGBA
+}
+
+func (f *fillWidget) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	if f.color != nil {
+		f.fill = f.color.Get()
+	}
+	return nil
+}
+
+func (f *fillWidget) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	renderTestEvent.Dispatch(f)
+}
+
+func (f *fillWidget) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	dst.SubImage(widgetBounds.Bounds()).(*ebiten.Image).Fill(f.fill)
+}
+
+type renderTestRoot struct {
+	fillWidget
+
+	child fillWidget
+
+	dispatched int
+}
+
+func (r *renderTestRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&r.child)
+	renderTestEvent.SetBubblingHandler(r, func(context *guigui.Context, event *guigui.Event) {
+		r.dispatched++
+	})
+	return nil
+}
+
+func (r *renderTestRoot) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	b := widgetBounds.Bounds()
+	layouter.LayoutWidget(&r.child, image.Rect(b.Min.X+b.Dx()/2, b.Min.Y, b.Max.X, b.Max.Y))
+}
+
+func TestRender(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+
+	var childColor guigui.Observable[color.RGBA]
+	childColor.Set(blue)
+	var root renderTestRoot
+	root.fill = red
+	root.child.color = &childColor
+
+	for _, tc := range []struct {
+		deviceScale float64
+		size        image.Point
+	}{
+		{deviceScale: 1, size: image.Pt(20, 10)},
+		{deviceScale: 2, size: image.Pt(40, 20)},
+	} {
+		img := guiguitest.Render(t, &root, tc.size, &guiguitest.RenderOptions{
+			DeviceScale: tc.deviceScale,
+		})
+		if got, want := img.Bounds().Size(), tc.size; got != want {
+			t.Errorf("size at scale %g: got: %v, want: %v", tc.deviceScale, got, want)
+		}
+		if got, want := img.RGBAAt(tc.size.X/4, tc.size.Y/2), red; got != want {
+			t.Errorf("the root's pixel at scale %g: got: %v, want: %v", tc.deviceScale, got, want)
+		}
+		if got, want := img.RGBAAt(tc.size.X*3/4, tc.size.Y/2), blue; got != want {
+			t.Errorf("the child's pixel at scale %g: got: %v, want: %v", tc.deviceScale, got, want)
+		}
+	}
+
+	// The child's Dispatch in its Layout reaches the root's bubbling handler in the rendered tree.
+	if root.dispatched == 0 {
+		t.Errorf("the bubbling handler was not called")
+	}
+
+	// A widget can be rendered again with a new value.
+	childColor.Set(green)
+	img := guiguitest.Render(t, &root, image.Pt(20, 10), nil)
+	if got, want := img.RGBAAt(15, 5), green; got != want {
+		t.Errorf("the child's pixel after the change: got: %v, want: %v", got, want)
+	}
+}
+
+// colorModeWidget records the color modes in Build, and posts a function that changes its fill color.
+type colorModeWidget struct {
+	fillWidget
+
+	colorMode       ebiten.ColorMode
+	globalColorMode ebiten.ColorMode
+	posted          bool
+}
+
+func (c *colorModeWidget) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	c.colorMode = context.ColorMode()
+	c.globalColorMode = ebiten.PreferredColorMode()
+	if !c.posted {
+		c.posted = true
+		guigui.Post(func(context *guigui.Context) {
+			c.fill = color.RGBA{G: 0xff, A: 0xff}
+		})
+	}
+	return nil
+}
+
+func TestRenderColorModeAndPost(t *testing.T) {
+	globalColorMode := ebiten.PreferredColorMode()
+	for _, mode := range []ebiten.ColorMode{ebiten.ColorModeLight, ebiten.ColorModeDark} {
+		var w colorModeWidget
+		w.fill = color.RGBA{R: 0xff, A: 0xff}
+		img := guiguitest.Render(t, &w, image.Pt(10, 10), &guiguitest.RenderOptions{
+			ColorMode: mode,
+		})
+		if got, want := w.colorMode, mode; got != want {
+			t.Errorf("ColorMode in Build: got: %v, want: %v", got, want)
+		}
+		// The color mode of the rendered tree does not change Ebitengine's color mode.
+		if got, want := w.globalColorMode, globalColorMode; got != want {
+			t.Errorf("ebiten.PreferredColorMode in Build: got: %v, want: %v", got, want)
+		}
+		// The function posted in Build runs before the widget is rendered.
+		if got, want := img.RGBAAt(5, 5), (color.RGBA{G: 0xff, A: 0xff}); got != want {
+			t.Errorf("the pixel after the posted function: got: %v, want: %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package i18n translates the messages of an application into the locales of a [guigui.Context].
+//
+// A [Catalog] has the translated messages of each language, loaded from gettext PO files, XLIFF files or JSON files.
+// A message is looked up by its key, which is usually the message in the source language,
+// such as "%d files selected".
+// [Catalog.Sprintf] takes the first of the context's locales that the catalog translates the message into,
+// and formats the translation with golang.org/x/text/message:
+// the arguments are substituted for the placeholders like fmt.Sprintf, numbers are formatted for the language,
+// and a plural message selects its form by the first argument.
+// [Catalog.Plural] formats a plural message with the count as the first argument.
+// If no locale has a translation, the key itself is formatted.
+//
+// [guigui.Context.SetAppLocales] rebuilds the whole widget tree,
+// so the messages translated in [guigui.Widget.Build] follow the new locales without any extra work.
+//
+// The guigui-i18n command extracts the message keys from the sources into the translation files:
+//
+//	go run github.com/guigui-gui/guigui/i18n/cmd/guigui-i18n -locales ja,fr ./...
+//
+// # JSON files
+//
+// A JSON file is an object whose names are the message keys.
+// A value is either a string, or an object of plural forms for a plural message:
+//
+//	{
+//		"Open": "Ouvrir",
+//		"%d files selected": {
+//			"one": "%d fichier sélectionné",
+//			"other": "%d fichiers sélectionnés"
+//		}
+//	}
+//
+// The names of plural forms are the CLDR plural categories "zero", "one", "two", "few", "many" and "other",
+// or an exact value like "=0". "other" is required.
+//
+// # PO files
+//
+// A PO file is read as GNU gettext writes it. msgid is the message key, and msgid_plural makes a plural message.
+// The forms msgstr[0], msgstr[1], ... are assigned to the CLDR plural categories that integers take in the language,
+// in the order of zero, one, two, few and many, and the last form is also the other category.
+// This is the order of the Plural-Forms headers of the common languages; the header itself is not evaluated.
+// Untranslated messages, fuzzy messages and messages with msgctxt are skipped.
+//
+// # XLIFF files
+//
+// An XLIFF 1.2 file is read in the same way as a PO file converted by the Translate Toolkit.
+// The id of a trans-unit is the message key, and the target is the translation.
+// A group whose restype is x-gettext-plurals is a plural message, whose trans-units are the plural forms in order.
+// A target whose state is needs-review-translation is fuzzy.
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+// Catalog is a set of the translated messages of languages.
+//
+// Load the messages before the application starts to look them up. A Catalog is not safe for concurrent use.
+type Catalog struct {
+	source  language.Tag
+	builder *catalog.Builder
+
+	// keys is the set of the message keys of each language.
+	keys      map[language.Tag]map[string]struct{}
+	languages []language.Tag
+	matcher   language.Matcher
+
+	printers map[language.Tag]*message.Printer
+
+	tmpLocales []language.Tag
+}
+
+// NewCatalog returns a new empty catalog whose messages are written in the source language.
+//
+// The source language is used to format a message that no locale has a translation of.
+func NewCatalog(source language.Tag) *Catalog {
+	return &Catalog{
+		source:  source,
+		builder: catalog.NewBuilder(catalog.Fallback(source)),
+	}
+}
+
+// Languages returns the languages that the catalog has messages of.
+func (c *Catalog) Languages() []language.Tag {
+	return slices.Clone(c.languages)
+}
+
+// SetString sets the translation of the message for the key in the language.
+func (c *Catalog) SetString(tag language.Tag, key string, msg string) error {
+	if err := c.builder.SetString(tag, key, msg); err != nil {
+		return fmt.Errorf("i18n: message %q: %w", key, err)
+	}
+	c.addKey(tag, key)
+	return nil
+}
+
+// SetPlural sets the translation of the plural message for the key in the language.
+//
+// forms maps the names of plural forms to the messages in the same way as a JSON file.
+func (c *Catalog) SetPlural(tag language.Tag, key string, forms map[string]string) error {
+	if _, ok := forms["other"]; !ok {
+		return fmt.Errorf("i18n: plural message %q has no other form", key)
+	}
+	names := make([]string, 0, len(forms))
+	for name := range forms {
+		names = append(names, name)
+	}
+	// An exact value must precede the categories, as the first matching case wins.
+	slices.SortFunc(names, func(a, b string) int {
+		if r := pluralFormRank(a) - pluralFormRank(b); r != 0 {
+			return r
+		}
+		return strings.Compare(a, b)
+	})
+	cases := make([]any, 0, 2*len(names))
+	for _, name := range names {
+		cases = append(cases, name, forms[name])
+	}
+	return c.setPlural(tag, key, cases)
+}
+
+// pluralFormRank returns the order of a plural form name in the cases of a plural message.
+func pluralFormRank(name string) int {
+	if strings.HasPrefix(name, "=") || strings.HasPrefix(name, "<") {
+		return 0
+	}
+	switch name {
+	case "zero":
+		return 1
+	case "one":
+		return 2
+	case "two":
+		return 3
+	case "few":
+		return 4
+	case "many":
+		return 5
+	case "other":
+		return 6
+	}
+	return 7
+}
+
+// setPluralByIndex sets the plural message from the forms in the order of gettext.
+func (c *Catalog) setPluralByIndex(tag language.Tag, key string, msgs []string) error {
+	forms := msgfile.IntegerPluralForms(tag)
+	cases := make([]any, 0, 2*len(forms))
+	for i, form := range forms {
+		// The last message is kept for the other category.
+		if i >= len(msgs)-1 || form == plural.Other {
+			break
+		}
+		cases = append(cases, form, msgs[i])
+	}
+	cases = append(cases, plural.Other, msgs[len(msgs)-1])
+	return c.setPlural(tag, key, cases)
+}
+
+func (c *Catalog) setPlural(tag language.Tag, key string, cases []any) error {
+	if err := c.builder.Set(tag, key, plural.Selectf(1, "", cases...)); err != nil {
+		return fmt.Errorf("i18n: message %q: %w", key, err)
+	}
+	c.addKey(tag, key)
+	return nil
+}
+
+func (c *Catalog) addKey(tag language.Tag, key string) {
+	keys, ok := c.keys[tag]
+	if !ok {
+		keys = map[string]struct{}{}
+		if c.keys == nil {
+			c.keys = map[language.Tag]map[string]struct{}{}
+		}
+		c.keys[tag] = keys
+		c.languages = append(c.languages, tag)
+		c.matcher = nil
+	}
+	keys[key] = struct{}{}
+}
+
+func (c *Catalog) hasKey(tag language.Tag, key string) bool {
+	_, ok := c.keys[tag][key]
+	return ok
+}
+
+// LoadFS loads the files in the root directory of fsys whose names are a language tag with the extension
+// .po, .xlf, .xliff or .json, such as ja.po and zh-Hant.json. The other files are ignored.
+//
+// LoadFS is useful with an embedded directory:
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	sub, err := fs.Sub(locales, "locales")
+//	...
+//	err = catalog.LoadFS(sub)
+func (c *Catalog) LoadFS(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := path.Ext(name)
+		switch ext {
+		case ".po", ".xlf", ".xliff", ".json":
+		default:
+			continue
+		}
+		tag, err := language.Parse(strings.TrimSuffix(name, ext))
+		if err != nil {
+			continue
+		}
+		if err := c.loadFile(fsys, name, tag, ext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) loadFile(fsys fs.FS, name string, tag language.Tag, ext string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	switch ext {
+	case ".po":
+		err = c.LoadPO(tag, f)
+	case ".xlf", ".xliff":
+		err = c.LoadXLIFF(tag, f)
+	case ".json":
+		err = c.LoadJSON(tag, f)
+	}
+	if err != nil {
+		return fmt.Errorf("%w (%s)", err, name)
+	}
+	return nil
+}
+
+// Sprintf formats the message for the key translated into the first of the context's locales that has a translation.
+// The arguments are substituted in the same way as [message.Printer.Sprintf].
+//
+// Sprintf is intended to be called from [guigui.Widget.Build]:
+//
+//	b.button.SetText(theCatalog.Sprintf(context, "Open"))
+func (c *Catalog) Sprintf(context *guigui.Context, key string, args ...any) string {
+	c.tmpLocales = context.AppendLocales(c.tmpLocales[:0])
+	return c.sprintf(c.tmpLocales, key, args...)
+}
+
+// Plural formats the plural message for the key as Sprintf(context, key, n, args...) does.
+// The plural form is selected by n.
+//
+// Use Plural rather than [Catalog.Sprintf] for a plural message, so that the guigui-i18n command extracts
+// the key as a plural message:
+//
+//	b.text.SetValue(theCatalog.Plural(context, "%d files selected", n))
+func (c *Catalog) Plural(context *guigui.Context, key string, n int, args ...any) string {
+	c.tmpLocales = context.AppendLocales(c.tmpLocales[:0])
+	return c.plural(c.tmpLocales, key, n, args...)
+}
+
+// Printer returns the printer for the first of the context's locales that the catalog has messages of.
+// Unlike [Catalog.Sprintf], a message that the language lacks is not looked up in the other locales.
+func (c *Catalog) Printer(context *guigui.Context) *message.Printer {
+	c.tmpLocales = context.AppendLocales(c.tmpLocales[:0])
+	return c.printer(c.languageFor(c.tmpLocales, ""))
+}
+
+func (c *Catalog) sprintf(locales []language.Tag, key string, args ...any) string {
+	return c.printer(c.languageFor(locales, key)).Sprintf(key, args...)
+}
+
+func (c *Catalog) plural(locales []language.Tag, key string, n int, args ...any) string {
+	return c.sprintf(locales, key, append([]any{n}, args...)...)
+}
+
+// languageFor returns the catalog language for the first of the locales that has the message for the key.
+// If key is empty, the catalog language for the first of the locales that the catalog has is returned.
+func (c *Catalog) languageFor(locales []language.Tag, key string) language.Tag {
+	if len(c.languages) == 0 {
+		return c.source
+	}
+	if c.matcher == nil {
+		c.matcher = language.NewMatcher(c.languages)
+	}
+	for _, locale := range locales {
+		_, i, conf := c.matcher.Match(locale)
+		if conf == language.No {
+			continue
+		}
+		if tag := c.languages[i]; key == "" || c.hasKey(tag, key) {
+			return tag
+		}
+	}
+	return c.source
+}
+
+func (c *Catalog) printer(tag language.Tag) *message.Printer {
+	if p, ok := c.printers[tag]; ok {
+		return p
+	}
+	p := message.NewPrinter(tag, message.Catalog(c.builder))
+	if c.printers == nil {
+		c.printers = map[language.Tag]*message.Printer{}
+	}
+	c.printers[tag] = p
+	return p
+}
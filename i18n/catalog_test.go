@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package i18n_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n"
+)
+
+const jaPO = `# Japanese translations.
+msgid ""
+msgstr ""
+"Language: ja\n"
+"Plural-Forms: nplurals=1; plural=0;\n"
+
+msgid "Open"
+msgstr "開く"
+
+#, fuzzy
+msgid "Close"
+msgstr "閉じる"
+
+msgctxt "menu"
+msgid "File"
+msgstr "ファイル"
+
+msgid "Save"
+msgstr ""
+
+msgid "%d file selected"
+msgid_plural "%d files selected"
+msgstr[0] "%d 個のファイルを選択"
+`
+
+const ruPO = `msgid "%d file selected"
+msgid_plural "%d files selected"
+msgstr[0] "Выбран %d файл"
+msgstr[1] "Выбрано %d "
+"файла"
+msgstr[2] "Выбрано %d файлов"
+`
+
+const frJSON = `{
+	"Open": "Ouvrir",
+	"Save": "Enregistrer",
+	"%d file selected": {
+		"=0": "Aucun fichier sélectionné",
+		"one": "%d fichier sélectionné",
+		"other": "%d fichiers sélectionnés"
+	}
+}`
+
+func newTestCatalog(t *testing.T) *i18n.Catalog {
+	t.Helper()
+	c := i18n.NewCatalog(language.English)
+	fsys := fstest.MapFS{
+		"ja.po":     {Data: []byte(jaPO)},
+		"ru.po":     {Data: []byte(ruPO)},
+		"fr.json":   {Data: []byte(frJSON)},
+		"README.md": {Data: []byte("Translations")},
+	}
+	if err := c.LoadFS(fsys); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSprintf(t *testing.T) {
+	c := newTestCatalog(t)
+	testCases := []struct {
+		locales []language.Tag
+		key     string
+		args    []any
+		want    string
+	}{
+		{
+			locales: []language.Tag{language.Japanese},
+			key:     "Open",
+			want:    "開く",
+		},
+		{
+			// A fuzzy message is not used.
+			locales: []language.Tag{language.Japanese},
+			key:     "Close",
+			want:    "Close",
+		},
+		{
+			// An untranslated message falls back to the next locale.
+			locales: []language.Tag{language.Japanese, language.French},
+			key:     "Save",
+			want:    "Enregistrer",
+		},
+		{
+			// A regional locale matches its language.
+			locales: []language.Tag{language.MustParse("fr-CA")},
+			key:     "Open",
+			want:    "Ouvrir",
+		},
+		{
+			locales: []language.Tag{language.German},
+			key:     "Open",
+			want:    "Open",
+		},
+		{
+			locales: []language.Tag{language.Japanese},
+			key:     "%d file selected",
+			args:    []any{3},
+			want:    "3 個のファイルを選択",
+		},
+		{
+			locales: []language.Tag{language.Russian},
+			key:     "%d file selected",
+			args:    []any{21},
+			want:    "Выбран 21 файл",
+		},
+		{
+			locales: []language.Tag{language.Russian},
+			key:     "%d file selected",
+			args:    []any{3},
+			want:    "Выбрано 3 файла",
+		},
+		{
+			locales: []language.Tag{language.Russian},
+			key:     "%d file selected",
+			args:    []any{5},
+			want:    "Выбрано 5 файлов",
+		},
+		{
+			locales: []language.Tag{language.French},
+			key:     "%d file selected",
+			args:    []any{0},
+			want:    "Aucun fichier sélectionné",
+		},
+		{
+			locales: []language.Tag{language.French},
+			key:     "%d file selected",
+			args:    []any{2},
+			want:    "2 fichiers sélectionnés",
+		},
+		{
+			// The source language has the plural forms of the PO files.
+			locales: []language.Tag{language.German},
+			key:     "%d file selected",
+			args:    []any{1},
+			want:    "1 file selected",
+		},
+		{
+			locales: []language.Tag{language.German},
+			key:     "%d file selected",
+			args:    []any{2},
+			want:    "2 files selected",
+		},
+	}
+	for _, tc := range testCases {
+		if got := c.SprintfForLocales(tc.locales, tc.key, tc.args...); got != tc.want {
+			t.Errorf("Sprintf(%v, %q, %v): got: %q, want: %q", tc.locales, tc.key, tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	c := newTestCatalog(t)
+
+	testCases := []struct {
+		locales []language.Tag
+		n       int
+		want    string
+	}{
+		{
+			locales: []language.Tag{language.Russian},
+			n:       3,
+			want:    "Выбрано 3 файла",
+		},
+		{
+			locales: []language.Tag{language.French},
+			n:       0,
+			want:    "Aucun fichier sélectionné",
+		},
+		{
+			locales: []language.Tag{language.German},
+			n:       1,
+			want:    "1 file selected",
+		},
+		{
+			locales: []language.Tag{language.German},
+			n:       2,
+			want:    "2 files selected",
+		},
+	}
+	for _, tc := range testCases {
+		if got := c.PluralForLocales(tc.locales, "%d file selected", tc.n); got != tc.want {
+			t.Errorf("Plural(%v, %d): got: %q, want: %q", tc.locales, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "ja.po",
+			data: "msgid \"Open\"\nmsgfoo \"開く\"\n",
+		},
+		{
+			name: "ja.po",
+			data: "\"Open\"\n",
+		},
+		{
+			name: "fr.json",
+			data: `{"Open": 1}`,
+		},
+		{
+			name: "fr.json",
+			data: `{"%d files": {"one": "%d fichier"}}`,
+		},
+	}
+	for _, tc := range testCases {
+		c := i18n.NewCatalog(language.English)
+		err := c.LoadFS(fstest.MapFS{tc.name: {Data: []byte(tc.data)}})
+		if err == nil {
+			t.Errorf("LoadFS(%s: %q): got: nil, want: an error", tc.name, tc.data)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "i18n: ") {
+			t.Errorf("LoadFS(%s: %q): got: %q, want: an error prefixed with i18n", tc.name, tc.data, err)
+		}
+	}
+}
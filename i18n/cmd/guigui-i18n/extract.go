@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+const (
+	i18nPath        = "github.com/guigui-gui/guigui/i18n"
+	basicwidgetPath = "github.com/guigui-gui/guigui/basicwidget"
+)
+
+// widgetTextMethods are the methods of the basicwidget types whose first argument is a text shown to the user.
+var widgetTextMethods = map[string][]string{
+	"Button":      {"SetText"},
+	"TooltipArea": {"SetText"},
+	"Text":        {"SetValue", "SetPlaceholder"},
+	"TextInput":   {"SetPlaceholder"},
+}
+
+// extractor collects the messages of packages.
+type extractor struct {
+	widgets bool
+	wd      string
+
+	messages map[string]*msgfile.Message
+}
+
+// extract returns the messages of the packages, sorted by their IDs.
+func extract(patterns []string, widgets bool) ([]msgfile.Message, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errors.New("failed to load the packages")
+	}
+
+	e := &extractor{
+		widgets:  widgets,
+		wd:       wd,
+		messages: map[string]*msgfile.Message{},
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CallExpr:
+					e.visitCall(pkg, n)
+				case *ast.CompositeLit:
+					e.visitCompositeLit(pkg, n)
+				}
+				return true
+			})
+		}
+	}
+
+	messages := make([]msgfile.Message, 0, len(e.messages))
+	for _, m := range e.messages {
+		messages = append(messages, *m)
+	}
+	slices.SortFunc(messages, func(a, b msgfile.Message) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return messages, nil
+}
+
+func (e *extractor) visitCall(pkg *packages.Package, call *ast.CallExpr) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	fn, ok := pkg.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+	typeName, ok := receiverTypeName(fn)
+	if !ok {
+		return
+	}
+
+	switch fn.Pkg().Path() {
+	case i18nPath:
+		if typeName != "Catalog" || (fn.Name() != "Sprintf" && fn.Name() != "Plural") || len(call.Args) < 2 {
+			return
+		}
+		key, ok := constantString(pkg, call.Args[1])
+		if !ok {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: the key of %s is not a constant string\n", e.position(pkg, call.Args[1].Pos()), fn.Name())
+			return
+		}
+		e.add(pkg, call.Args[1], key, fn.Name() == "Plural")
+
+	case basicwidgetPath:
+		if !e.widgets || len(call.Args) < 1 || !slices.Contains(widgetTextMethods[typeName], fn.Name()) {
+			return
+		}
+		if text, ok := constantString(pkg, call.Args[0]); ok {
+			e.add(pkg, call.Args[0], text, false)
+		}
+	}
+}
+
+func (e *extractor) visitCompositeLit(pkg *packages.Package, lit *ast.CompositeLit) {
+	if !e.widgets {
+		return
+	}
+	t := pkg.TypesInfo.TypeOf(lit)
+	if t == nil {
+		return
+	}
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return
+	}
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != basicwidgetPath || !strings.HasSuffix(obj.Name(), "Item") {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "Text" {
+			continue
+		}
+		if text, ok := constantString(pkg, kv.Value); ok {
+			e.add(pkg, kv.Value, text, false)
+		}
+	}
+}
+
+// receiverTypeName returns the name of the receiver type of a method.
+func receiverTypeName(fn *types.Func) (string, bool) {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return "", false
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return "", false
+	}
+	return named.Origin().Obj().Name(), true
+}
+
+// constantString returns the value of a non-empty constant string expression.
+func constantString(pkg *packages.Package, expr ast.Expr) (string, bool) {
+	tv, ok := pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	s := constant.StringVal(tv.Value)
+	if s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+// add adds the message of id extracted from expr.
+//
+// A plural message has the same msgid and msgid_plural, as the key of a call of Plural is
+// the only source string. The plural forms in the source language are given by a translation file
+// of the source language, as those in the other languages are.
+func (e *extractor) add(pkg *packages.Package, expr ast.Expr, id string, plural bool) {
+	m, ok := e.messages[id]
+	if !ok {
+		m = &msgfile.Message{
+			ID: id,
+		}
+		e.messages[id] = m
+	}
+	if plural {
+		m.IDPlural = id
+	}
+	m.References = append(m.References, e.position(pkg, expr.Pos()))
+}
+
+// position returns the position as a path relative to the working directory and a line number.
+func (e *extractor) position(pkg *packages.Package, pos token.Pos) string {
+	p := pkg.Fset.Position(pos)
+	path := p.Filename
+	if rel, err := filepath.Rel(e.wd, path); err == nil {
+		path = filepath.ToSlash(rel)
+	}
+	return path + ":" + strconv.Itoa(p.Line)
+}
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// guigui-i18n extracts the translatable messages from Go packages and updates the translation files
+// that the i18n package loads.
+//
+// Usage:
+//
+//	guigui-i18n [flags] [packages]
+//
+// The messages are the constant keys of the calls to (*i18n.Catalog).Sprintf and (*i18n.Catalog).Plural.
+// With -widgets, the constant texts given to basicwidget are extracted too:
+// the arguments of Button.SetText, TooltipArea.SetText, Text.SetValue and the SetPlaceholder methods,
+// and the Text fields of the item types like ListItem and MenubarItem.
+// The key of a call of Plural is a plural message, and the key of a call of Sprintf is not.
+// A plural message has the key as both its msgid and msgid_plural, since Plural takes only one source string.
+//
+// guigui-i18n writes a template, messages.pot or messages.xlf, into the directory of the translation files,
+// and updates the translation file of each locale there, such as ja.po or ja.xlf:
+// new messages are added as untranslated, and the messages no longer used are marked obsolete in a PO file
+// or removed from an XLIFF file. A message is reported as stale only by the run that makes it obsolete.
+// The number of the untranslated, fuzzy and stale messages of each locale
+// is reported with their keys.
+//
+// The flags are:
+//
+//	-dir string
+//		the directory of the translation files (default "locales")
+//	-format string
+//		the format of the translation files: po or xliff (default "po")
+//	-source string
+//		the source language of the messages (default "en")
+//	-locales string
+//		comma-separated locales to create the translation files of, in addition to the existing ones
+//	-widgets
+//		extract the texts given to basicwidget too
+//	-check
+//		report without writing the files, and exit with status 1 if any locale has an untranslated,
+//		fuzzy or stale message
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+var (
+	flagDir     = flag.String("dir", "locales", "the directory of the translation files")
+	flagFormat  = flag.String("format", "po", "the format of the translation files: po or xliff")
+	flagSource  = flag.String("source", "en", "the source language of the messages")
+	flagLocales = flag.String("locales", "", "comma-separated locales to create the translation files of")
+	flagWidgets = flag.Bool("widgets", false, "extract the texts given to basicwidget too")
+	flagCheck   = flag.Bool("check", false, "report without writing, and fail if any message needs work")
+)
+
+func main() {
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "usage: guigui-i18n [flags] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	ok, err := run(flag.Args())
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "guigui-i18n: %v\n", err)
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// run extracts the messages and updates the translation files.
+// run reports false when -check is specified and any locale needs work.
+func run(patterns []string) (bool, error) {
+	var ext string
+	switch *flagFormat {
+	case "po":
+		ext = ".po"
+	case "xliff":
+		ext = ".xlf"
+	default:
+		return false, fmt.Errorf("unknown format: %q", *flagFormat)
+	}
+	source, err := language.Parse(*flagSource)
+	if err != nil {
+		return false, err
+	}
+
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	messages, err := extract(patterns, *flagWidgets)
+	if err != nil {
+		return false, err
+	}
+
+	tags, err := localesInDir(*flagDir, ext)
+	if err != nil {
+		return false, err
+	}
+	if *flagLocales != "" {
+		for l := range strings.SplitSeq(*flagLocales, ",") {
+			tag, err := language.Parse(strings.TrimSpace(l))
+			if err != nil {
+				return false, err
+			}
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	if !*flagCheck {
+		if err := os.MkdirAll(*flagDir, 0755); err != nil {
+			return false, err
+		}
+		template := &msgfile.File{
+			Header:         "MIME-Version: 1.0\nContent-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit\n",
+			SourceLanguage: source.String(),
+			Messages:       templateMessages(messages),
+		}
+		if err := writeFile(filepath.Join(*flagDir, "messages"+templateExt(ext)), template); err != nil {
+			return false, err
+		}
+	}
+
+	ok := true
+	for _, tag := range tags {
+		name := tag.String() + ext
+		path := filepath.Join(*flagDir, name)
+		old, err := readFile(path)
+		if err != nil {
+			return false, err
+		}
+		if old == nil {
+			old = &msgfile.File{
+				Header: "Language: " + tag.String() + "\nMIME-Version: 1.0\nContent-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit\n",
+			}
+		}
+		f, r := merge(old, messages, tag)
+		f.SourceLanguage = source.String()
+		f.TargetLanguage = tag.String()
+		r.print(os.Stdout, name)
+		if *flagCheck {
+			if r.needsWork() {
+				ok = false
+			}
+			continue
+		}
+		if err := writeFile(path, f); err != nil {
+			return false, err
+		}
+	}
+	return ok, nil
+}
+
+func templateExt(ext string) string {
+	if ext == ".po" {
+		return ".pot"
+	}
+	return ext
+}
+
+// localesInDir returns the locales of the translation files with the extension in the directory.
+func localesInDir(dir string, ext string) ([]language.Tag, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tags []language.Tag
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ext {
+			continue
+		}
+		tag, err := language.Parse(strings.TrimSuffix(name, ext))
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// readFile reads a translation file. If the file does not exist, readFile returns nil.
+func readFile(path string) (*msgfile.File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var f *msgfile.File
+	if filepath.Ext(path) == ".po" {
+		f, err = msgfile.ParsePO(bytes.NewReader(data))
+	} else {
+		f, err = msgfile.ParseXLIFF(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// writeFile writes a translation file in the format of its extension.
+func writeFile(path string, f *msgfile.File) error {
+	var buf bytes.Buffer
+	switch filepath.Ext(path) {
+	case ".po", ".pot":
+		if err := msgfile.WritePO(&buf, f); err != nil {
+			return err
+		}
+	default:
+		if err := msgfile.WriteXLIFF(&buf, f); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
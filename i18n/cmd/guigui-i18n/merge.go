@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+// report is the state of the messages of a translation file.
+type report struct {
+	messages     int
+	untranslated []string
+	fuzzy        []string
+	stale        []string
+}
+
+func (r *report) needsWork() bool {
+	return len(r.untranslated) > 0 || len(r.fuzzy) > 0 || len(r.stale) > 0
+}
+
+func (r *report) print(w io.Writer, name string) {
+	_, _ = fmt.Fprintf(w, "%s: %d messages, %d untranslated, %d fuzzy, %d stale\n", name, r.messages, len(r.untranslated), len(r.fuzzy), len(r.stale))
+	for _, id := range r.untranslated {
+		_, _ = fmt.Fprintf(w, "\tuntranslated: %q\n", id)
+	}
+	for _, id := range r.fuzzy {
+		_, _ = fmt.Fprintf(w, "\tfuzzy: %q\n", id)
+	}
+	for _, id := range r.stale {
+		_, _ = fmt.Fprintf(w, "\tstale: %q\n", id)
+	}
+}
+
+// templateMessages returns the extracted messages with empty translations for a template.
+func templateMessages(extracted []msgfile.Message) []msgfile.Message {
+	messages := make([]msgfile.Message, 0, len(extracted))
+	for _, m := range extracted {
+		m.Strs = []string{""}
+		if m.IDPlural != "" {
+			m.Strs = []string{"", ""}
+		}
+		messages = append(messages, m)
+	}
+	return messages
+}
+
+// merge returns the translation file of the language updated with the extracted messages.
+//
+// The translations of the messages still extracted are kept. A translation whose message has changed
+// its plural form is marked fuzzy. The messages no longer extracted are marked obsolete,
+// and the obsolete ones without translations are removed.
+// Only the messages made obsolete by this merge are reported as stale; the ones already obsolete are kept
+// without being reported, so that a removed message does not need work on every run.
+// The messages with a msgctxt, which guigui-i18n never extracts, are kept as they are.
+func merge(old *msgfile.File, extracted []msgfile.Message, tag language.Tag) (*msgfile.File, *report) {
+	f := &msgfile.File{
+		Header: old.Header,
+	}
+	var r report
+
+	oldMessages := map[string]*msgfile.Message{}
+	for i := range old.Messages {
+		if m := &old.Messages[i]; !m.HasContext {
+			oldMessages[m.ID] = m
+		}
+	}
+	nplurals := len(msgfile.IntegerPluralForms(tag))
+
+	used := map[string]struct{}{}
+	for _, e := range extracted {
+		used[e.ID] = struct{}{}
+		m := msgfile.Message{
+			ID:         e.ID,
+			IDPlural:   e.IDPlural,
+			References: e.References,
+		}
+		n := 1
+		if m.IDPlural != "" {
+			n = nplurals
+		}
+		m.Strs = make([]string, n)
+		if o, ok := oldMessages[e.ID]; ok {
+			m.Comments = o.Comments
+			m.Fuzzy = o.Fuzzy
+			copy(m.Strs, o.Strs)
+			if (o.IDPlural == "") != (m.IDPlural == "") && len(o.Strs) > 0 && o.Strs[0] != "" {
+				m.Fuzzy = true
+			}
+		}
+		f.Messages = append(f.Messages, m)
+
+		r.messages++
+		switch {
+		case m.Translated():
+		case m.Fuzzy:
+			r.fuzzy = append(r.fuzzy, m.ID)
+		default:
+			r.untranslated = append(r.untranslated, m.ID)
+		}
+	}
+
+	for _, o := range old.Messages {
+		if o.HasContext {
+			f.Messages = append(f.Messages, o)
+			continue
+		}
+		if _, ok := used[o.ID]; ok {
+			continue
+		}
+		if !o.Translated() && !o.Fuzzy {
+			continue
+		}
+		if !o.Obsolete {
+			r.stale = append(r.stale, o.ID)
+		}
+		o.Obsolete = true
+		o.References = nil
+		f.Messages = append(f.Messages, o)
+	}
+	return f, &r
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+func TestMerge(t *testing.T) {
+	old := &msgfile.File{
+		Header: "Language: ru\n",
+		Messages: []msgfile.Message{
+			{
+				ID:         "Open",
+				Strs:       []string{"Открыть"},
+				Comments:   []string{"A button."},
+				References: []string{"old.go:1"},
+			},
+			{
+				ID:   "Save",
+				Strs: []string{"Сохранить"},
+			},
+			{
+				ID:   "Close",
+				Strs: []string{""},
+			},
+			{
+				ID:   "%d file",
+				Strs: []string{"%d файл"},
+			},
+			{
+				ID:       "Quit",
+				Strs:     []string{"Выйти"},
+				Obsolete: true,
+			},
+		},
+	}
+	extracted := []msgfile.Message{
+		{
+			ID:         "%d file",
+			IDPlural:   "%d file",
+			References: []string{"files.go:5"},
+		},
+		{
+			ID:         "Edit",
+			References: []string{"main.go:3"},
+		},
+		{
+			ID:         "Open",
+			References: []string{"main.go:2"},
+		},
+	}
+
+	got, r := merge(old, extracted, language.Russian)
+	want := &msgfile.File{
+		Header: "Language: ru\n",
+		Messages: []msgfile.Message{
+			{
+				ID:         "%d file",
+				IDPlural:   "%d file",
+				Strs:       []string{"%d файл", "", ""},
+				References: []string{"files.go:5"},
+				Fuzzy:      true,
+			},
+			{
+				ID:         "Edit",
+				Strs:       []string{""},
+				References: []string{"main.go:3"},
+			},
+			{
+				ID:         "Open",
+				Strs:       []string{"Открыть"},
+				Comments:   []string{"A button."},
+				References: []string{"main.go:2"},
+			},
+			{
+				ID:       "Save",
+				Strs:     []string{"Сохранить"},
+				Obsolete: true,
+			},
+			{
+				ID:       "Quit",
+				Strs:     []string{"Выйти"},
+				Obsolete: true,
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("merge: got: %+v, want: %+v", got, want)
+	}
+
+	wantReport := &report{
+		messages:     3,
+		untranslated: []string{"Edit"},
+		fuzzy:        []string{"%d file"},
+		stale:        []string{"Save"},
+	}
+	if !reflect.DeepEqual(r, wantReport) {
+		t.Errorf("merge report: got: %+v, want: %+v", r, wantReport)
+	}
+}
+
+func TestMergeObsolete(t *testing.T) {
+	old := &msgfile.File{
+		Messages: []msgfile.Message{
+			{
+				ID:   "Open",
+				Strs: []string{"Ouvrir"},
+			},
+			{
+				ID:       "Quit",
+				Strs:     []string{"Quitter"},
+				Obsolete: true,
+			},
+		},
+	}
+	extracted := []msgfile.Message{
+		{
+			ID: "Open",
+		},
+	}
+
+	// A message already obsolete is kept, but is not stale again.
+	got, r := merge(old, extracted, language.French)
+	if r.needsWork() {
+		t.Errorf("merge report: got: %+v, want: no work", r)
+	}
+	if got, want := len(got.Messages), 2; got != want {
+		t.Errorf("len(Messages): got: %d, want: %d", got, want)
+	}
+}
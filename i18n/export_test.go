@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+func (c *Catalog) SprintfForLocales(locales []language.Tag, key string, args ...any) string {
+	return c.sprintf(locales, key, args...)
+}
+
+func (c *Catalog) PluralForLocales(locales []language.Tag, key string, n int, args ...any) string {
+	return c.plural(locales, key, n, args...)
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package msgfile reads and writes translation files: gettext PO files and XLIFF 1.2 files.
+package msgfile
+
+import (
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// File is a translation file.
+type File struct {
+	// Header is the header of a PO file, which is the translation of the empty message.
+	Header string
+
+	// SourceLanguage and TargetLanguage are the languages of an XLIFF file.
+	SourceLanguage string
+	TargetLanguage string
+
+	Messages []Message
+}
+
+// Message is a message of a translation file.
+type Message struct {
+	// Context is the msgctxt of a PO file. HasContext reports whether the message has a msgctxt.
+	Context    string
+	HasContext bool
+
+	// ID is the message key, and IDPlural is the plural form of the key for a plural message.
+	ID       string
+	IDPlural string
+
+	// Strs are the translations. A singular message has one, and a plural message has one for each plural form.
+	Strs []string
+
+	// Comments are the translator comments.
+	Comments []string
+
+	// References are the source locations of the message, such as "main.go:12".
+	References []string
+
+	// Fuzzy reports whether the translation needs a review.
+	Fuzzy bool
+
+	// Obsolete reports whether the message is no longer used in the sources.
+	Obsolete bool
+}
+
+// Translated reports whether the message has all the translations and does not need a review.
+func (m *Message) Translated() bool {
+	if m.Fuzzy || len(m.Strs) == 0 {
+		return false
+	}
+	for _, s := range m.Strs {
+		if s == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// IntegerPluralForms returns the CLDR plural categories that integers take in the language,
+// in the order of the plural forms of gettext: zero, one, two, few, many and other.
+// The number of the categories is the number of the plural forms of a message in the language.
+func IntegerPluralForms(tag language.Tag) []plural.Form {
+	var found [plural.Many + 1]bool
+	for i := range 1000 {
+		found[plural.Cardinal.MatchPlural(tag, i, 0, 0, 0, 0)] = true
+	}
+	var forms []plural.Form
+	for form := plural.Zero; form <= plural.Many; form++ {
+		if found[form] {
+			forms = append(forms, form)
+		}
+	}
+	if found[plural.Other] {
+		forms = append(forms, plural.Other)
+	}
+	return forms
+}
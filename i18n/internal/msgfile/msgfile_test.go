@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package msgfile_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+const testPO = `msgid ""
+msgstr ""
+"Language: ja\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+
+# A button.
+#: main.go:10 main.go:20
+msgid "Open"
+msgstr "開く"
+
+#, fuzzy
+msgid ""
+"Line 1\n"
+"Line 2"
+msgstr ""
+"1 行目\n"
+"2 行目"
+
+#: files.go:5
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d 個のファイル"
+
+#~ msgid "Close"
+#~ msgstr "閉じる"
+`
+
+func testFile() *msgfile.File {
+	return &msgfile.File{
+		Header: "Language: ja\nContent-Type: text/plain; charset=UTF-8\n",
+		Messages: []msgfile.Message{
+			{
+				ID:         "Open",
+				Strs:       []string{"開く"},
+				Comments:   []string{"A button."},
+				References: []string{"main.go:10", "main.go:20"},
+			},
+			{
+				ID:    "Line 1\nLine 2",
+				Strs:  []string{"1 行目\n2 行目"},
+				Fuzzy: true,
+			},
+			{
+				ID:         "%d file",
+				IDPlural:   "%d files",
+				Strs:       []string{"%d 個のファイル"},
+				References: []string{"files.go:5"},
+			},
+			{
+				ID:       "Close",
+				Strs:     []string{"閉じる"},
+				Obsolete: true,
+			},
+		},
+	}
+}
+
+func TestParsePO(t *testing.T) {
+	got, err := msgfile.ParsePO(strings.NewReader(testPO))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := testFile(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePO: got: %+v, want: %+v", got, want)
+	}
+}
+
+func TestWritePO(t *testing.T) {
+	var buf bytes.Buffer
+	if err := msgfile.WritePO(&buf, testFile()); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), testPO; got != want {
+		t.Errorf("WritePO: got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParsePOInvalid(t *testing.T) {
+	for _, src := range []string{
+		"msgid \"Open\"\nmsgfoo \"開く\"\n",
+		"\"Open\"\n",
+		"msgid Open\n",
+		"msgid \"Open\\u0041\"\n",
+	} {
+		if _, err := msgfile.ParsePO(strings.NewReader(src)); err == nil {
+			t.Errorf("ParsePO(%q): got: nil, want: an error", src)
+		}
+	}
+}
+
+func TestXLIFFRoundTrip(t *testing.T) {
+	f := testFile()
+	f.Header = ""
+	f.SourceLanguage = "en"
+	f.Messages[2].Strs = []string{"%d file", "%d files"}
+	f.TargetLanguage = "en-GB"
+
+	var buf bytes.Buffer
+	if err := msgfile.WriteXLIFF(&buf, f); err != nil {
+		t.Fatal(err)
+	}
+	got, err := msgfile.ParseXLIFF(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An obsolete message is not written.
+	want := *f
+	want.Messages = want.Messages[:3]
+	if !reflect.DeepEqual(got, &want) {
+		t.Errorf("XLIFF round trip: got: %+v, want: %+v", got, &want)
+	}
+}
+
+func TestIntegerPluralForms(t *testing.T) {
+	testCases := []struct {
+		tag  language.Tag
+		want []plural.Form
+	}{
+		{
+			tag:  language.English,
+			want: []plural.Form{plural.One, plural.Other},
+		},
+		{
+			tag:  language.Japanese,
+			want: []plural.Form{plural.Other},
+		},
+		{
+			tag:  language.Russian,
+			want: []plural.Form{plural.One, plural.Few, plural.Many},
+		},
+		{
+			tag:  language.Arabic,
+			want: []plural.Form{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other},
+		},
+	}
+	for _, tc := range testCases {
+		if got := msgfile.IntegerPluralForms(tc.tag); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("IntegerPluralForms(%v): got: %v, want: %v", tc.tag, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package msgfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParsePO parses a gettext PO file.
+func ParsePO(r io.Reader) (*File, error) {
+	var f File
+	var m Message
+	// field is the string that a continued string line is appended to.
+	var field *string
+	flush := func() {
+		if m.ID == "" && !m.HasContext && len(m.Strs) > 0 && f.Header == "" && len(f.Messages) == 0 {
+			f.Header = m.Strs[0]
+		} else if m.ID != "" || len(m.Strs) > 0 {
+			f.Messages = append(f.Messages, m)
+		}
+		m = Message{}
+		field = nil
+	}
+
+	s := bufio.NewScanner(r)
+	var lineNum int
+	for s.Scan() {
+		lineNum++
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+
+		// An obsolete message is commented out with #~.
+		obsolete := false
+		if rest, ok := strings.CutPrefix(line, "#~"); ok {
+			line = strings.TrimSpace(rest)
+			obsolete = true
+		} else if strings.HasPrefix(line, "#") {
+			// A comment starts the next message.
+			if len(m.Strs) > 0 {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#,"):
+				for flag := range strings.SplitSeq(line[2:], ",") {
+					if strings.TrimSpace(flag) == "fuzzy" {
+						m.Fuzzy = true
+					}
+				}
+			case strings.HasPrefix(line, "#:"):
+				m.References = append(m.References, strings.Fields(line[2:])...)
+			case line == "#" || strings.HasPrefix(line, "# "):
+				m.Comments = append(m.Comments, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, `"`) {
+			if field == nil {
+				return nil, fmt.Errorf("line %d: unexpected string", lineNum)
+			}
+			str, err := unquotePO(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			*field += str
+			continue
+		}
+
+		keyword, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing string: %q", lineNum, line)
+		}
+		str, err := unquotePO(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		switch {
+		case keyword == "msgctxt":
+			if len(m.Strs) > 0 {
+				flush()
+			}
+			m.Context = str
+			m.HasContext = true
+			field = &m.Context
+		case keyword == "msgid":
+			if len(m.Strs) > 0 {
+				flush()
+			}
+			m.ID = str
+			field = &m.ID
+		case keyword == "msgid_plural":
+			m.IDPlural = str
+			field = &m.IDPlural
+		case keyword == "msgstr":
+			m.Strs = append(m.Strs[:0], str)
+			field = &m.Strs[0]
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			n, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: invalid keyword: %q", lineNum, keyword)
+			}
+			for len(m.Strs) <= n {
+				m.Strs = append(m.Strs, "")
+			}
+			m.Strs[n] = str
+			field = &m.Strs[n]
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword: %q", lineNum, keyword)
+		}
+		if obsolete {
+			m.Obsolete = true
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return &f, nil
+}
+
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string: %s", s)
+	}
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i >= len(s)-1 {
+			return "", fmt.Errorf("invalid string: %s", s)
+		}
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			return "", fmt.Errorf("invalid escape sequence in %s", s)
+		}
+	}
+	return b.String(), nil
+}
+
+// WritePO writes the file in the gettext PO format.
+func WritePO(w io.Writer, f *File) error {
+	bw := bufio.NewWriter(w)
+	if f.Header != "" {
+		writePOString(bw, "", "msgid", "")
+		writePOString(bw, "", "msgstr", f.Header)
+	}
+	for i, m := range f.Messages {
+		if i > 0 || f.Header != "" {
+			_, _ = bw.WriteString("\n")
+		}
+		for _, c := range m.Comments {
+			_, _ = fmt.Fprintf(bw, "# %s\n", c)
+		}
+		if len(m.References) > 0 && !m.Obsolete {
+			_, _ = fmt.Fprintf(bw, "#: %s\n", strings.Join(m.References, " "))
+		}
+		if m.Fuzzy {
+			_, _ = bw.WriteString("#, fuzzy\n")
+		}
+		var prefix string
+		if m.Obsolete {
+			prefix = "#~ "
+		}
+		if m.HasContext {
+			writePOString(bw, prefix, "msgctxt", m.Context)
+		}
+		writePOString(bw, prefix, "msgid", m.ID)
+		if m.IDPlural != "" {
+			writePOString(bw, prefix, "msgid_plural", m.IDPlural)
+			for i, s := range m.Strs {
+				writePOString(bw, prefix, fmt.Sprintf("msgstr[%d]", i), s)
+			}
+			continue
+		}
+		var str string
+		if len(m.Strs) > 0 {
+			str = m.Strs[0]
+		}
+		writePOString(bw, prefix, "msgstr", str)
+	}
+	return bw.Flush()
+}
+
+// writePOString writes a keyword and its string. A string with line breaks is written one line each.
+func writePOString(w *bufio.Writer, prefix, keyword, str string) {
+	lines := strings.SplitAfter(str, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		_, _ = fmt.Fprintf(w, "%s%s %s\n", prefix, keyword, quotePO(str))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s%s \"\"\n", prefix, keyword)
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(w, "%s%s\n", prefix, quotePO(line))
+	}
+}
+
+func quotePO(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
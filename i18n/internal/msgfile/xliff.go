@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package msgfile
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// pluralGroupType is the restype of an XLIFF group of the plural forms of a message,
+// which is the convention of the Translate Toolkit.
+const pluralGroupType = "x-gettext-plurals"
+
+// stateNeedsReview is the state of an XLIFF target for a fuzzy translation.
+const stateNeedsReview = "needs-review-translation"
+
+type xliffDocument struct {
+	XMLName xml.Name  `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string    `xml:"version,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	SourceLanguage string    `xml:"source-language,attr"`
+	TargetLanguage string    `xml:"target-language,attr,omitempty"`
+	Datatype       string    `xml:"datatype,attr"`
+	Original       string    `xml:"original,attr"`
+	Body           xliffBody `xml:"body"`
+}
+
+type xliffBody struct {
+	Units  []xliffUnit  `xml:"trans-unit"`
+	Groups []xliffGroup `xml:"group"`
+}
+
+type xliffGroup struct {
+	ID      string      `xml:"id,attr"`
+	ResType string      `xml:"restype,attr,omitempty"`
+	Units   []xliffUnit `xml:"trans-unit"`
+}
+
+type xliffUnit struct {
+	ID     string       `xml:"id,attr"`
+	Source string       `xml:"source"`
+	Target *xliffTarget `xml:"target"`
+	Notes  []xliffNote  `xml:"note"`
+}
+
+type xliffTarget struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+type xliffNote struct {
+	From string `xml:"from,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+// noteFromDeveloper is the from attribute of a note of a source location.
+const noteFromDeveloper = "developer"
+
+// ParseXLIFF parses an XLIFF 1.2 file.
+//
+// The ID of a message is the id attribute of its trans-unit.
+// A group whose restype is x-gettext-plurals is a plural message.
+// If the group has only one trans-unit, the plural form of the ID is the same as the ID.
+func ParseXLIFF(r io.Reader) (*File, error) {
+	var doc xliffDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	f := &File{
+		SourceLanguage: doc.File.SourceLanguage,
+		TargetLanguage: doc.File.TargetLanguage,
+	}
+	for _, u := range doc.File.Body.Units {
+		m := Message{
+			ID: u.ID,
+		}
+		m.addUnit(&u)
+		f.Messages = append(f.Messages, m)
+	}
+	for _, g := range doc.File.Body.Groups {
+		if g.ResType != pluralGroupType {
+			for _, u := range g.Units {
+				m := Message{
+					ID: u.ID,
+				}
+				m.addUnit(&u)
+				f.Messages = append(f.Messages, m)
+			}
+			continue
+		}
+		if len(g.Units) == 0 {
+			continue
+		}
+		// A language with a single plural form has no unit of msgid_plural.
+		m := Message{
+			ID:       g.ID,
+			IDPlural: g.Units[0].Source,
+		}
+		if len(g.Units) > 1 {
+			m.IDPlural = g.Units[1].Source
+		}
+		for _, u := range g.Units {
+			m.addUnit(&u)
+		}
+		f.Messages = append(f.Messages, m)
+	}
+	return f, nil
+}
+
+func (m *Message) addUnit(u *xliffUnit) {
+	var str string
+	if u.Target != nil {
+		str = u.Target.Text
+		if u.Target.State == stateNeedsReview {
+			m.Fuzzy = true
+		}
+	}
+	m.Strs = append(m.Strs, str)
+	for _, n := range u.Notes {
+		if n.From == noteFromDeveloper {
+			if !slices.Contains(m.References, n.Text) {
+				m.References = append(m.References, n.Text)
+			}
+			continue
+		}
+		if !slices.Contains(m.Comments, n.Text) {
+			m.Comments = append(m.Comments, n.Text)
+		}
+	}
+}
+
+// WriteXLIFF writes the file in the XLIFF 1.2 format.
+//
+// A plural message is written as a group of a trans-unit for each plural form,
+// whose id is the ID of the message followed by the index of the form like "[0]".
+// Obsolete messages and the contexts of messages are not written, as XLIFF has no place for them.
+func WriteXLIFF(w io.Writer, f *File) error {
+	doc := xliffDocument{
+		Version: "1.2",
+		File: xliffFile{
+			SourceLanguage: f.SourceLanguage,
+			TargetLanguage: f.TargetLanguage,
+			Datatype:       "plaintext",
+			Original:       "messages",
+		},
+	}
+	for _, m := range f.Messages {
+		if m.Obsolete {
+			continue
+		}
+		if m.IDPlural == "" {
+			var str string
+			if len(m.Strs) > 0 {
+				str = m.Strs[0]
+			}
+			doc.File.Body.Units = append(doc.File.Body.Units, m.unit(m.ID, m.ID, str, f.TargetLanguage != ""))
+			continue
+		}
+		g := xliffGroup{
+			ID:      m.ID,
+			ResType: pluralGroupType,
+		}
+		for i, str := range m.Strs {
+			source := m.IDPlural
+			if i == 0 {
+				source = m.ID
+			}
+			u := m.unit(fmt.Sprintf("%s[%d]", m.ID, i), source, str, f.TargetLanguage != "")
+			// The notes are written once for the group.
+			if i > 0 {
+				u.Notes = nil
+			}
+			g.Units = append(g.Units, u)
+		}
+		doc.File.Body.Groups = append(doc.File.Body.Groups, g)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(&doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// unit returns a trans-unit of the message. If hasTarget is false, the unit is of a template without a target.
+func (m *Message) unit(id, source, target string, hasTarget bool) xliffUnit {
+	u := xliffUnit{
+		ID:     id,
+		Source: source,
+	}
+	if hasTarget {
+		u.Target = &xliffTarget{
+			Text: target,
+		}
+		if m.Fuzzy {
+			u.Target.State = stateNeedsReview
+		}
+	}
+	for _, c := range m.Comments {
+		u.Notes = append(u.Notes, xliffNote{Text: c})
+	}
+	for _, r := range m.References {
+		u.Notes = append(u.Notes, xliffNote{From: noteFromDeveloper, Text: r})
+	}
+	return u
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+// LoadJSON loads the messages in the language from a JSON file.
+func (c *Catalog) LoadJSON(tag language.Tag, r io.Reader) error {
+	var messages map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	// Set the messages in a stable order so that an error is reproducible.
+	for _, key := range slices.Sorted(maps.Keys(messages)) {
+		raw := messages[key]
+		var str string
+		if err := json.Unmarshal(raw, &str); err == nil {
+			if err := c.SetString(tag, key, str); err != nil {
+				return err
+			}
+			continue
+		}
+		var forms map[string]string
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return fmt.Errorf("i18n: message %q must be a string or an object of plural forms", key)
+		}
+		if err := c.SetPlural(tag, key, forms); err != nil {
+			return err
+		}
+	}
+	return nil
+}
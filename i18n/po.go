@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package i18n
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+// LoadPO loads the messages in the language from a gettext PO file.
+//
+// A plural message is also set in the source language with msgid and msgid_plural,
+// unless the source language already has the message.
+func (c *Catalog) LoadPO(tag language.Tag, r io.Reader) error {
+	f, err := msgfile.ParsePO(r)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	return c.loadMessages(tag, f.Messages)
+}
+
+func (c *Catalog) loadMessages(tag language.Tag, messages []msgfile.Message) error {
+	for _, m := range messages {
+		if m.ID == "" || m.HasContext || m.Obsolete || !m.Translated() {
+			continue
+		}
+		if m.IDPlural == "" {
+			if err := c.SetString(tag, m.ID, m.Strs[0]); err != nil {
+				return err
+			}
+			continue
+		}
+		if !c.hasKey(c.source, m.ID) {
+			if err := c.setPluralByIndex(c.source, m.ID, []string{m.ID, m.IDPlural}); err != nil {
+				return err
+			}
+		}
+		if err := c.setPluralByIndex(tag, m.ID, m.Strs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package i18n
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/text/language"
+
+	"github.com/guigui-gui/guigui/i18n/internal/msgfile"
+)
+
+// LoadXLIFF loads the messages in the language from an XLIFF 1.2 file.
+//
+// A plural message is also set in the source language in the same way as [Catalog.LoadPO].
+func (c *Catalog) LoadXLIFF(tag language.Tag, r io.Reader) error {
+	f, err := msgfile.ParseXLIFF(r)
+	if err != nil {
+		return fmt.Errorf("i18n: %w", err)
+	}
+	return c.loadMessages(tag, f.Messages)
+}
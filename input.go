@@ -5,7 +5,8 @@ package guigui
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/guigui-gui/guigui/internal/inputsource"
 )
 
 type inputState struct {
@@ -28,15 +29,15 @@ func (s *inputState) update() {
 	s.prevCursorX = s.cursorX
 	s.prevCursorY = s.cursorY
 
-	s.anyMousePressed = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) ||
-		ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) ||
-		ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle)
-	s.touchIDs = ebiten.AppendTouchIDs(s.touchIDs[:0])
+	s.anyMousePressed = inputsource.IsMouseButtonPressed(ebiten.MouseButtonLeft) ||
+		inputsource.IsMouseButtonPressed(ebiten.MouseButtonRight) ||
+		inputsource.IsMouseButtonPressed(ebiten.MouseButtonMiddle)
+	s.touchIDs = inputsource.AppendTouchIDs(s.touchIDs[:0])
 	s.anyTouch = len(s.touchIDs) > 0
-	s.wheelX, s.wheelY = ebiten.Wheel()
-	s.cursorX, s.cursorY = ebiten.CursorPosition()
-	s.pressedKeys = inpututil.AppendPressedKeys(s.pressedKeys[:0])
-	s.justReleasedKeys = inpututil.AppendJustReleasedKeys(s.justReleasedKeys[:0])
+	s.wheelX, s.wheelY = inputsource.Wheel()
+	s.cursorX, s.cursorY = inputsource.CursorPosition()
+	s.pressedKeys = inputsource.AppendPressedKeys(s.pressedKeys[:0])
+	s.justReleasedKeys = inputsource.AppendJustReleasedKeys(s.justReleasedKeys[:0])
 }
 
 func (s *inputState) isButtonActive() bool {
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package inputrecord records the input an application receives and replays it.
+//
+// A Guigui application records its input when the environment variable GUIGUI_DEBUG includes record=<file>.
+// The recording has the input of each tick: the pressed keys and mouse buttons, the cursor position,
+// the wheel, the touches, the typed characters, the texts committed by an input method (IME), and the window size.
+// Only the changes are written, one line per change, so an idle application writes nothing.
+//
+// A Guigui application replays a recording in itself when GUIGUI_DEBUG includes replay=<file>.
+// The recorded input replaces the input Guigui and its widget packages read, tick by tick from the first tick.
+//
+// A [Player] also replays a recording tick by tick on an Ebitengine application running as a virtualization guest
+// of Ebitengine's exp/vmhost package, for example through the Ebitengine widget of the ebitenginewidget package.
+// As the guest is advanced tick by tick by the host, the replay is deterministic even when the host is slow.
+//
+// Positions and sizes are in device-independent pixels, which are the guest's outside coordinates.
+//
+// An IME commit is recorded as its text, and replayed as typed characters, as a replay has no IME.
+// The intermediate compositions are not recorded, nor the surrounding text that an IME replaces.
+// A commit is seen after the input of its tick is recorded, so it is recorded at the next tick.
+package inputrecord
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// header is the first line of a recording.
+const header = "guigui-input-recording 1"
+
+const (
+	opSize      = "size"
+	opCursor    = "cursor"
+	opKeyDown   = "keydown"
+	opKeyUp     = "keyup"
+	opMouseDown = "mousedown"
+	opMouseUp   = "mouseup"
+	opWheel     = "wheel"
+	opTouchDown = "touchdown"
+	opTouchMove = "touchmove"
+	opTouchUp   = "touchup"
+	opChars     = "chars"
+	opIMECommit = "imecommit"
+)
+
+// State is the input state in a tick.
+type State struct {
+	// Width and Height are the window's outside size.
+	Width  float64
+	Height float64
+
+	// CursorX and CursorY are the cursor position.
+	CursorX float64
+	CursorY float64
+
+	// Keys are the pressed keys.
+	Keys []ebiten.Key
+
+	// MouseButtons are the pressed mouse buttons.
+	MouseButtons []ebiten.MouseButton
+
+	// WheelX and WheelY are the wheel movement in the tick.
+	WheelX float64
+	WheelY float64
+
+	// Touches are the current touches.
+	Touches []Touch
+
+	// Chars are the characters typed in the tick.
+	Chars []rune
+
+	// IMECommits are the texts committed by an IME since the previous tick.
+	IMECommits []string
+}
+
+// Touch is a touch in a tick.
+type Touch struct {
+	ID ebiten.TouchID
+	X  float64
+	Y  float64
+}
+
+// Reset resets the state to the zero value, keeping the allocated slices.
+func (s *State) Reset() {
+	*s = State{
+		Keys:         s.Keys[:0],
+		MouseButtons: s.MouseButtons[:0],
+		Touches:      s.Touches[:0],
+		Chars:        s.Chars[:0],
+		IMECommits:   s.IMECommits[:0],
+	}
+}
+
+// Recorder writes the changes of input states to a recording.
+type Recorder struct {
+	w    *bufio.Writer
+	prev State
+	err  error
+}
+
+// NewRecorder creates a Recorder writing to w.
+// The caller must call [Recorder.Flush] to write the buffered data.
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{
+		w: bufio.NewWriter(w),
+	}
+	r.printf("%s\n", header)
+	return r
+}
+
+// Record records the input state at the tick.
+// Ticks must be recorded in ascending order.
+func (r *Recorder) Record(tick int64, state *State) error {
+	if r.err != nil {
+		return r.err
+	}
+	p := &r.prev
+
+	if state.Width != p.Width || state.Height != p.Height {
+		r.printf("%d %s %s %s\n", tick, opSize, formatFloat(state.Width), formatFloat(state.Height))
+	}
+	if state.CursorX != p.CursorX || state.CursorY != p.CursorY {
+		r.printf("%d %s %s %s\n", tick, opCursor, formatFloat(state.CursorX), formatFloat(state.CursorY))
+	}
+	for _, k := range p.Keys {
+		if !slices.Contains(state.Keys, k) {
+			r.printf("%d %s %d\n", tick, opKeyUp, k)
+		}
+	}
+	for _, k := range state.Keys {
+		if !slices.Contains(p.Keys, k) {
+			r.printf("%d %s %d\n", tick, opKeyDown, k)
+		}
+	}
+	for _, b := range p.MouseButtons {
+		if !slices.Contains(state.MouseButtons, b) {
+			r.printf("%d %s %d\n", tick, opMouseUp, b)
+		}
+	}
+	for _, b := range state.MouseButtons {
+		if !slices.Contains(p.MouseButtons, b) {
+			r.printf("%d %s %d\n", tick, opMouseDown, b)
+		}
+	}
+	if state.WheelX != 0 || state.WheelY != 0 {
+		r.printf("%d %s %s %s\n", tick, opWheel, formatFloat(state.WheelX), formatFloat(state.WheelY))
+	}
+	for _, t := range p.Touches {
+		if !slices.ContainsFunc(state.Touches, func(t2 Touch) bool { return t2.ID == t.ID }) {
+			r.printf("%d %s %d\n", tick, opTouchUp, t.ID)
+		}
+	}
+	for _, t := range state.Touches {
+		idx := slices.IndexFunc(p.Touches, func(t2 Touch) bool { return t2.ID == t.ID })
+		if idx < 0 {
+			r.printf("%d %s %d %s %s\n", tick, opTouchDown, t.ID, formatFloat(t.X), formatFloat(t.Y))
+			continue
+		}
+		if p.Touches[idx] != t {
+			r.printf("%d %s %d %s %s\n", tick, opTouchMove, t.ID, formatFloat(t.X), formatFloat(t.Y))
+		}
+	}
+	if len(state.Chars) > 0 {
+		r.printf("%d %s %s\n", tick, opChars, strconv.Quote(string(state.Chars)))
+	}
+	for _, text := range state.IMECommits {
+		r.printf("%d %s %s\n", tick, opIMECommit, strconv.Quote(text))
+	}
+
+	p.Width = state.Width
+	p.Height = state.Height
+	p.CursorX = state.CursorX
+	p.CursorY = state.CursorY
+	p.Keys = append(p.Keys[:0], state.Keys...)
+	p.MouseButtons = append(p.MouseButtons[:0], state.MouseButtons...)
+	p.Touches = append(p.Touches[:0], state.Touches...)
+
+	return r.err
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (r *Recorder) Flush() error {
+	if r.err != nil {
+		return r.err
+	}
+	if err := r.w.Flush(); err != nil {
+		r.err = err
+	}
+	return r.err
+}
+
+func (r *Recorder) printf(format string, args ...any) {
+	if r.err != nil {
+		return
+	}
+	if _, err := fmt.Fprintf(r.w, format, args...); err != nil {
+		r.err = err
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
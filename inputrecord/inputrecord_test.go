@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package inputrecord_test
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui/inputrecord"
+)
+
+type session struct {
+	calls []string
+}
+
+func (s *session) PressKey(key ebiten.Key)   { s.logf("PressKey %d", key) }
+func (s *session) ReleaseKey(key ebiten.Key) { s.logf("ReleaseKey %d", key) }
+func (s *session) TypeRune(r rune)           { s.logf("TypeRune %q", r) }
+func (s *session) MoveCursor(x, y float64)   { s.logf("MoveCursor %g %g", x, y) }
+func (s *session) PressMouseButton(button ebiten.MouseButton) {
+	s.logf("PressMouseButton %d", button)
+}
+func (s *session) ReleaseMouseButton(button ebiten.MouseButton) {
+	s.logf("ReleaseMouseButton %d", button)
+}
+func (s *session) ScrollWheel(x, y float64) { s.logf("ScrollWheel %g %g", x, y) }
+func (s *session) PressTouch(id ebiten.TouchID, x, y float64) {
+	s.logf("PressTouch %d %g %g", id, x, y)
+}
+func (s *session) MoveTouch(id ebiten.TouchID, x, y float64) {
+	s.logf("MoveTouch %d %g %g", id, x, y)
+}
+func (s *session) ReleaseTouch(id ebiten.TouchID) { s.logf("ReleaseTouch %d", id) }
+
+func (s *session) logf(format string, args ...any) {
+	s.calls = append(s.calls, fmt.Sprintf(format, args...))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var buf bytes.Buffer
+	r := inputrecord.NewRecorder(&buf)
+	states := []inputrecord.State{
+		{Width: 640, Height: 480},
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20},
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20, MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonLeft}},
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20},
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20},
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20, Keys: []ebiten.Key{ebiten.KeyA}, Chars: []rune("a b")},
+		{Width: 800, Height: 600, CursorX: 10.5, CursorY: 20, WheelY: -1, Touches: []inputrecord.Touch{{ID: 1, X: 3, Y: 4}}},
+		{Width: 800, Height: 600, CursorX: 10.5, CursorY: 20, Touches: []inputrecord.Touch{{ID: 1, X: 5, Y: 4}}, IMECommits: []string{"日本", "語"}},
+		{Width: 800, Height: 600, CursorX: 10.5, CursorY: 20},
+	}
+	for i := range states {
+		if err := r.Record(int64(i+1), &states[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The idle tick 5 writes nothing.
+	if strings.Contains(buf.String(), "\n5 ") {
+		t.Errorf("recording has an idle tick:\n%s", buf.String())
+	}
+
+	p, err := inputrecord.NewPlayer(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]string
+	var s session
+	for p.Step(&s) {
+		got = append(got, slices.Clone(s.calls))
+		s.calls = s.calls[:0]
+	}
+	want := [][]string{
+		nil,
+		{"MoveCursor 10.5 20"},
+		{fmt.Sprintf("PressMouseButton %d", ebiten.MouseButtonLeft)},
+		{fmt.Sprintf("ReleaseMouseButton %d", ebiten.MouseButtonLeft)},
+		nil,
+		{fmt.Sprintf("PressKey %d", ebiten.KeyA), "TypeRune 'a'", "TypeRune ' '", "TypeRune 'b'"},
+		{fmt.Sprintf("ReleaseKey %d", ebiten.KeyA), "ScrollWheel 0 -1", "PressTouch 1 3 4"},
+		{"MoveTouch 1 5 4", "TypeRune '日'", "TypeRune '本'", "TypeRune '語'"},
+		{"ReleaseTouch 1"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got: %q, want: %q", got, want)
+	}
+	if w, h := p.Size(); w != 800 || h != 600 {
+		t.Errorf("Size(): got: (%g, %g), want: (800, 600)", w, h)
+	}
+}
+
+func TestNewPlayerInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"unknown header\n",
+		"guigui-input-recording 1\n1 jump 1\n",
+		"guigui-input-recording 1\n1 keydown\n",
+		"guigui-input-recording 1\n2 keydown 1\n1 keyup 1\n",
+	} {
+		if _, err := inputrecord.NewPlayer(strings.NewReader(input)); err == nil {
+			t.Errorf("NewPlayer(%q) must return an error", input)
+		}
+	}
+}
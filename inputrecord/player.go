@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package inputrecord
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Session is the destination of a replay.
+//
+// *vmhost.GuestSession of Ebitengine's exp/vmhost package implements Session.
+type Session interface {
+	PressKey(key ebiten.Key)
+	ReleaseKey(key ebiten.Key)
+	TypeRune(r rune)
+	MoveCursor(x, y float64)
+	PressMouseButton(button ebiten.MouseButton)
+	ReleaseMouseButton(button ebiten.MouseButton)
+	ScrollWheel(x, y float64)
+	PressTouch(id ebiten.TouchID, x, y float64)
+	MoveTouch(id ebiten.TouchID, x, y float64)
+	ReleaseTouch(id ebiten.TouchID)
+}
+
+// event is a change of the input in a recording.
+type event struct {
+	tick int64
+	op   string
+	id   int
+	x    float64
+	y    float64
+	text string
+}
+
+// Player replays a recording.
+type Player struct {
+	events []event
+
+	// tick is the tick to replay next.
+	tick int64
+
+	// next is the index of the next event to replay.
+	next int
+
+	width  float64
+	height float64
+}
+
+// NewPlayer creates a Player by reading a recording from r.
+func NewPlayer(r io.Reader) (*Player, error) {
+	p := &Player{}
+	s := bufio.NewScanner(r)
+	// A line of typed characters can be long.
+	s.Buffer(nil, 1<<20)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("inputrecord: empty recording")
+	}
+	if s.Text() != header {
+		return nil, fmt.Errorf("inputrecord: unexpected header: %q", s.Text())
+	}
+	var lineNum int
+	for s.Scan() {
+		lineNum++
+		line := s.Text()
+		if line == "" {
+			continue
+		}
+		e, err := parseEvent(line)
+		if err != nil {
+			return nil, fmt.Errorf("inputrecord: line %d: %w", lineNum+1, err)
+		}
+		if len(p.events) > 0 && e.tick < p.events[len(p.events)-1].tick {
+			return nil, fmt.Errorf("inputrecord: line %d: tick %d is out of order", lineNum+1, e.tick)
+		}
+		p.events = append(p.events, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.events) > 0 {
+		p.tick = p.events[0].tick
+	}
+	return p, nil
+}
+
+func parseEvent(line string) (event, error) {
+	tickStr, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		return event{}, fmt.Errorf("missing operation: %q", line)
+	}
+	tick, err := strconv.ParseInt(tickStr, 10, 64)
+	if err != nil {
+		return event{}, err
+	}
+	op, args, _ := strings.Cut(rest, " ")
+	e := event{
+		tick: tick,
+		op:   op,
+	}
+
+	if op == opChars || op == opIMECommit {
+		text, err := strconv.Unquote(args)
+		if err != nil {
+			return event{}, err
+		}
+		e.text = text
+		return e, nil
+	}
+
+	fields := strings.Fields(args)
+	var nInts, nFloats int
+	switch op {
+	case opSize, opCursor, opWheel:
+		nFloats = 2
+	case opKeyDown, opKeyUp, opMouseDown, opMouseUp, opTouchUp:
+		nInts = 1
+	case opTouchDown, opTouchMove:
+		nInts = 1
+		nFloats = 2
+	default:
+		return event{}, fmt.Errorf("unknown operation: %q", op)
+	}
+	if len(fields) != nInts+nFloats {
+		return event{}, fmt.Errorf("%s: expected %d arguments but got %d", op, nInts+nFloats, len(fields))
+	}
+	if nInts > 0 {
+		id, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return event{}, err
+		}
+		e.id = id
+		fields = fields[1:]
+	}
+	if nFloats > 0 {
+		if e.x, err = strconv.ParseFloat(fields[0], 64); err != nil {
+			return event{}, err
+		}
+		if e.y, err = strconv.ParseFloat(fields[1], 64); err != nil {
+			return event{}, err
+		}
+	}
+	return e, nil
+}
+
+// Done reports whether all the ticks have been replayed.
+func (p *Player) Done() bool {
+	return p.next >= len(p.events)
+}
+
+// Tick returns the recorded tick number to be replayed by the next [Player.Step].
+func (p *Player) Tick() int64 {
+	return p.tick
+}
+
+// Size returns the window's outside size recorded until the last replayed tick.
+// The guest's outside size should match it to replay the input faithfully.
+func (p *Player) Size() (width, height float64) {
+	return p.width, p.height
+}
+
+// Step sends the input of the next tick to session.
+// The caller must advance the guest by exactly one tick after each Step.
+//
+// Step returns false if all the ticks have already been replayed.
+func (p *Player) Step(session Session) bool {
+	if p.Done() {
+		return false
+	}
+	for ; p.next < len(p.events) && p.events[p.next].tick == p.tick; p.next++ {
+		e := &p.events[p.next]
+		switch e.op {
+		case opSize:
+			p.width = e.x
+			p.height = e.y
+		case opCursor:
+			session.MoveCursor(e.x, e.y)
+		case opKeyDown:
+			session.PressKey(ebiten.Key(e.id))
+		case opKeyUp:
+			session.ReleaseKey(ebiten.Key(e.id))
+		case opMouseDown:
+			session.PressMouseButton(ebiten.MouseButton(e.id))
+		case opMouseUp:
+			session.ReleaseMouseButton(ebiten.MouseButton(e.id))
+		case opWheel:
+			session.ScrollWheel(e.x, e.y)
+		case opTouchDown:
+			session.PressTouch(ebiten.TouchID(e.id), e.x, e.y)
+		case opTouchMove:
+			session.MoveTouch(ebiten.TouchID(e.id), e.x, e.y)
+		case opTouchUp:
+			session.ReleaseTouch(ebiten.TouchID(e.id))
+		case opChars, opIMECommit:
+			for _, r := range e.text {
+				session.TypeRune(r)
+			}
+		}
+	}
+	p.tick++
+	return true
+}
@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/guigui-gui/guigui/internal/debugmode"
+	"github.com/guigui-gui/guigui/internal/inputsource"
+)
+
+// inspectorToggleKey is the key to show and hide the inspector.
+const inspectorToggleKey = ebiten.KeyF12
+
+const (
+	// inspectorCharWidth and inspectorLineHeight are the glyph metrics of the ebitenutil debug font.
+	inspectorCharWidth  = 6
+	inspectorLineHeight = 16
+
+	inspectorPadding     = 4
+	inspectorMaxParents  = 12
+	inspectorMaxChildren = 8
+)
+
+// inspector is an overlay to inspect the widget tree at run time.
+//
+// The inspector is available only when GUIGUI_DEBUG includes inspector.
+// While the inspector is shown, inputs are not dispatched to the widgets,
+// and instead select a widget to inspect:
+//
+//   - Hovering highlights the topmost widget under the cursor.
+//   - A click pins the hovered widget.
+//   - With a pinned widget, the arrow keys move to the parent (up), the first child (down), and the siblings (left and right).
+//   - A right click or Escape unpins the widget.
+type inspector struct {
+	shown bool
+
+	// hovered is the topmost widget under the cursor, or nil.
+	hovered Widget
+
+	// pinned is the widget selected by a click or the arrow keys, or nil.
+	pinned Widget
+
+	panel *ebiten.Image
+}
+
+// selected returns the widget to inspect, or nil.
+func (i *inspector) selected() Widget {
+	if i.pinned != nil {
+		return i.pinned
+	}
+	return i.hovered
+}
+
+// updateInspector handles the inputs for the inspector.
+// updateInspector reports whether the inspector is shown. If so, the inputs must not be dispatched to the widgets.
+func (a *app) updateInspector() bool {
+	if !debugmode.Inspector() {
+		return false
+	}
+
+	i := &a.inspector
+	if inputsource.IsKeyJustPressed(inspectorToggleKey) {
+		i.shown = !i.shown
+		i.hovered = nil
+		i.pinned = nil
+	}
+	if !i.shown {
+		return false
+	}
+
+	if i.pinned != nil && !i.pinned.widgetState().isInTree(a.buildCount) {
+		i.pinned = nil
+	}
+
+	// maybeHitWidgets are ordered by descending layer values, and descendants come before their ancestors in the same layer.
+	i.hovered = nil
+	for _, wl := range a.maybeHitWidgets {
+		ws := wl.widget.widgetState()
+		if !ws.isInTree(a.buildCount) || !ws.isVisible() || ws.isPassthrough() {
+			continue
+		}
+		i.hovered = wl.widget
+		break
+	}
+
+	switch {
+	case inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		i.pinned = i.hovered
+	case inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonRight), inputsource.IsKeyJustPressed(ebiten.KeyEscape):
+		i.pinned = nil
+	}
+
+	if i.pinned == nil {
+		return true
+	}
+	ws := i.pinned.widgetState()
+	switch {
+	case inputsource.IsKeyJustPressed(ebiten.KeyArrowUp):
+		if ws.parent != nil {
+			i.pinned = ws.parent
+		}
+	case inputsource.IsKeyJustPressed(ebiten.KeyArrowDown):
+		if len(ws.children) > 0 {
+			i.pinned = ws.children[0]
+		}
+	case inputsource.IsKeyJustPressed(ebiten.KeyArrowLeft), inputsource.IsKeyJustPressed(ebiten.KeyArrowRight):
+		if ws.parent == nil {
+			break
+		}
+		siblings := ws.parent.widgetState().children
+		idx := slices.IndexFunc(siblings, func(w Widget) bool {
+			return w.widgetState() == ws
+		})
+		if idx < 0 {
+			break
+		}
+		if inputsource.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+			idx--
+		} else {
+			idx++
+		}
+		if idx >= 0 && idx < len(siblings) {
+			i.pinned = siblings[idx]
+		}
+	}
+	return true
+}
+
+// drawInspector draws the highlight of the inspected widget and the panel describing it.
+func (a *app) drawInspector(screen *ebiten.Image) {
+	i := &a.inspector
+	if !i.shown {
+		return
+	}
+	widget := i.selected()
+	if widget == nil {
+		return
+	}
+	ws := widget.widgetState()
+
+	scale := math.Max(1, math.Floor(a.deviceScale))
+
+	// Highlight the visible bounds, and outline the bounds.
+	b := ws.bounds
+	vb := a.context.visibleBounds(ws)
+	vector.FillRect(screen, float32(vb.Min.X), float32(vb.Min.Y), float32(vb.Dx()), float32(vb.Dy()), color.NRGBA{R: 0x00, G: 0x80, B: 0xff, A: 0x40}, false)
+	sw := float32(2 * scale)
+	vector.StrokeRect(screen, float32(b.Min.X)+sw/2, float32(b.Min.Y)+sw/2, float32(b.Dx())-sw, float32(b.Dy())-sw, sw, color.NRGBA{R: 0x00, G: 0x80, B: 0xff, A: 0xff}, false)
+
+	text := a.inspectorText(widget)
+	var cols int
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		cols = max(cols, len(line))
+	}
+	size := image.Pt(cols*inspectorCharWidth+2*inspectorPadding, len(lines)*inspectorLineHeight+2*inspectorPadding)
+
+	if i.panel != nil {
+		if pb := i.panel.Bounds(); pb.Dx() < size.X || pb.Dy() < size.Y {
+			i.panel.Deallocate()
+			i.panel = nil
+		}
+	}
+	if i.panel == nil {
+		i.panel = ebiten.NewImage(size.X, size.Y)
+	}
+	panel := i.panel.SubImage(image.Rectangle{Max: size}).(*ebiten.Image)
+	panel.Fill(color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xc0})
+	ebitenutil.DebugPrintAt(panel, text, inspectorPadding, inspectorPadding)
+
+	// Put the panel at the top-left corner, or the bottom-right corner if the cursor is over the top-left corner.
+	margin := int(8 * scale)
+	pos := image.Pt(margin, margin)
+	scaledSize := image.Pt(int(float64(size.X)*scale), int(float64(size.Y)*scale))
+	if image.Pt(inputsource.CursorPosition()).In(image.Rectangle{Min: pos, Max: pos.Add(scaledSize)}) {
+		sb := screen.Bounds()
+		pos = sb.Max.Sub(scaledSize).Sub(image.Pt(margin, margin))
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(pos.X), float64(pos.Y))
+	screen.DrawImage(panel, op)
+}
+
+// inspectorText returns the description of the widget shown in the inspector panel.
+func (a *app) inspectorText(widget Widget) string {
+	i := &a.inspector
+	ws := widget.widgetState()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%T", widget)
+	if i.pinned != nil {
+		sb.WriteString(" (pinned)")
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "bounds:         %v\n", ws.bounds)
+	fmt.Fprintf(&sb, "visible bounds: %v\n", a.context.visibleBounds(ws))
+	fmt.Fprintf(&sb, "layer:          %d\n", ws.actualLayer())
+	fmt.Fprintf(&sb, "enabled: %t, visible: %t, focused: %t, passthrough: %t\n", ws.isEnabled(), ws.isVisible(), a.context.IsFocused(widget), ws.isPassthrough())
+	fmt.Fprintf(&sb, "state key:      %016x\n", ws.capturedStateKey)
+
+	sb.WriteString("parents:\n")
+	var n int
+	for p := ws.parent; p != nil; p = p.widgetState().parent {
+		if n == inspectorMaxParents {
+			sb.WriteString("  ...\n")
+			break
+		}
+		fmt.Fprintf(&sb, "  %T\n", p)
+		n++
+	}
+
+	fmt.Fprintf(&sb, "children: %d\n", len(ws.children))
+	for idx, c := range ws.children {
+		if idx == inspectorMaxChildren {
+			sb.WriteString("  ...\n")
+			break
+		}
+		fmt.Fprintf(&sb, "  %T\n", c)
+	}
+
+	if i.pinned != nil {
+		sb.WriteString("[arrows] browse  [esc] unpin  [F12] close")
+	} else {
+		sb.WriteString("[click] pin  [F12] close")
+	}
+	return sb.String()
+}
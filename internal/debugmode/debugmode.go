@@ -14,8 +14,19 @@ type debugMode struct {
 	showRenderingRegions bool
 	showBuildLogs        bool
 	showInputLogs        bool
+	inspector            bool
+	checkContrast        bool
 	deviceScale          float64
 
+	// profileFile is the file to write the profile to, or empty if profiling is disabled.
+	profileFile string
+
+	// recordFile is the file to record the input to, or empty if recording is disabled.
+	recordFile string
+
+	// replayFile is the file to replay the input from, or empty if replaying is disabled.
+	replayFile string
+
 	// emulateClipboard replaces the system clipboard with an in-process one.
 	emulateClipboard bool
 
@@ -34,6 +45,10 @@ func init() {
 			theDebugMode.showBuildLogs = true
 		case token == "showinputlogs":
 			theDebugMode.showInputLogs = true
+		case token == "inspector":
+			theDebugMode.inspector = true
+		case token == "checkcontrast":
+			theDebugMode.checkContrast = true
 		case token == "emulateclipboard":
 			theDebugMode.emulateClipboard = true
 		case strings.HasPrefix(token, "devicescale="):
@@ -42,6 +57,12 @@ func init() {
 				slog.Error(err.Error())
 			}
 			theDebugMode.deviceScale = f
+		case strings.HasPrefix(token, "profile="):
+			theDebugMode.profileFile = token[len("profile="):]
+		case strings.HasPrefix(token, "record="):
+			theDebugMode.recordFile = token[len("record="):]
+		case strings.HasPrefix(token, "replay="):
+			theDebugMode.replayFile = token[len("replay="):]
 		case token == "":
 		default:
 			slog.Warn("unknown debug option", "option", token)
@@ -68,6 +89,34 @@ func ShowInputLogs() bool {
 	return theDebugMode.showInputLogs
 }
 
+// Inspector reports whether the widget inspector is available.
+func Inspector() bool {
+	return theDebugMode.inspector
+}
+
+// CheckContrast reports whether the contrast of text against its background should be checked.
+func CheckContrast() bool {
+	return theDebugMode.checkContrast
+}
+
+// ProfileFile returns the file to write the per-widget profile to,
+// or an empty string when profiling is disabled.
+func ProfileFile() string {
+	return theDebugMode.profileFile
+}
+
+// RecordFile returns the file to record the input to,
+// or an empty string when recording is disabled.
+func RecordFile() string {
+	return theDebugMode.recordFile
+}
+
+// ReplayFile returns the file to replay the input from,
+// or an empty string when replaying is disabled.
+func ReplayFile() string {
+	return theDebugMode.replayFile
+}
+
 // DeviceScale returns the device scale factor to use instead of the monitor's,
 // or 0 when the monitor's factor should be used.
 func DeviceScale() float64 {
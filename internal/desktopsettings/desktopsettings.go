@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package desktopsettings reads the desktop settings that Guigui follows, such as the accent color,
+// the caret blinking and the GTK key theme.
+//
+// On Linux and other Unix desktops, the settings are read from GSettings, xfconf, and the GTK settings
+// file. On the other systems, no settings are read yet.
+package desktopsettings
+
+import (
+	"image/color"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Settings are the desktop settings.
+// The zero value of each field means that the desktop does not specify it.
+type Settings struct {
+	// AccentColor is the accent color.
+	AccentColor color.Color
+
+	// TextScalingFactor is the factor to scale text by, such as GNOME's text-scaling-factor.
+	//
+	// The DPI of Xft is not a source of this, as the device scale factor already follows it on X11.
+	TextScalingFactor float64
+
+	// AnimationsDisabled reports whether animations should be disabled to reduce motion.
+	AnimationsDisabled bool
+
+	// CaretBlinkDisabled reports whether the caret should not blink.
+	CaretBlinkDisabled bool
+
+	// CaretBlinkTime is the duration of a caret blink cycle.
+	CaretBlinkTime time.Duration
+
+	// CaretBlinkTimeout is the duration after which the caret stops blinking without any input.
+	CaretBlinkTimeout time.Duration
+
+	// DoubleClickTime is the maximum duration between two clicks of a double click.
+	DoubleClickTime time.Duration
+
+	// GTKKeyTheme is the name of the GTK key theme, such as "Emacs".
+	GTKKeyTheme string
+}
+
+// cachedSettings is the settings, read at the first use, as reading them might launch processes. The value never expires: a settings change
+// is reflected only at the next run.
+var cachedSettings = sync.OnceValue(func() Settings {
+	var s Settings
+	readSettings(&s)
+	return s
+})
+
+var disabled atomic.Bool
+
+// Get returns the settings of the current desktop.
+// After [Disable], Get returns the zero Settings.
+func Get() Settings {
+	if disabled.Load() {
+		return Settings{}
+	}
+	return cachedSettings()
+}
+
+// Disable makes Guigui ignore the desktop settings, so that the results do not depend on the host.
+// Disable is intended for tests, and must be called before the settings are used.
+func Disable() {
+	disabled.Store(true)
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+//go:build !(unix && !android && !darwin)
+
+package desktopsettings
+
+func readSettings(s *Settings) {
+}
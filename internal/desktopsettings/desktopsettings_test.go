@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package desktopsettings_test
+
+import (
+	"testing"
+
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+)
+
+func TestDisable(t *testing.T) {
+	desktopsettings.Disable()
+	if got := desktopsettings.Get(); got != (desktopsettings.Settings{}) {
+		t.Errorf("Get() after Disable: got: %+v, want: the zero Settings", got)
+	}
+}
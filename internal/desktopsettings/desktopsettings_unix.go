@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+//go:build unix && !android && !darwin
+
+package desktopsettings
+
+import (
+	"image/color"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// desktop identifies where the settings of a desktop are read from.
+type desktop int
+
+const (
+	desktopNone desktop = iota
+	desktopGNOME
+	desktopCinnamon
+	desktopMATE
+	desktopXfce
+)
+
+// desktops maps a desktop name as it appears in XDG_CURRENT_DESKTOP, lowercased, to the desktop.
+var desktops = map[string]desktop{
+	"gnome":      desktopGNOME,
+	"unity":      desktopGNOME,
+	"pantheon":   desktopGNOME,
+	"cinnamon":   desktopCinnamon,
+	"x-cinnamon": desktopCinnamon,
+	"mate":       desktopMATE,
+	"xfce":       desktopXfce,
+}
+
+// desktopForName returns the first desktop named in an XDG_CURRENT_DESKTOP value that is known, or
+// desktopNone.
+func desktopForName(currentDesktop string) desktop {
+	for name := range strings.SplitSeq(currentDesktop, ":") {
+		if d, ok := desktops[strings.ToLower(strings.TrimSpace(name))]; ok {
+			return d
+		}
+	}
+	return desktopNone
+}
+
+// setter sets a field of Settings from a raw value. An invalid value is ignored.
+type setter func(s *Settings, value string)
+
+// gsettingsSetters maps a desktop to its GSettings keys, written as "schema key".
+var gsettingsSetters = map[desktop]map[string]setter{
+	desktopGNOME: {
+		"org.gnome.desktop.interface accent-color":         setGNOMEAccentColor,
+		"org.gnome.desktop.interface text-scaling-factor":  setTextScalingFactor,
+		"org.gnome.desktop.interface enable-animations":    setEnableAnimations,
+		"org.gnome.desktop.interface cursor-blink":         setCursorBlink,
+		"org.gnome.desktop.interface cursor-blink-time":    setCursorBlinkTime,
+		"org.gnome.desktop.interface cursor-blink-timeout": setCursorBlinkTimeout,
+		"org.gnome.desktop.peripherals.mouse double-click": setDoubleClickTime,
+		"org.gnome.desktop.interface gtk-key-theme":        setGTKKeyTheme,
+	},
+	desktopCinnamon: {
+		"org.cinnamon.desktop.interface text-scaling-factor":  setTextScalingFactor,
+		"org.cinnamon.desktop.interface cursor-blink":         setCursorBlink,
+		"org.cinnamon.desktop.interface cursor-blink-time":    setCursorBlinkTime,
+		"org.cinnamon.desktop.interface cursor-blink-timeout": setCursorBlinkTimeout,
+		"org.cinnamon.desktop.peripherals.mouse double-click": setDoubleClickTime,
+		"org.cinnamon.desktop.interface gtk-key-theme":        setGTKKeyTheme,
+	},
+	desktopMATE: {
+		"org.mate.interface gtk-enable-animations": setEnableAnimations,
+		"org.mate.interface cursor-blink":          setCursorBlink,
+		"org.mate.interface cursor-blink-time":     setCursorBlinkTime,
+		"org.mate.peripherals-mouse double-click":  setDoubleClickTime,
+		"org.mate.interface gtk-key-theme":         setGTKKeyTheme,
+	},
+}
+
+// xfconfSetters maps the properties of the Xfce xsettings channel.
+var xfconfSetters = map[string]setter{
+	"/Gtk/EnableAnimations": setEnableAnimations,
+	"/Net/CursorBlink":      setCursorBlink,
+	"/Net/CursorBlinkTime":  setCursorBlinkTime,
+	"/Net/DoubleClickTime":  setDoubleClickTime,
+	"/Gtk/KeyThemeName":     setGTKKeyTheme,
+}
+
+// gtkSettingsSetters maps the keys of a GTK settings file.
+var gtkSettingsSetters = map[string]setter{
+	"gtk-enable-animations":    setEnableAnimations,
+	"gtk-cursor-blink":         setCursorBlink,
+	"gtk-cursor-blink-time":    setCursorBlinkTime,
+	"gtk-cursor-blink-timeout": setCursorBlinkTimeout,
+	"gtk-double-click-time":    setDoubleClickTime,
+	"gtk-key-theme-name":       setGTKKeyTheme,
+}
+
+// gnomeAccentColors are the colors of the GNOME accent color names, which are the ones of libadwaita.
+var gnomeAccentColors = map[string]color.Color{
+	"blue":   color.RGBA{R: 0x35, G: 0x84, B: 0xe4, A: 0xff},
+	"teal":   color.RGBA{R: 0x21, G: 0x90, B: 0xa4, A: 0xff},
+	"green":  color.RGBA{R: 0x3a, G: 0x94, B: 0x4a, A: 0xff},
+	"yellow": color.RGBA{R: 0xc8, G: 0x88, B: 0x00, A: 0xff},
+	"orange": color.RGBA{R: 0xed, G: 0x5b, B: 0x00, A: 0xff},
+	"red":    color.RGBA{R: 0xe6, G: 0x2d, B: 0x42, A: 0xff},
+	"pink":   color.RGBA{R: 0xd5, G: 0x61, B: 0x99, A: 0xff},
+	"purple": color.RGBA{R: 0x91, G: 0x41, B: 0xac, A: 0xff},
+	"slate":  color.RGBA{R: 0x6f, G: 0x83, B: 0x96, A: 0xff},
+}
+
+func setGNOMEAccentColor(s *Settings, value string) {
+	if clr, ok := gnomeAccentColors[value]; ok {
+		s.AccentColor = clr
+	}
+}
+
+func setTextScalingFactor(s *Settings, value string) {
+	if f, err := strconv.ParseFloat(value, 64); err == nil && f > 0 {
+		s.TextScalingFactor = f
+	}
+}
+
+func setEnableAnimations(s *Settings, value string) {
+	if b, err := strconv.ParseBool(value); err == nil {
+		s.AnimationsDisabled = !b
+	}
+}
+
+func setCursorBlink(s *Settings, value string) {
+	if b, err := strconv.ParseBool(value); err == nil {
+		s.CaretBlinkDisabled = !b
+	}
+}
+
+func setCursorBlinkTime(s *Settings, value string) {
+	if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+		s.CaretBlinkTime = time.Duration(ms) * time.Millisecond
+	}
+}
+
+// setCursorBlinkTimeout sets the caret blink timeout from seconds.
+func setCursorBlinkTimeout(s *Settings, value string) {
+	if sec, err := strconv.Atoi(value); err == nil && sec > 0 {
+		s.CaretBlinkTimeout = time.Duration(sec) * time.Second
+	}
+}
+
+func setDoubleClickTime(s *Settings, value string) {
+	if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+		s.DoubleClickTime = time.Duration(ms) * time.Millisecond
+	}
+}
+
+// setGTKKeyTheme sets the GTK key theme. An empty value is valid, and means the default key theme.
+func setGTKKeyTheme(s *Settings, value string) {
+	s.GTKKeyTheme = value
+}
+
+func readSettings(s *Settings) {
+	// The settings file goes first, and a desktop that answers overrides it: the desktop's settings reach
+	// GTK through XSETTINGS or the desktop portal, which take precedence over the file.
+	// Only GTK3's file is consulted: GTK4 dropped key themes.
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		s.applyGTKSettingsFile(filepath.Join(homeDir, ".config", "gtk-3.0", "settings.ini"))
+	}
+
+	switch d := desktopForName(os.Getenv("XDG_CURRENT_DESKTOP")); d {
+	case desktopNone:
+	case desktopXfce:
+		out, err := exec.Command("xfconf-query", "-c", "xsettings", "-lv").Output()
+		if err != nil {
+			return
+		}
+		s.applyXfconfList(string(out))
+	default:
+		// List each schema at once rather than each key, as launching gsettings is not cheap.
+		var schemas []string
+		for key := range gsettingsSetters[d] {
+			schema, _, _ := strings.Cut(key, " ")
+			if !slices.Contains(schemas, schema) {
+				schemas = append(schemas, schema)
+			}
+		}
+		for _, schema := range schemas {
+			out, err := exec.Command("gsettings", "list-recursively", schema).Output()
+			if err != nil {
+				continue
+			}
+			s.applyGSettingsList(d, string(out))
+		}
+	}
+}
+
+// applyGSettingsList applies the output of gsettings list-recursively, whose lines are "schema key value".
+func (s *Settings) applyGSettingsList(d desktop, output string) {
+	setters := gsettingsSetters[d]
+	for line := range strings.Lines(output) {
+		schema, rest, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		key, value, ok := strings.Cut(rest, " ")
+		if !ok {
+			continue
+		}
+		if set, ok := setters[schema+" "+key]; ok {
+			set(s, unquoteGSettingsValue(value))
+		}
+	}
+}
+
+// unquoteGSettingsValue returns the content of a GVariant literal as printed by gsettings, without the
+// quotes of a string and the type annotation of a number like "uint32 400".
+func unquoteGSettingsValue(value string) string {
+	value = strings.TrimSpace(value)
+	if typ, v, ok := strings.Cut(value, " "); ok {
+		switch typ {
+		case "byte", "int16", "uint16", "int32", "uint32", "int64", "uint64":
+			value = v
+		}
+	}
+	value = strings.TrimPrefix(value, "'")
+	value = strings.TrimSuffix(value, "'")
+	return value
+}
+
+// applyXfconfList applies the output of xfconf-query -lv, whose lines are a property and its value
+// separated by spaces.
+func (s *Settings) applyXfconfList(output string) {
+	for line := range strings.Lines(output) {
+		property, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		if set, ok := xfconfSetters[property]; ok {
+			set(s, strings.TrimSpace(value))
+		}
+	}
+}
+
+// applyGTKSettingsFile applies the GTK settings file at path, if it can be read.
+func (s *Settings) applyGTKSettingsFile(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	s.applyGTKSettings(string(data))
+}
+
+// applyGTKSettings applies the content of a GTK settings file. The last assignment of a key wins.
+func (s *Settings) applyGTKSettings(content string) {
+	for line := range strings.Lines(content) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if set, ok := gtkSettingsSetters[strings.TrimSpace(key)]; ok {
+			set(s, strings.Trim(strings.TrimSpace(value), `"`))
+		}
+	}
+}
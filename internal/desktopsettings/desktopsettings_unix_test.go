@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+//go:build unix && !android && !darwin
+
+package desktopsettings_test
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guigui-gui/guigui/internal/desktopsettings"
+)
+
+func TestDesktopForName(t *testing.T) {
+	testCases := []struct {
+		currentDesktop string
+		want           desktopsettings.Desktop
+	}{
+		{
+			currentDesktop: "GNOME",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "ubuntu:GNOME",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "GNOME-Flashback:GNOME",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "Budgie:GNOME",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "Unity:Unity7:ubuntu",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "Pantheon",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "X-Cinnamon",
+			want:           desktopsettings.DesktopCinnamon,
+		},
+		{
+			currentDesktop: "MATE",
+			want:           desktopsettings.DesktopMATE,
+		},
+		{
+			currentDesktop: "XFCE",
+			want:           desktopsettings.DesktopXfce,
+		},
+		{
+			currentDesktop: "xfce",
+			want:           desktopsettings.DesktopXfce,
+		},
+		{
+			// The first known desktop wins, so an Xfce session is not answered by a
+			// GNOME schema that merely happens to be installed.
+			currentDesktop: "XFCE:GNOME",
+			want:           desktopsettings.DesktopXfce,
+		},
+		{
+			// An unknown desktop is skipped rather than ending the search.
+			currentDesktop: "Enlightenment:GNOME",
+			want:           desktopsettings.DesktopGNOME,
+		},
+		{
+			currentDesktop: "KDE",
+			want:           desktopsettings.DesktopNone,
+		},
+		{
+			currentDesktop: "sway",
+			want:           desktopsettings.DesktopNone,
+		},
+		{
+			currentDesktop: "",
+			want:           desktopsettings.DesktopNone,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.currentDesktop, func(t *testing.T) {
+			if got, want := desktopsettings.DesktopForName(tc.currentDesktop), tc.want; got != want {
+				t.Errorf("DesktopForName(%q): got %d, want %d", tc.currentDesktop, got, want)
+			}
+		})
+	}
+}
+
+func TestUnquoteGSettingsValue(t *testing.T) {
+	testCases := []struct {
+		value string
+		want  string
+	}{
+		{
+			value: "'blue'",
+			want:  "blue",
+		},
+		{
+			value: "uint32 400",
+			want:  "400",
+		},
+		{
+			value: "1.25\n",
+			want:  "1.25",
+		},
+		{
+			value: "'Sans 11'",
+			want:  "Sans 11",
+		},
+		{
+			value: "'Emacs'\n",
+			want:  "Emacs",
+		},
+		{
+			value: "''\n",
+			want:  "",
+		},
+		{
+			value: "Emacs\n",
+			want:  "Emacs",
+		},
+	}
+	for _, tc := range testCases {
+		if got, want := desktopsettings.UnquoteGSettingsValue(tc.value), tc.want; got != want {
+			t.Errorf("UnquoteGSettingsValue(%q): got %q, want %q", tc.value, got, want)
+		}
+	}
+}
+
+func TestApplyGSettingsList(t *testing.T) {
+	const output = `org.gnome.desktop.interface accent-color 'purple'
+org.gnome.desktop.interface cursor-blink false
+org.gnome.desktop.interface cursor-blink-time 1000
+org.gnome.desktop.interface cursor-blink-timeout 10
+org.gnome.desktop.interface enable-animations false
+org.gnome.desktop.interface font-name 'Cantarell 11'
+org.gnome.desktop.interface text-scaling-factor 1.25
+org.gnome.desktop.peripherals.mouse double-click 300
+`
+	var got desktopsettings.Settings
+	got.ApplyGSettingsList(desktopsettings.DesktopGNOME, output)
+	want := desktopsettings.Settings{
+		AccentColor:        color.RGBA{R: 0x91, G: 0x41, B: 0xac, A: 0xff},
+		TextScalingFactor:  1.25,
+		AnimationsDisabled: true,
+		CaretBlinkDisabled: true,
+		CaretBlinkTime:     time.Second,
+		CaretBlinkTimeout:  10 * time.Second,
+		DoubleClickTime:    300 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("ApplyGSettingsList: got %+v, want %+v", got, want)
+	}
+
+	// The keys of another desktop are ignored.
+	var got2 desktopsettings.Settings
+	got2.ApplyGSettingsList(desktopsettings.DesktopMATE, output)
+	if got2 != (desktopsettings.Settings{}) {
+		t.Errorf("ApplyGSettingsList for MATE: got %+v, want the zero value", got2)
+	}
+}
+
+func TestApplyXfconfList(t *testing.T) {
+	const output = `/Gtk/FontName                     Sans 10
+/Net/CursorBlink                  true
+/Net/CursorBlinkTime              800
+/Net/DoubleClickTime              250
+/Xft/DPI                          144
+`
+	var got desktopsettings.Settings
+	got.ApplyXfconfList(output)
+	// The DPI is ignored, as the device scale factor already follows it.
+	want := desktopsettings.Settings{
+		CaretBlinkTime:  800 * time.Millisecond,
+		DoubleClickTime: 250 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("ApplyXfconfList: got %+v, want %+v", got, want)
+	}
+}
+
+func TestApplyGTKSettings(t *testing.T) {
+	const content = `[Settings]
+gtk-theme-name=Adwaita
+gtk-enable-animations=0
+gtk-cursor-blink = false
+#gtk-double-click-time=100
+gtk-double-click-time=400
+gtk-xft-dpi=98304
+gtk-cursor-blink-time=-1
+`
+	var got desktopsettings.Settings
+	got.ApplyGTKSettings(content)
+	want := desktopsettings.Settings{
+		AnimationsDisabled: true,
+		CaretBlinkDisabled: true,
+		DoubleClickTime:    400 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("ApplyGTKSettings: got %+v, want %+v", got, want)
+	}
+}
+
+func TestApplyGTKSettingsKeyTheme(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "typical",
+			content: "[Settings]\ngtk-key-theme-name=Emacs\n",
+			want:    "Emacs",
+		},
+		{
+			name:    "spaces around the separator",
+			content: "[Settings]\ngtk-key-theme-name = Emacs\n",
+			want:    "Emacs",
+		},
+		{
+			name:    "among other keys",
+			content: "[Settings]\ngtk-theme-name=Adwaita\ngtk-key-theme-name=Emacs\ngtk-font-name=Sans 10\n",
+			want:    "Emacs",
+		},
+		{
+			name:    "commented out",
+			content: "[Settings]\n#gtk-key-theme-name=Emacs\n",
+			want:    "",
+		},
+		{
+			name:    "key not set",
+			content: "[Settings]\ngtk-theme-name=Adwaita\n",
+			want:    "",
+		},
+		{
+			name:    "empty value",
+			content: "[Settings]\ngtk-key-theme-name=\n",
+			want:    "",
+		},
+		{
+			name:    "last assignment wins",
+			content: "[Settings]\ngtk-key-theme-name=Emacs\ngtk-key-theme-name=Default\n",
+			want:    "Default",
+		},
+		{
+			name:    "another key ending with the same name",
+			content: "[Settings]\nxgtk-key-theme-name=Emacs\n",
+			want:    "",
+		},
+		{
+			name:    "no trailing newline",
+			content: "[Settings]\ngtk-key-theme-name=Emacs",
+			want:    "Emacs",
+		},
+		{
+			name:    "CRLF",
+			content: "[Settings]\r\ngtk-key-theme-name=Emacs\r\n",
+			want:    "Emacs",
+		},
+		{
+			name:    "empty content",
+			content: "",
+			want:    "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s desktopsettings.Settings
+			s.ApplyGTKSettings(tc.content)
+			if got, want := s.GTKKeyTheme, tc.want; got != want {
+				t.Errorf("ApplyGTKSettings(%q): got key theme %q, want %q", tc.content, got, want)
+			}
+		})
+	}
+}
+
+func TestApplyGSettingsListKeyTheme(t *testing.T) {
+	// A desktop that answers overrides the settings file, even with an empty key theme.
+	s := desktopsettings.Settings{
+		GTKKeyTheme: "Emacs",
+	}
+	s.ApplyGSettingsList(desktopsettings.DesktopGNOME, "org.gnome.desktop.interface gtk-key-theme ''\n")
+	if got, want := s.GTKKeyTheme, ""; got != want {
+		t.Errorf("ApplyGSettingsList: got key theme %q, want %q", got, want)
+	}
+
+	s.ApplyXfconfList("/Gtk/KeyThemeName                 Emacs\n")
+	if got, want := s.GTKKeyTheme, "Emacs"; got != want {
+		t.Errorf("ApplyXfconfList: got key theme %q, want %q", got, want)
+	}
+}
+
+func TestApplyGTKSettingsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "settings.ini")
+	if err := os.WriteFile(path, []byte("[Settings]\ngtk-key-theme-name=Emacs\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var s desktopsettings.Settings
+	s.ApplyGTKSettingsFile(path)
+	if got, want := s.GTKKeyTheme, "Emacs"; got != want {
+		t.Errorf("ApplyGTKSettingsFile: got key theme %q, want %q", got, want)
+	}
+
+	var s2 desktopsettings.Settings
+	s2.ApplyGTKSettingsFile(filepath.Join(dir, "nonexistent.ini"))
+	if s2 != (desktopsettings.Settings{}) {
+		t.Errorf("ApplyGTKSettingsFile for a missing file: got %+v, want the zero value", s2)
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+//go:build unix && !android && !darwin
+
+package desktopsettings
+
+type Desktop = desktop
+
+const (
+	DesktopNone     = desktopNone
+	DesktopGNOME    = desktopGNOME
+	DesktopCinnamon = desktopCinnamon
+	DesktopMATE     = desktopMATE
+	DesktopXfce     = desktopXfce
+)
+
+var (
+	DesktopForName        = desktopForName
+	UnquoteGSettingsValue = unquoteGSettingsValue
+)
+
+func (s *Settings) ApplyGSettingsList(d Desktop, output string) {
+	s.applyGSettingsList(d, output)
+}
+
+func (s *Settings) ApplyXfconfList(output string) {
+	s.applyXfconfList(output)
+}
+
+func (s *Settings) ApplyGTKSettings(content string) {
+	s.applyGTKSettings(content)
+}
+
+func (s *Settings) ApplyGTKSettingsFile(path string) {
+	s.applyGTKSettingsFile(path)
+}
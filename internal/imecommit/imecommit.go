@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package imecommit passes the texts an input method (IME) commits to the input recorder.
+//
+// The text widgets receive IME commits through Ebitengine's exp/textinput, which the application
+// does not see, so they notify the commits here.
+package imecommit
+
+// handler is called with each committed text. This is accessed only on the UI goroutine.
+var handler func(text string)
+
+// SetHandler sets the function called with each committed text.
+// A nil handler stops the notification.
+func SetHandler(f func(text string)) {
+	handler = f
+}
+
+// Notify notifies the handler, if any, of the committed text.
+func Notify(text string) {
+	if handler == nil {
+		return
+	}
+	handler(text)
+}
@@ -0,0 +1,403 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package inputsource provides the input functions of Ebitengine's ebiten and inpututil packages
+// that Guigui and its widgets use, so that a recording can replace the input.
+//
+// While a replay is active, the functions return the replayed input instead of Ebitengine's.
+// Without a replay, they call Ebitengine's functions of the same names.
+//
+// The functions must be called only on the UI goroutine.
+package inputsource
+
+import (
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/guigui-gui/guigui/inputrecord"
+)
+
+// theReplay is the active replay, or nil.
+var theReplay *replay
+
+// pressState is the state of a key, a mouse button, or a touch in a replay.
+type pressState struct {
+	// pressedTick is the tick when the input was pressed, or 0 when it is not pressed.
+	pressedTick int64
+
+	// releasedTick is the tick when the input was released last.
+	releasedTick int64
+}
+
+func (p pressState) pressed() bool {
+	return p.pressedTick != 0
+}
+
+// touchState is the state of a touch in a replay.
+type touchState struct {
+	pressState
+	x float64
+	y float64
+}
+
+func (t touchState) pressed() bool {
+	return t.pressState.pressed()
+}
+
+// replay is the input state replayed by an [inputrecord.Player]. replay implements [inputrecord.Session].
+type replay struct {
+	player *inputrecord.Player
+
+	// tick is the current tick, starting at 1.
+	tick int64
+
+	// deviceScale is the device scale factor to convert the recorded device-independent pixels.
+	deviceScale float64
+
+	keys         map[ebiten.Key]pressState
+	mouseButtons map[ebiten.MouseButton]pressState
+	touches      map[ebiten.TouchID]touchState
+
+	cursorX float64
+	cursorY float64
+	wheelX  float64
+	wheelY  float64
+	chars   []rune
+}
+
+// StartReplay starts replaying player. The first [Step] replays the first tick of the recording.
+func StartReplay(player *inputrecord.Player) {
+	theReplay = &replay{
+		player:       player,
+		keys:         map[ebiten.Key]pressState{},
+		mouseButtons: map[ebiten.MouseButton]pressState{},
+		touches:      map[ebiten.TouchID]touchState{},
+	}
+}
+
+// IsReplaying reports whether a replay is active.
+func IsReplaying() bool {
+	return theReplay != nil
+}
+
+// ReplayedTick returns the tick of the active replay, starting at 1 at the first [Step],
+// or 0 when no replay is active.
+func ReplayedTick() int64 {
+	if theReplay == nil {
+		return 0
+	}
+	return theReplay.tick
+}
+
+// Step replays the next tick of the active replay. Step must be called once at the start of every tick,
+// before any input is read.
+//
+// Step returns the recorded window size in device-independent pixels, and true if a tick was replayed.
+// When the recording is exhausted, the replay ends and Step returns false; the input is read from Ebitengine again.
+func Step(deviceScale float64) (width, height float64, ok bool) {
+	r := theReplay
+	if r == nil {
+		return 0, 0, false
+	}
+	r.tick++
+	r.deviceScale = deviceScale
+	r.wheelX = 0
+	r.wheelY = 0
+	r.chars = r.chars[:0]
+	if !r.player.Step(r) {
+		theReplay = nil
+		return 0, 0, false
+	}
+	width, height = r.player.Size()
+	return width, height, true
+}
+
+func (r *replay) PressKey(key ebiten.Key) {
+	r.keys[key] = pressState{pressedTick: r.tick}
+}
+
+func (r *replay) ReleaseKey(key ebiten.Key) {
+	r.keys[key] = pressState{releasedTick: r.tick}
+}
+
+func (r *replay) TypeRune(ch rune) {
+	r.chars = append(r.chars, ch)
+}
+
+func (r *replay) MoveCursor(x, y float64) {
+	r.cursorX = x
+	r.cursorY = y
+}
+
+func (r *replay) PressMouseButton(button ebiten.MouseButton) {
+	r.mouseButtons[button] = pressState{pressedTick: r.tick}
+}
+
+func (r *replay) ReleaseMouseButton(button ebiten.MouseButton) {
+	r.mouseButtons[button] = pressState{releasedTick: r.tick}
+}
+
+func (r *replay) ScrollWheel(x, y float64) {
+	r.wheelX += x
+	r.wheelY += y
+}
+
+func (r *replay) PressTouch(id ebiten.TouchID, x, y float64) {
+	r.touches[id] = touchState{
+		pressState: pressState{pressedTick: r.tick},
+		x:          x,
+		y:          y,
+	}
+}
+
+func (r *replay) MoveTouch(id ebiten.TouchID, x, y float64) {
+	t := r.touches[id]
+	t.x = x
+	t.y = y
+	r.touches[id] = t
+}
+
+func (r *replay) ReleaseTouch(id ebiten.TouchID) {
+	t := r.touches[id]
+	t.pressState = pressState{releasedTick: r.tick}
+	r.touches[id] = t
+}
+
+// toScreen converts a recorded position in device-independent pixels to the screen's pixels.
+func (r *replay) toScreen(v float64) int {
+	return int(math.Round(v * r.deviceScale))
+}
+
+// keyAliases returns the physical keys the virtual key stands for, as Ebitengine's [ebiten.IsKeyPressed] treats them.
+func keyAliases(key ebiten.Key) []ebiten.Key {
+	switch key {
+	case ebiten.KeyAlt:
+		return []ebiten.Key{ebiten.KeyAlt, ebiten.KeyAltLeft, ebiten.KeyAltRight}
+	case ebiten.KeyControl:
+		return []ebiten.Key{ebiten.KeyControl, ebiten.KeyControlLeft, ebiten.KeyControlRight}
+	case ebiten.KeyShift:
+		return []ebiten.Key{ebiten.KeyShift, ebiten.KeyShiftLeft, ebiten.KeyShiftRight}
+	case ebiten.KeyMeta:
+		return []ebiten.Key{ebiten.KeyMeta, ebiten.KeyMetaLeft, ebiten.KeyMetaRight}
+	}
+	return nil
+}
+
+// keyState returns the state of key. For a virtual key like [ebiten.KeyShift], the state is pressed
+// since the earliest press of its physical keys.
+func (r *replay) keyState(key ebiten.Key) pressState {
+	aliases := keyAliases(key)
+	if aliases == nil {
+		return r.keys[key]
+	}
+	var s pressState
+	for _, k := range aliases {
+		ks := r.keys[k]
+		if ks.pressed() && (!s.pressed() || ks.pressedTick < s.pressedTick) {
+			s.pressedTick = ks.pressedTick
+		}
+		s.releasedTick = max(s.releasedTick, ks.releasedTick)
+	}
+	return s
+}
+
+// duration returns the number of ticks s has been pressed, counting the current tick.
+func (r *replay) duration(s pressState) int {
+	if !s.pressed() {
+		return 0
+	}
+	return int(r.tick - s.pressedTick + 1)
+}
+
+func (r *replay) justPressed(s pressState) bool {
+	return s.pressed() && s.pressedTick == r.tick
+}
+
+func (r *replay) justReleased(s pressState) bool {
+	return !s.pressed() && s.releasedTick == r.tick
+}
+
+// appendSorted appends the keys of m whose states satisfy f to keys, in ascending order.
+func appendSorted[K ~int, S any](keys []K, m map[K]S, f func(S) bool) []K {
+	n := len(keys)
+	for k, s := range m {
+		if f(s) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys[n:])
+	return keys
+}
+
+// IsKeyPressed is like [ebiten.IsKeyPressed].
+func IsKeyPressed(key ebiten.Key) bool {
+	r := theReplay
+	if r == nil {
+		return ebiten.IsKeyPressed(key)
+	}
+	return r.keyState(key).pressed()
+}
+
+// IsKeyJustPressed is like [inpututil.IsKeyJustPressed].
+func IsKeyJustPressed(key ebiten.Key) bool {
+	r := theReplay
+	if r == nil {
+		return inpututil.IsKeyJustPressed(key)
+	}
+	return r.justPressed(r.keyState(key))
+}
+
+// KeyPressDuration is like [inpututil.KeyPressDuration].
+func KeyPressDuration(key ebiten.Key) int {
+	r := theReplay
+	if r == nil {
+		return inpututil.KeyPressDuration(key)
+	}
+	return r.duration(r.keyState(key))
+}
+
+// AppendPressedKeys is like [inpututil.AppendPressedKeys].
+func AppendPressedKeys(keys []ebiten.Key) []ebiten.Key {
+	r := theReplay
+	if r == nil {
+		return inpututil.AppendPressedKeys(keys)
+	}
+	return appendSorted(keys, r.keys, pressState.pressed)
+}
+
+// AppendJustPressedKeys is like [inpututil.AppendJustPressedKeys].
+func AppendJustPressedKeys(keys []ebiten.Key) []ebiten.Key {
+	r := theReplay
+	if r == nil {
+		return inpututil.AppendJustPressedKeys(keys)
+	}
+	return appendSorted(keys, r.keys, r.justPressed)
+}
+
+// AppendJustReleasedKeys is like [inpututil.AppendJustReleasedKeys].
+func AppendJustReleasedKeys(keys []ebiten.Key) []ebiten.Key {
+	r := theReplay
+	if r == nil {
+		return inpututil.AppendJustReleasedKeys(keys)
+	}
+	return appendSorted(keys, r.keys, r.justReleased)
+}
+
+// AppendInputChars is like [ebiten.AppendInputChars].
+func AppendInputChars(runes []rune) []rune {
+	r := theReplay
+	if r == nil {
+		return ebiten.AppendInputChars(runes)
+	}
+	return append(runes, r.chars...)
+}
+
+// IsMouseButtonPressed is like [ebiten.IsMouseButtonPressed].
+func IsMouseButtonPressed(button ebiten.MouseButton) bool {
+	r := theReplay
+	if r == nil {
+		return ebiten.IsMouseButtonPressed(button)
+	}
+	return r.mouseButtons[button].pressed()
+}
+
+// IsMouseButtonJustPressed is like [inpututil.IsMouseButtonJustPressed].
+func IsMouseButtonJustPressed(button ebiten.MouseButton) bool {
+	r := theReplay
+	if r == nil {
+		return inpututil.IsMouseButtonJustPressed(button)
+	}
+	return r.justPressed(r.mouseButtons[button])
+}
+
+// IsMouseButtonJustReleased is like [inpututil.IsMouseButtonJustReleased].
+func IsMouseButtonJustReleased(button ebiten.MouseButton) bool {
+	r := theReplay
+	if r == nil {
+		return inpututil.IsMouseButtonJustReleased(button)
+	}
+	return r.justReleased(r.mouseButtons[button])
+}
+
+// MouseButtonPressDuration is like [inpututil.MouseButtonPressDuration].
+func MouseButtonPressDuration(button ebiten.MouseButton) int {
+	r := theReplay
+	if r == nil {
+		return inpututil.MouseButtonPressDuration(button)
+	}
+	return r.duration(r.mouseButtons[button])
+}
+
+// CursorPosition is like [ebiten.CursorPosition].
+func CursorPosition() (x, y int) {
+	r := theReplay
+	if r == nil {
+		return ebiten.CursorPosition()
+	}
+	return r.toScreen(r.cursorX), r.toScreen(r.cursorY)
+}
+
+// Wheel is like [ebiten.Wheel].
+func Wheel() (xoff, yoff float64) {
+	r := theReplay
+	if r == nil {
+		return ebiten.Wheel()
+	}
+	return r.wheelX, r.wheelY
+}
+
+// AppendTouchIDs is like [ebiten.AppendTouchIDs].
+func AppendTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	r := theReplay
+	if r == nil {
+		return ebiten.AppendTouchIDs(touches)
+	}
+	return appendSorted(touches, r.touches, touchState.pressed)
+}
+
+// AppendJustPressedTouchIDs is like [inpututil.AppendJustPressedTouchIDs].
+func AppendJustPressedTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	r := theReplay
+	if r == nil {
+		return inpututil.AppendJustPressedTouchIDs(touches)
+	}
+	return appendSorted(touches, r.touches, func(t touchState) bool {
+		return r.justPressed(t.pressState)
+	})
+}
+
+// AppendJustReleasedTouchIDs is like [inpututil.AppendJustReleasedTouchIDs].
+func AppendJustReleasedTouchIDs(touches []ebiten.TouchID) []ebiten.TouchID {
+	r := theReplay
+	if r == nil {
+		return inpututil.AppendJustReleasedTouchIDs(touches)
+	}
+	return appendSorted(touches, r.touches, func(t touchState) bool {
+		return r.justReleased(t.pressState)
+	})
+}
+
+// TouchPosition is like [ebiten.TouchPosition].
+func TouchPosition(id ebiten.TouchID) (x, y int) {
+	r := theReplay
+	if r == nil {
+		return ebiten.TouchPosition(id)
+	}
+	t, ok := r.touches[id]
+	if !ok || !t.pressed() {
+		return 0, 0
+	}
+	return r.toScreen(t.x), r.toScreen(t.y)
+}
+
+// TouchPressDuration is like [inpututil.TouchPressDuration].
+func TouchPressDuration(id ebiten.TouchID) int {
+	r := theReplay
+	if r == nil {
+		return inpututil.TouchPressDuration(id)
+	}
+	return r.duration(r.touches[id].pressState)
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package inputsource_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui/inputrecord"
+	"github.com/guigui-gui/guigui/internal/inputsource"
+)
+
+func startReplay(t *testing.T, states []inputrecord.State) {
+	t.Helper()
+	var buf bytes.Buffer
+	r := inputrecord.NewRecorder(&buf)
+	for i := range states {
+		if err := r.Record(int64(i+1), &states[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	p, err := inputrecord.NewPlayer(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputsource.StartReplay(p)
+	t.Cleanup(func() {
+		for inputsource.IsReplaying() {
+			inputsource.Step(1)
+		}
+	})
+}
+
+func TestReplayKeys(t *testing.T) {
+	startReplay(t, []inputrecord.State{
+		{Width: 640, Height: 480},
+		{Width: 640, Height: 480, Keys: []ebiten.Key{ebiten.KeyA, ebiten.KeyShiftLeft}, Chars: []rune("A")},
+		{Width: 640, Height: 480, Keys: []ebiten.Key{ebiten.KeyA, ebiten.KeyShiftLeft}},
+		{Width: 640, Height: 480, Keys: []ebiten.Key{ebiten.KeyShiftLeft}},
+		{Width: 640, Height: 480},
+	})
+
+	testCases := []struct {
+		pressed      bool
+		justPressed  bool
+		duration     int
+		shiftPressed bool
+		justReleased []ebiten.Key
+		chars        string
+	}{
+		{},
+		{pressed: true, justPressed: true, duration: 1, shiftPressed: true, chars: "A"},
+		{pressed: true, duration: 2, shiftPressed: true},
+		{shiftPressed: true, justReleased: []ebiten.Key{ebiten.KeyA}},
+		{justReleased: []ebiten.Key{ebiten.KeyShiftLeft}},
+	}
+	for i, tc := range testCases {
+		if _, _, ok := inputsource.Step(1); !ok {
+			t.Fatalf("Step at tick %d: got: false, want: true", i+1)
+		}
+		if got, want := inputsource.ReplayedTick(), int64(i+1); got != want {
+			t.Errorf("ReplayedTick: got: %d, want: %d", got, want)
+		}
+		if got := inputsource.IsKeyPressed(ebiten.KeyA); got != tc.pressed {
+			t.Errorf("IsKeyPressed(KeyA) at tick %d: got: %t, want: %t", i+1, got, tc.pressed)
+		}
+		if got := inputsource.IsKeyJustPressed(ebiten.KeyA); got != tc.justPressed {
+			t.Errorf("IsKeyJustPressed(KeyA) at tick %d: got: %t, want: %t", i+1, got, tc.justPressed)
+		}
+		if got := inputsource.KeyPressDuration(ebiten.KeyA); got != tc.duration {
+			t.Errorf("KeyPressDuration(KeyA) at tick %d: got: %d, want: %d", i+1, got, tc.duration)
+		}
+		// KeyShift stands for the both shift keys.
+		if got := inputsource.IsKeyPressed(ebiten.KeyShift); got != tc.shiftPressed {
+			t.Errorf("IsKeyPressed(KeyShift) at tick %d: got: %t, want: %t", i+1, got, tc.shiftPressed)
+		}
+		if got := inputsource.AppendJustReleasedKeys(nil); !slices.Equal(got, tc.justReleased) {
+			t.Errorf("AppendJustReleasedKeys at tick %d: got: %v, want: %v", i+1, got, tc.justReleased)
+		}
+		if got := string(inputsource.AppendInputChars(nil)); got != tc.chars {
+			t.Errorf("AppendInputChars at tick %d: got: %q, want: %q", i+1, got, tc.chars)
+		}
+	}
+
+	// After the recording ends, the replay ends.
+	if _, _, ok := inputsource.Step(1); ok {
+		t.Errorf("Step after the recording: got: true, want: false")
+	}
+	if inputsource.IsReplaying() {
+		t.Errorf("IsReplaying after the recording: got: true, want: false")
+	}
+	if got := inputsource.ReplayedTick(); got != 0 {
+		t.Errorf("ReplayedTick after the recording: got: %d, want: 0", got)
+	}
+}
+
+func TestReplayPointing(t *testing.T) {
+	startReplay(t, []inputrecord.State{
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20},
+		{Width: 640, Height: 480, CursorX: 10.5, CursorY: 20, MouseButtons: []ebiten.MouseButton{ebiten.MouseButtonLeft}, WheelY: -1},
+		{Width: 800, Height: 600, CursorX: 10.5, CursorY: 20, Touches: []inputrecord.Touch{{ID: 1, X: 3, Y: 4}}},
+	})
+
+	// The positions are scaled by the device scale factor.
+	w, h, ok := inputsource.Step(2)
+	if !ok {
+		t.Fatalf("Step: got: false, want: true")
+	}
+	if w != 640 || h != 480 {
+		t.Errorf("Step size: got: (%g, %g), want: (640, 480)", w, h)
+	}
+	if x, y := inputsource.CursorPosition(); x != 21 || y != 40 {
+		t.Errorf("CursorPosition: got: (%d, %d), want: (21, 40)", x, y)
+	}
+
+	inputsource.Step(2)
+	if !inputsource.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		t.Errorf("IsMouseButtonJustPressed: got: false, want: true")
+	}
+	if x, y := inputsource.Wheel(); x != 0 || y != -1 {
+		t.Errorf("Wheel: got: (%g, %g), want: (0, -1)", x, y)
+	}
+
+	w, h, _ = inputsource.Step(2)
+	if w != 800 || h != 600 {
+		t.Errorf("Step size: got: (%g, %g), want: (800, 600)", w, h)
+	}
+	if !inputsource.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		t.Errorf("IsMouseButtonJustReleased: got: false, want: true")
+	}
+	// The wheel is the movement in the tick.
+	if x, y := inputsource.Wheel(); x != 0 || y != 0 {
+		t.Errorf("Wheel: got: (%g, %g), want: (0, 0)", x, y)
+	}
+	if got, want := inputsource.AppendJustPressedTouchIDs(nil), []ebiten.TouchID{1}; !slices.Equal(got, want) {
+		t.Errorf("AppendJustPressedTouchIDs: got: %v, want: %v", got, want)
+	}
+	if x, y := inputsource.TouchPosition(1); x != 6 || y != 8 {
+		t.Errorf("TouchPosition: got: (%d, %d), want: (6, 8)", x, y)
+	}
+}
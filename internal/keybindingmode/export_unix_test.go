@@ -5,20 +5,4 @@
 
 package keybindingmode
 
-type KeyThemeSource = keyThemeSource
-
-const (
-	KeyThemeSourceNone     = keyThemeSourceNone
-	KeyThemeSourceGNOME    = keyThemeSourceGNOME
-	KeyThemeSourceCinnamon = keyThemeSourceCinnamon
-	KeyThemeSourceMATE     = keyThemeSourceMATE
-	KeyThemeSourceXfce     = keyThemeSourceXfce
-)
-
-var (
-	ModeForKeyTheme          = modeForKeyTheme
-	KeyThemeSourceForDesktop = keyThemeSourceForDesktop
-	UnquoteGSettingsString   = unquoteGSettingsString
-	KeyThemeInGTKSettings    = keyThemeInGTKSettings
-	GTKSettingsFileKeyTheme  = gtkSettingsFileKeyTheme
-)
+var ModeForKeyTheme = modeForKeyTheme
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package dispatchevent defines an Analyzer that checks for DispatchEvent calls mismatching the event handlers.
+package dispatchevent
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/guigui-gui/guigui/internal/vettool/internal/guiguiutil"
+)
+
+const Doc = `check for DispatchEvent calls mismatching the event handlers
+
+guigui.DispatchEvent calls the handler registered by guigui.SetEventHandler via reflection,
+so passing arguments that do not match the handler's parameters panics only at run time.
+This checks the arguments against the handlers registered for the same event key variable in the package.
+
+Type-safe event keys such as guigui.EventKey1 are checked by the compiler and are not covered.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "dispatchevent",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	// Collect the handler signatures first, as a handler might be registered after the dispatching code.
+	handlers := map[*types.Var][]*types.Signature{}
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		if !guiguiutil.IsFunc(pass.TypesInfo, call, "SetEventHandler") || len(call.Args) != 3 {
+			return
+		}
+		key := eventKeyVar(pass, call.Args[1])
+		if key == nil {
+			return
+		}
+		sig, ok := pass.TypesInfo.TypeOf(call.Args[2]).Underlying().(*types.Signature)
+		if !ok {
+			return
+		}
+		if sig.Params().Len() == 0 || !guiguiutil.IsPointerToNamed(sig.Params().At(0).Type(), "Context") {
+			pass.ReportRangef(call.Args[2], "event handler must take *guigui.Context as the first parameter")
+			return
+		}
+		handlers[key] = append(handlers[key], sig)
+	})
+
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		if !guiguiutil.IsFunc(pass.TypesInfo, call, "DispatchEvent") || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+			return
+		}
+		key := eventKeyVar(pass, call.Args[1])
+		if key == nil {
+			return
+		}
+		args := call.Args[2:]
+		for _, arg := range args {
+			if tv, ok := pass.TypesInfo.Types[arg]; ok && tv.IsNil() {
+				pass.ReportRangef(arg, "nil cannot be passed to DispatchEvent; use a typed nil value")
+				return
+			}
+		}
+		sigs := handlers[key]
+		if len(sigs) == 0 {
+			return
+		}
+		var mismatch string
+		for _, sig := range sigs {
+			m := mismatchOf(pass, sig, args)
+			if m == "" {
+				return
+			}
+			if mismatch == "" {
+				mismatch = m
+			}
+		}
+		pass.ReportRangef(call, "DispatchEvent with %s does not match the handler %s: %s", key.Name(), sigs[0], mismatch)
+	})
+	return nil, nil
+}
+
+// eventKeyVar returns the variable of an event key expression such as eventKey or pkg.EventKey.
+func eventKeyVar(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	var id *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return nil
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || v.IsField() || !guiguiutil.IsNamed(v.Type(), "EventKey") {
+		return nil
+	}
+	return v
+}
+
+// mismatchOf returns a description of how args mismatch the handler signature sig.
+// mismatchOf returns an empty string if args match sig.
+func mismatchOf(pass *analysis.Pass, sig *types.Signature, args []ast.Expr) string {
+	params := sig.Params()
+	n := params.Len() - 1
+	if sig.Variadic() {
+		if len(args) < n-1 {
+			return fmt.Sprintf("%d arguments for at least %d parameters", len(args), n-1)
+		}
+	} else if len(args) != n {
+		return fmt.Sprintf("%d arguments for %d parameters", len(args), n)
+	}
+	var mismatches []string
+	for i, arg := range args {
+		var param types.Type
+		if sig.Variadic() && i >= n-1 {
+			param = params.At(n).Type().(*types.Slice).Elem()
+		} else {
+			param = params.At(i + 1).Type()
+		}
+		// An untyped constant is passed with its default type, as the parameter of DispatchEvent is any.
+		argType := types.Default(pass.TypesInfo.TypeOf(arg))
+		if argType == nil || types.AssignableTo(argType, param) {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("argument %d has type %s but the parameter has type %s", i+1, argType, param))
+	}
+	return strings.Join(mismatches, ", ")
+}
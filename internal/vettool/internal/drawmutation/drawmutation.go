@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package drawmutation defines an Analyzer that checks for widget fields mutated in Draw.
+package drawmutation
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/guigui-gui/guigui/internal/vettool/internal/guiguiutil"
+)
+
+const Doc = `check for widget fields mutated in Draw
+
+Draw must only render the widget. Draw is not called when the widget's region is not redrawn,
+so state changed in Draw is not observed reliably, and such a change does not trigger a rebuild or a redraw.
+Change the state in Build, Tick or an event handler instead.
+
+Only fields also used in Build, Layout, Measure or WriteStateKey are reported.
+A field used only in Draw, such as a cache of a rendered image, can be mutated in Draw.`
+
+var Analyzer = &analysis.Analyzer{
+	Name: "drawmutation",
+	Doc:  Doc,
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, methods := range guiguiutil.WidgetMethods(pass) {
+		draw, ok := methods["Draw"]
+		if !ok {
+			continue
+		}
+		stateFields := map[*types.Var]bool{}
+		for _, name := range []string{"Build", "Layout", "Measure", "WriteStateKey"} {
+			if m, ok := methods[name]; ok {
+				for f := range guiguiutil.ReceiverFieldsUsed(pass.TypesInfo, m) {
+					stateFields[f] = true
+				}
+			}
+		}
+		for _, m := range guiguiutil.FieldMutations(pass.TypesInfo, draw) {
+			if !stateFields[m.Field] {
+				continue
+			}
+			pass.ReportRangef(m.Expr, "%s is mutated in Draw but is used in Build, Layout, Measure or WriteStateKey", types.ExprString(m.Expr))
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package guiguiutil provides helpers shared by the Guigui analyzers.
+package guiguiutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// PkgPath is the import path of the guigui package.
+const PkgPath = "github.com/guigui-gui/guigui"
+
+// IsNamed reports whether t is the named type guigui.name.
+func IsNamed(t types.Type, name string) bool {
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == PkgPath && obj.Name() == name
+}
+
+// IsPointerToNamed reports whether t is a pointer to the named type guigui.name.
+func IsPointerToNamed(t types.Type, name string) bool {
+	p, ok := types.Unalias(t).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	return IsNamed(p.Elem(), name)
+}
+
+// IsFunc reports whether call calls the package-level function guigui.name.
+func IsFunc(info *types.Info, call *ast.CallExpr, name string) bool {
+	f := typeutil.StaticCallee(info, call)
+	if f == nil || f.Pkg() == nil || f.Pkg().Path() != PkgPath || f.Name() != name {
+		return false
+	}
+	return f.Signature().Recv() == nil
+}
+
+// IsMethod reports whether call calls the method (*guigui.typeName).name.
+func IsMethod(info *types.Info, call *ast.CallExpr, typeName, name string) bool {
+	f := typeutil.StaticCallee(info, call)
+	if f == nil || f.Name() != name {
+		return false
+	}
+	recv := f.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+	return IsPointerToNamed(recv.Type(), typeName) || IsNamed(recv.Type(), typeName)
+}
+
+// WidgetMethod is a method of a widget type declared in the package being analyzed.
+type WidgetMethod struct {
+	Decl *ast.FuncDecl
+
+	// Recv is the receiver variable, or nil if the receiver is unnamed.
+	Recv *types.Var
+}
+
+// WidgetMethods returns the methods of the widget interface declared in the package, keyed by the receiver type and the method name.
+//
+// A method is regarded as a widget method when its name is one of the [guigui.Widget] methods
+// and its first parameter is *guigui.Context.
+func WidgetMethods(pass *analysis.Pass) map[*types.TypeName]map[string]WidgetMethod {
+	methods := map[*types.TypeName]map[string]WidgetMethod{}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 || fd.Body == nil {
+				continue
+			}
+			switch fd.Name.Name {
+			case "Build", "Layout", "HandlePointingInput", "HandleButtonInput", "Tick", "CursorShape", "Draw", "Measure", "WriteStateKey", "Env":
+			default:
+				continue
+			}
+			f, ok := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			sig := f.Signature()
+			if sig.Params().Len() == 0 || !IsPointerToNamed(sig.Params().At(0).Type(), "Context") {
+				continue
+			}
+			tn := ReceiverTypeName(sig.Recv().Type())
+			if tn == nil {
+				continue
+			}
+			var recv *types.Var
+			if names := fd.Recv.List[0].Names; len(names) > 0 && names[0].Name != "_" {
+				recv, _ = pass.TypesInfo.Defs[names[0]].(*types.Var)
+			}
+			if methods[tn] == nil {
+				methods[tn] = map[string]WidgetMethod{}
+			}
+			methods[tn][fd.Name.Name] = WidgetMethod{
+				Decl: fd,
+				Recv: recv,
+			}
+		}
+	}
+	return methods
+}
+
+// ReceiverTypeName returns the type name of a receiver type T or *T.
+func ReceiverTypeName(t types.Type) *types.TypeName {
+	if p, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return nil
+	}
+	return n.Origin().Obj()
+}
+
+// ReceiverField returns the field of recv that expr is rooted at.
+// For example, ReceiverField returns the field foo for &w.foo, w.foo.bar, w.foo[i] and w.foo.At(i), where w is recv.
+// The returned field is the origin field for a generic type.
+// ReceiverField returns nil if expr is not rooted at a field of recv.
+func ReceiverField(info *types.Info, recv *types.Var, expr ast.Expr) *types.Var {
+	if recv == nil {
+		return nil
+	}
+	var field *types.Var
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.UnaryExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.SliceExpr:
+			expr = e.X
+		case *ast.CallExpr:
+			expr = e.Fun
+		case *ast.SelectorExpr:
+			if sel, ok := info.Selections[e]; ok && sel.Kind() == types.FieldVal {
+				if v, ok := sel.Obj().(*types.Var); ok {
+					// Use the origin so that the fields of a generic type match across methods.
+					field = v.Origin()
+				}
+			}
+			expr = e.X
+		case *ast.Ident:
+			if info.Uses[e] != recv {
+				return nil
+			}
+			return field
+		default:
+			return nil
+		}
+	}
+}
+
+// ReceiverFieldsUsed returns the fields of the receiver used in the method body.
+//
+// Taking the address of a field, such as &w.button passed to [guigui.ChildAdder.AddWidget],
+// is not regarded as a use, as it refers to the field's identity rather than its state.
+func ReceiverFieldsUsed(info *types.Info, m WidgetMethod) map[*types.Var]bool {
+	fields := map[*types.Var]bool{}
+	if m.Recv == nil {
+		return fields
+	}
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.UnaryExpr:
+			if n.Op != token.AND {
+				return true
+			}
+			// Visit only the indices in the operand, such as i in &w.items[i].
+			for x := n.X; ; {
+				switch e := x.(type) {
+				case *ast.ParenExpr:
+					x = e.X
+					continue
+				case *ast.SelectorExpr:
+					x = e.X
+					continue
+				case *ast.IndexExpr:
+					ast.Inspect(e.Index, visit)
+					x = e.X
+					continue
+				case *ast.Ident:
+				default:
+					ast.Inspect(x, visit)
+				}
+				break
+			}
+			return false
+		case *ast.SelectorExpr:
+			if field := ReceiverField(info, m.Recv, n); field != nil {
+				fields[field] = true
+			}
+		}
+		return true
+	}
+	ast.Inspect(m.Decl.Body, visit)
+	return fields
+}
+
+// FieldMutation is an assignment, an increment or a decrement to a field of a receiver.
+type FieldMutation struct {
+	// Expr is the mutated expression, such as w.foo or w.foo[i].
+	Expr ast.Expr
+
+	// Field is the field of the receiver that Expr is rooted at.
+	Field *types.Var
+}
+
+// FieldMutations returns the mutations to the fields of the receiver in the method body.
+// Mutations in function literals are included.
+func FieldMutations(info *types.Info, m WidgetMethod) []FieldMutation {
+	if m.Recv == nil {
+		return nil
+	}
+	var mutations []FieldMutation
+	add := func(expr ast.Expr) {
+		// Assigning to a local variable derived from the receiver, such as a pointer, is not tracked.
+		if _, ok := ast.Unparen(expr).(*ast.Ident); ok {
+			return
+		}
+		if field := ReceiverField(info, m.Recv, expr); field != nil {
+			mutations = append(mutations, FieldMutation{
+				Expr:  expr,
+				Field: field,
+			})
+		}
+	}
+	ast.Inspect(m.Decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				add(lhs)
+			}
+		case *ast.IncDecStmt:
+			add(n.X)
+		}
+		return true
+	})
+	return mutations
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package missingrebuild defines an Analyzer that checks for widget state changed in Tick without a rebuild or a redraw.
+package missingrebuild
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/guigui-gui/guigui/internal/vettool/internal/guiguiutil"
+)
+
+const Doc = `check for widget state changed in Tick without a rebuild or a redraw
+
+Unlike an input or event handler, Tick does not trigger a rebuild by itself.
+A field changed in Tick must be written in WriteStateKey, or Tick must call guigui.RequestRebuild
+when the field is used in Build, Layout or Measure, or guigui.RequestRedraw when the field is used only in Draw.
+Otherwise, the change is not reflected on the screen until something else triggers a rebuild.
+
+To avoid false positives, a Tick is not checked when it changes any field written in WriteStateKey
+or calls a method whose name starts with Set, such as a child's SetOpen, as such a change already triggers a rebuild.
+A rebuild is always of the whole tree, so the other changes in the same Tick are reflected too.`
+
+var Analyzer = &analysis.Analyzer{
+	Name: "missingrebuild",
+	Doc:  Doc,
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, methods := range guiguiutil.WidgetMethods(pass) {
+		tick, ok := methods["Tick"]
+		if !ok {
+			continue
+		}
+		mutations := guiguiutil.FieldMutations(pass.TypesInfo, tick)
+		if len(mutations) == 0 {
+			continue
+		}
+
+		var keyFields map[*types.Var]bool
+		if key, ok := methods["WriteStateKey"]; ok {
+			if writesStruct(pass, key) {
+				continue
+			}
+			keyFields = guiguiutil.ReceiverFieldsUsed(pass.TypesInfo, key)
+		}
+		buildFields := map[*types.Var]bool{}
+		for _, name := range []string{"Build", "Layout", "Measure"} {
+			if m, ok := methods[name]; ok {
+				for f := range guiguiutil.ReceiverFieldsUsed(pass.TypesInfo, m) {
+					buildFields[f] = true
+				}
+			}
+		}
+		drawFields := map[*types.Var]bool{}
+		if m, ok := methods["Draw"]; ok {
+			drawFields = guiguiutil.ReceiverFieldsUsed(pass.TypesInfo, m)
+		}
+		rebuilds, redraws, setters := requests(pass, tick)
+		if setters || slices.ContainsFunc(mutations, func(m guiguiutil.FieldMutation) bool {
+			return keyFields[m.Field]
+		}) {
+			continue
+		}
+
+		reported := map[*types.Var]bool{}
+		for _, m := range mutations {
+			if reported[m.Field] {
+				continue
+			}
+			switch {
+			case buildFields[m.Field] && !rebuilds:
+				pass.ReportRangef(m.Expr, "%s is changed in Tick and used in Build, Layout or Measure, but is not written in WriteStateKey and guigui.RequestRebuild is not called", types.ExprString(m.Expr))
+				reported[m.Field] = true
+			case drawFields[m.Field] && !buildFields[m.Field] && !rebuilds && !redraws:
+				pass.ReportRangef(m.Expr, "%s is changed in Tick and used in Draw, but is not written in WriteStateKey and guigui.RequestRedraw is not called", types.ExprString(m.Expr))
+				reported[m.Field] = true
+			}
+		}
+	}
+	return nil, nil
+}
+
+// writesStruct reports whether the WriteStateKey method writes the fields via StateKeyWriter.WriteStruct.
+func writesStruct(pass *analysis.Pass, key guiguiutil.WidgetMethod) bool {
+	var found bool
+	ast.Inspect(key.Decl.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && guiguiutil.IsMethod(pass.TypesInfo, call, "StateKeyWriter", "WriteStruct") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// requests reports whether the method calls guigui.RequestRebuild, guigui.RequestRedraw, and a setter method.
+func requests(pass *analysis.Pass, m guiguiutil.WidgetMethod) (rebuild, redraw, setter bool) {
+	ast.Inspect(m.Decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if guiguiutil.IsFunc(pass.TypesInfo, call, "RequestRebuild") {
+			rebuild = true
+		}
+		if guiguiutil.IsFunc(pass.TypesInfo, call, "RequestRedraw") {
+			redraw = true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Set") {
+			if s, ok := pass.TypesInfo.Selections[sel]; ok && s.Kind() == types.MethodVal {
+				setter = true
+			}
+		}
+		return true
+	})
+	return rebuild, redraw, setter
+}
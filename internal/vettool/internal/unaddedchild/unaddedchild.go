@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package unaddedchild defines an Analyzer that checks for child widgets laid out without being added.
+package unaddedchild
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/guigui-gui/guigui/internal/vettool/internal/guiguiutil"
+)
+
+const Doc = `check for child widgets laid out without being added in Build
+
+A widget passed to ChildLayouter.LayoutWidget in Layout must be added by ChildAdder.AddWidget in Build.
+Otherwise, the widget is not in the tree and the layout is ignored.
+
+The check covers widgets stored in the receiver's fields, such as &w.button or w.items.At(i).
+A widget type whose Build passes the ChildAdder to another function is not checked,
+as the added widgets cannot be determined.`
+
+var Analyzer = &analysis.Analyzer{
+	Name: "unaddedchild",
+	Doc:  Doc,
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, methods := range guiguiutil.WidgetMethods(pass) {
+		build, ok := methods["Build"]
+		if !ok {
+			continue
+		}
+		layout, ok := methods["Layout"]
+		if !ok || layout.Recv == nil {
+			continue
+		}
+		added, ok := addedFields(pass, build)
+		if !ok {
+			continue
+		}
+		ast.Inspect(layout.Decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if !guiguiutil.IsMethod(pass.TypesInfo, call, "ChildLayouter", "LayoutWidget") {
+				return true
+			}
+			field := guiguiutil.ReceiverField(pass.TypesInfo, layout.Recv, call.Args[0])
+			if field == nil || added[field] {
+				return true
+			}
+			pass.ReportRangef(call.Args[0], "%s is laid out but not added in Build", types.ExprString(call.Args[0]))
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// addedFields returns the receiver fields whose widgets are added in build.
+// addedFields returns false if the added widgets cannot be determined.
+func addedFields(pass *analysis.Pass, build guiguiutil.WidgetMethod) (map[*types.Var]bool, bool) {
+	params := build.Decl.Type.Params.List
+	if len(params) < 2 || len(params[1].Names) == 0 {
+		return nil, false
+	}
+	adder, ok := pass.TypesInfo.Defs[params[1].Names[0]].(*types.Var)
+	if !ok {
+		return nil, false
+	}
+
+	added := map[*types.Var]bool{}
+	determined := true
+	ast.Inspect(build.Decl.Body, func(n ast.Node) bool {
+		if !determined {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if guiguiutil.IsMethod(pass.TypesInfo, call, "ChildAdder", "AddWidget") && len(call.Args) > 0 {
+			if field := guiguiutil.ReceiverField(pass.TypesInfo, build.Recv, call.Args[0]); field != nil {
+				added[field] = true
+			}
+			return true
+		}
+		// The adder escapes to another function. Give up.
+		for _, arg := range call.Args {
+			if id, ok := ast.Unparen(arg).(*ast.Ident); ok && pass.TypesInfo.Uses[id] == adder {
+				determined = false
+				return false
+			}
+		}
+		return true
+	})
+	if !determined {
+		return nil, false
+	}
+	return added, true
+}
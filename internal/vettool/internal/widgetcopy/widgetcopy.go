@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package widgetcopy defines an Analyzer that checks for widgets passed by value.
+package widgetcopy
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/guigui-gui/guigui/internal/vettool/internal/guiguiutil"
+)
+
+const Doc = `check for widgets passed by value
+
+A struct that embeds guigui.DefaultWidget must not be copied after its first use,
+as the copy shares nothing with the widget tree. The copy is detected only at run time
+by a panic. Refer to such a struct through a pointer instead.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "widgetcopy",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.GenDecl)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.ReturnStmt)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			for i, x := range node.Rhs {
+				if path := widgetPathRhs(pass, x); path != nil && i < len(node.Lhs) {
+					pass.ReportRangef(x, "assignment copies widget value to %s: %s", types.ExprString(node.Lhs[i]), path)
+				}
+			}
+		case *ast.CallExpr:
+			if isTypeOnlyCall(pass, node) {
+				return
+			}
+			for _, x := range node.Args {
+				if path := widgetPathRhs(pass, x); path != nil {
+					pass.ReportRangef(x, "call of %s copies widget value: %s", types.ExprString(node.Fun), path)
+				}
+			}
+		case *ast.CompositeLit:
+			for _, x := range node.Elts {
+				if kv, ok := x.(*ast.KeyValueExpr); ok {
+					x = kv.Value
+				}
+				if path := widgetPathRhs(pass, x); path != nil {
+					pass.ReportRangef(x, "literal copies widget value from %s: %s", types.ExprString(x), path)
+				}
+			}
+		case *ast.FuncDecl:
+			checkFunc(pass, node.Name.Name, node.Recv, node.Type)
+		case *ast.FuncLit:
+			checkFunc(pass, "func", nil, node.Type)
+		case *ast.GenDecl:
+			if node.Tok != token.VAR {
+				return
+			}
+			for _, spec := range node.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, x := range vs.Values {
+					if path := widgetPathRhs(pass, x); path != nil && i < len(vs.Names) {
+						pass.ReportRangef(x, "variable declaration copies widget value to %s: %s", vs.Names[i].Name, path)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			checkRangeVar(pass, node.Tok, node.Key)
+			checkRangeVar(pass, node.Tok, node.Value)
+		case *ast.ReturnStmt:
+			for _, x := range node.Results {
+				if path := widgetPathRhs(pass, x); path != nil {
+					pass.ReportRangef(x, "return copies widget value: %s", path)
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+// isTypeOnlyCall reports whether call uses its arguments only for their types, such as len.
+func isTypeOnlyCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	var id *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	}
+	if fun, ok := pass.TypesInfo.Uses[id].(*types.Builtin); ok {
+		switch fun.Name() {
+		case "len", "cap", "Sizeof", "Offsetof", "Alignof":
+			return true
+		}
+	}
+	return false
+}
+
+func checkFunc(pass *analysis.Pass, name string, recv *ast.FieldList, typ *ast.FuncType) {
+	if recv != nil && len(recv.List) > 0 {
+		expr := recv.List[0].Type
+		if path := widgetPath(pass.TypesInfo.Types[expr].Type, nil); path != nil {
+			pass.ReportRangef(expr, "%s passes widget by value: %s", name, path)
+		}
+	}
+	if typ.Params != nil {
+		for _, field := range typ.Params.List {
+			expr := field.Type
+			if path := widgetPath(pass.TypesInfo.Types[expr].Type, nil); path != nil {
+				pass.ReportRangef(expr, "%s passes widget by value: %s", name, path)
+			}
+		}
+	}
+}
+
+func checkRangeVar(pass *analysis.Pass, tok token.Token, e ast.Expr) {
+	if e == nil {
+		return
+	}
+	id, isIdent := e.(*ast.Ident)
+	if isIdent && id.Name == "_" {
+		return
+	}
+	var typ types.Type
+	if tok == token.DEFINE {
+		if !isIdent {
+			return
+		}
+		obj := pass.TypesInfo.Defs[id]
+		if obj == nil {
+			return
+		}
+		typ = obj.Type()
+	} else {
+		typ = pass.TypesInfo.Types[e].Type
+	}
+	if path := widgetPath(typ, nil); path != nil {
+		pass.ReportRangef(e, "range var %s copies widget value: %s", types.ExprString(e), path)
+	}
+}
+
+// typePath describes where a guigui.DefaultWidget is in a type, from the innermost to the outermost.
+type typePath []string
+
+func (p typePath) String() string {
+	var sb strings.Builder
+	for i := len(p) - 1; i >= 0; i-- {
+		sb.WriteString(p[i])
+		if i > 0 {
+			sb.WriteString(" contains ")
+		}
+	}
+	return sb.String()
+}
+
+func widgetPathRhs(pass *analysis.Pass, x ast.Expr) typePath {
+	x = ast.Unparen(x)
+	switch x := x.(type) {
+	case *ast.CompositeLit:
+		return nil
+	case *ast.CallExpr:
+		// A call may return a zero value.
+		return nil
+	case *ast.StarExpr:
+		if _, ok := ast.Unparen(x.X).(*ast.CallExpr); ok {
+			// A call may return a pointer to a zero value.
+			return nil
+		}
+	}
+	if tv, ok := pass.TypesInfo.Types[x]; ok && tv.IsValue() {
+		return widgetPath(tv.Type, nil)
+	}
+	return nil
+}
+
+// widgetPath returns a typePath describing the location of a guigui.DefaultWidget contained in typ.
+// widgetPath returns nil if typ contains no guigui.DefaultWidget by value.
+func widgetPath(typ types.Type, seen map[types.Type]bool) typePath {
+	if typ == nil || seen[typ] {
+		return nil
+	}
+	if seen == nil {
+		seen = map[types.Type]bool{}
+	}
+	seen[typ] = true
+
+	for {
+		a, ok := typ.Underlying().(*types.Array)
+		if !ok {
+			break
+		}
+		typ = a.Elem()
+	}
+
+	if guiguiutil.IsNamed(typ, "DefaultWidget") {
+		return typePath{typ.String()}
+	}
+
+	s, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := range s.NumFields() {
+		if path := widgetPath(s.Field(i).Type(), seen); path != nil {
+			return append(path, typ.String())
+		}
+	}
+	return nil
+}
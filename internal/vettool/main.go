@@ -6,8 +6,21 @@ package main
 import (
 	"github.com/kisielk/errcheck/errcheck"
 	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/guigui-gui/guigui/internal/vettool/internal/dispatchevent"
+	"github.com/guigui-gui/guigui/internal/vettool/internal/drawmutation"
+	"github.com/guigui-gui/guigui/internal/vettool/internal/missingrebuild"
+	"github.com/guigui-gui/guigui/internal/vettool/internal/unaddedchild"
+	"github.com/guigui-gui/guigui/internal/vettool/internal/widgetcopy"
 )
 
 func main() {
-	multichecker.Main(errcheck.Analyzer)
+	multichecker.Main(
+		errcheck.Analyzer,
+		dispatchevent.Analyzer,
+		drawmutation.Analyzer,
+		missingrebuild.Analyzer,
+		unaddedchild.Analyzer,
+		widgetcopy.Analyzer,
+	)
 }
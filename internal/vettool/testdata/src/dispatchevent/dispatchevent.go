@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package dispatchevent
+
+import (
+	"github.com/guigui-gui/guigui"
+)
+
+var (
+	eventValueChanged = guigui.GenerateEventKey()
+	eventItemsMoved   = guigui.GenerateEventKey()
+	eventVariadic     = guigui.GenerateEventKey()
+)
+
+type Foo struct {
+	guigui.DefaultWidget
+}
+
+func (f *Foo) OnValueChanged(f2 func(context *guigui.Context, value float64, committed bool)) {
+	guigui.SetEventHandler(f, eventValueChanged, f2)
+}
+
+func (f *Foo) OnItemsMoved(f2 func(context *guigui.Context, from, count, to int)) {
+	guigui.SetEventHandler(f, eventItemsMoved, f2)
+}
+
+func (f *Foo) OnVariadic(f2 func(context *guigui.Context, values ...string)) {
+	guigui.SetEventHandler(f, eventVariadic, f2)
+}
+
+func (f *Foo) setBadHandler() {
+	guigui.SetEventHandler(f, eventValueChanged, func(value float64) {}) // want "event handler must take \\*guigui.Context as the first parameter"
+}
+
+func (f *Foo) dispatch(value float64, count int, err error, values []any) {
+	guigui.DispatchEvent(f, eventValueChanged, value, true)
+	guigui.DispatchEvent(f, eventValueChanged, 1.0, false)
+	guigui.DispatchEvent(f, eventValueChanged, 1, true) // want "argument 1 has type int but the parameter has type float64"
+	guigui.DispatchEvent(f, eventValueChanged, value)   // want "1 arguments for 2 parameters"
+	guigui.DispatchEvent(f, eventItemsMoved, count, count, count)
+	guigui.DispatchEvent(f, eventItemsMoved, count, "a", count) // want "argument 2 has type string but the parameter has type int"
+	guigui.DispatchEvent(f, eventVariadic)
+	guigui.DispatchEvent(f, eventVariadic, "a", "b")
+	guigui.DispatchEvent(f, eventVariadic, "a", 1)        // want "argument 2 has type int but the parameter has type string"
+	guigui.DispatchEvent(f, eventValueChanged, nil, true) // want "nil cannot be passed to DispatchEvent"
+
+	// Spread arguments are not checked.
+	guigui.DispatchEvent(f, eventValueChanged, values...)
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package drawmutation
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type Foo struct {
+	guigui.DefaultWidget
+
+	count  int
+	values []int
+	cache  *ebiten.Image
+}
+
+func (f *Foo) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	_ = f.count
+	return nil
+}
+
+func (f *Foo) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	w.WriteInt(len(f.values))
+}
+
+func (f *Foo) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	f.count++       // want "f.count is mutated in Draw but is used in Build, Layout, Measure or WriteStateKey"
+	f.values[0] = 1 // want "f.values\\[0\\] is mutated in Draw"
+
+	// A field used only in Draw can be mutated.
+	if f.cache == nil {
+		f.cache = &ebiten.Image{}
+	}
+
+	// A local variable can be mutated.
+	count := f.count
+	count++
+	_ = count
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package guigui is a stub for the analyzer tests.
+package guigui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Context struct{}
+
+type WidgetBounds struct{}
+
+func (w *WidgetBounds) Bounds() image.Rectangle {
+	return image.Rectangle{}
+}
+
+type ChildAdder struct{}
+
+func (c *ChildAdder) AddWidget(widget Widget) {}
+
+type ChildLayouter struct{}
+
+func (c *ChildLayouter) LayoutWidget(widget Widget, bounds image.Rectangle) {}
+
+type StateKeyWriter struct{}
+
+func (w *StateKeyWriter) WriteBool(v bool)  {}
+func (w *StateKeyWriter) WriteInt(v int)    {}
+func (w *StateKeyWriter) WriteStruct(v any) {}
+
+type Widget interface {
+	Build(context *Context, adder *ChildAdder) error
+	Layout(context *Context, widgetBounds *WidgetBounds, layouter *ChildLayouter)
+	Tick(context *Context, widgetBounds *WidgetBounds) error
+	Draw(context *Context, widgetBounds *WidgetBounds, dst *ebiten.Image)
+	WriteStateKey(context *Context, w *StateKeyWriter)
+}
+
+type DefaultWidget struct {
+	addr *DefaultWidget
+}
+
+func (*DefaultWidget) Build(context *Context, adder *ChildAdder) error { return nil }
+func (*DefaultWidget) Layout(context *Context, widgetBounds *WidgetBounds, layouter *ChildLayouter) {
+}
+func (*DefaultWidget) Tick(context *Context, widgetBounds *WidgetBounds) error              { return nil }
+func (*DefaultWidget) Draw(context *Context, widgetBounds *WidgetBounds, dst *ebiten.Image) {}
+func (*DefaultWidget) WriteStateKey(context *Context, w *StateKeyWriter)                    {}
+
+type EventKey int64
+
+func GenerateEventKey() EventKey {
+	return 0
+}
+
+func SetEventHandler(widget Widget, eventKey EventKey, handler any) {}
+
+func DispatchEvent(widget Widget, eventKey EventKey, args ...any) ([]any, bool) {
+	return nil, false
+}
+
+func RequestRebuild() {}
+
+func RequestRedraw(widget Widget) {}
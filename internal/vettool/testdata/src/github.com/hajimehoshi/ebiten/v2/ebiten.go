@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+// Package ebiten is a stub for the analyzer tests.
+package ebiten
+
+type Image struct{}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package missingrebuild
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type Missing struct {
+	guigui.DefaultWidget
+
+	text  string
+	alpha float64
+	ticks int
+}
+
+func (m *Missing) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	_ = m.text
+	return nil
+}
+
+func (m *Missing) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	_ = m.alpha
+}
+
+func (m *Missing) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
+	m.text = "foo" // want "m.text is changed in Tick and used in Build, Layout or Measure, but is not written in WriteStateKey and guigui.RequestRebuild is not called"
+	m.alpha = 0.5  // want "m.alpha is changed in Tick and used in Draw, but is not written in WriteStateKey and guigui.RequestRedraw is not called"
+	m.ticks++      // Used nowhere else.
+	return nil
+}
+
+type Keyed struct {
+	guigui.DefaultWidget
+
+	text string
+}
+
+func (k *Keyed) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	_ = k.text
+	return nil
+}
+
+func (k *Keyed) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	w.WriteInt(len(k.text))
+}
+
+func (k *Keyed) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
+	k.text = "foo"
+	return nil
+}
+
+type Requested struct {
+	guigui.DefaultWidget
+
+	text  string
+	alpha float64
+}
+
+func (r *Requested) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	_ = r.text
+	return nil
+}
+
+func (r *Requested) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	_ = r.alpha
+}
+
+func (r *Requested) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
+	if r.text != "foo" {
+		r.text = "foo"
+		guigui.RequestRebuild()
+	}
+	r.alpha = 0.5
+	guigui.RequestRedraw(r)
+	return nil
+}
+
+type Struct struct {
+	guigui.DefaultWidget
+
+	Text string
+}
+
+func (s *Struct) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	_ = s.Text
+	return nil
+}
+
+func (s *Struct) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	w.WriteStruct(s)
+}
+
+func (s *Struct) Tick(context *guigui.Context, widgetBounds *guigui.WidgetBounds) error {
+	s.Text = "foo"
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package unaddedchild
+
+import (
+	"github.com/guigui-gui/guigui"
+)
+
+type Child struct {
+	guigui.DefaultWidget
+}
+
+type Children struct {
+	items []Child
+}
+
+func (c *Children) At(i int) *Child {
+	return &c.items[i]
+}
+
+type Foo struct {
+	guigui.DefaultWidget
+
+	added    Child
+	notAdded Child
+	children Children
+	items    []Child
+	other    guigui.Widget
+}
+
+func (f *Foo) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&f.added)
+	for i := range f.items {
+		adder.AddWidget(&f.items[i])
+	}
+	adder.AddWidget(f.children.At(0))
+	return nil
+}
+
+func (f *Foo) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	layouter.LayoutWidget(&f.added, widgetBounds.Bounds())
+	layouter.LayoutWidget(&f.notAdded, widgetBounds.Bounds()) // want "&f.notAdded is laid out but not added in Build"
+	layouter.LayoutWidget(f.children.At(0), widgetBounds.Bounds())
+	for i := range f.items {
+		layouter.LayoutWidget(&f.items[i], widgetBounds.Bounds())
+	}
+	layouter.LayoutWidget(f.other, widgetBounds.Bounds()) // want "f.other is laid out but not added in Build"
+
+	// A widget not in the receiver's fields is not checked.
+	var c Child
+	layouter.LayoutWidget(&c, widgetBounds.Bounds())
+}
+
+type Generic[T any] struct {
+	guigui.DefaultWidget
+
+	added    Child
+	notAdded Child
+}
+
+func (g *Generic[T]) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&g.added)
+	return nil
+}
+
+func (g *Generic[T]) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	layouter.LayoutWidget(&g.added, widgetBounds.Bounds())
+	layouter.LayoutWidget(&g.notAdded, widgetBounds.Bounds()) // want "&g.notAdded is laid out but not added in Build"
+}
+
+type Delegating struct {
+	guigui.DefaultWidget
+
+	child Child
+}
+
+func (d *Delegating) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	addChildren(d, adder)
+	return nil
+}
+
+func addChildren(d *Delegating, adder *guigui.ChildAdder) {
+	adder.AddWidget(&d.child)
+}
+
+func (d *Delegating) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	// The added widgets cannot be determined, so this is not reported.
+	layouter.LayoutWidget(&d.child, widgetBounds.Bounds())
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package widgetcopy
+
+import (
+	"github.com/guigui-gui/guigui"
+)
+
+type Foo struct {
+	guigui.DefaultWidget
+
+	value int
+}
+
+type Bar struct {
+	foo Foo
+}
+
+func byValue(foo Foo) {} // want "byValue passes widget by value: widgetcopy.Foo contains github.com/guigui-gui/guigui.DefaultWidget"
+
+func byPointer(foo *Foo) {}
+
+func (f Foo) valueMethod() {} // want "valueMethod passes widget by value"
+
+func newFoo() Foo {
+	return Foo{}
+}
+
+func copies(foo *Foo, bar *Bar, foos []Foo) {
+	f := *foo // want "assignment copies widget value to f: widgetcopy.Foo contains github.com/guigui-gui/guigui.DefaultWidget"
+	byPointer(&f)
+	b := *bar // want "assignment copies widget value to b: widgetcopy.Bar contains widgetcopy.Foo contains github.com/guigui-gui/guigui.DefaultWidget"
+	byPointer(&b.foo)
+	var v = bar.foo // want "variable declaration copies widget value to v"
+	byPointer(&v)
+	byPointer(foo)
+	byValue(*foo)            // want "call of byValue copies widget value"
+	_ = Bar{foo: *foo}       // want "literal copies widget value"
+	for _, f := range foos { // want "range var f copies widget value"
+		byPointer(&f)
+	}
+	for i := range foos {
+		byPointer(&foos[i])
+	}
+
+	// Zero values are fine.
+	_ = Foo{}
+	_ = newFoo()
+	_ = len(foos)
+}
+
+func returns(foo *Foo) Foo {
+	return *foo // want "return copies widget value"
+}
@@ -48,5 +48,5 @@ func (l *LayerWidget[T]) Layout(context *Context, widgetBounds *WidgetBounds, la
 
 // Measure implements [Widget.Measure].
 func (l *LayerWidget[T]) Measure(context *Context, constraints Constraints) image.Point {
-	return l.widget.Widget().Measure(context, constraints)
+	return measureWidget(context, l.widget.Widget(), constraints)
 }
@@ -66,13 +66,15 @@ const (
 
 // Padding represents the padding around a layout.
 type Padding struct {
-	// Start is the left padding in pixels. RTL mirroring is not yet supported.
+	// Start is the padding in pixels at the start of the layout: the left,
+	// or the right in a right-to-left reading direction.
 	Start int
 
 	// Top is the padding in pixels at the top of the layout.
 	Top int
 
-	// End is the right padding in pixels. RTL mirroring is not yet supported.
+	// End is the padding in pixels at the end of the layout: the right,
+	// or the left in a right-to-left reading direction.
 	End int
 
 	// Bottom is the padding in pixels at the bottom of the layout.
@@ -80,6 +82,11 @@ type Padding struct {
 }
 
 // LinearLayout arranges widgets in a linear fashion.
+//
+// LinearLayout is mirrored horizontally when the reading direction is right-to-left:
+// the items of a horizontal layout are arranged from right to left, and [Padding.Start] is at the right.
+// The reading direction is the one of the widget whose method such as [Widget.Layout] or [Widget.Draw] is being called
+// (see [Context.ReadingDirection]), or the one of the first locale outside the widget methods.
 type LinearLayout struct {
 	// Direction is the direction of the layout.
 	Direction LayoutDirection
@@ -188,15 +195,15 @@ func linearLayoutItemDefaultAlongSize(context *Context, direction LayoutDirectio
 		switch direction {
 		case LayoutDirectionHorizontal:
 			if acrossSize <= 0 {
-				s2 = item.Widget.Measure(context, Constraints{}).X
+				s2 = measureWidget(context, item.Widget, Constraints{}).X
 			} else {
-				s2 = item.Widget.Measure(context, FixedHeightConstraints(acrossSize)).X
+				s2 = measureWidget(context, item.Widget, FixedHeightConstraints(acrossSize)).X
 			}
 		case LayoutDirectionVertical:
 			if acrossSize <= 0 {
-				s2 = item.Widget.Measure(context, Constraints{}).Y
+				s2 = measureWidget(context, item.Widget, Constraints{}).Y
 			} else {
-				s2 = item.Widget.Measure(context, FixedWidthConstraints(acrossSize)).Y
+				s2 = measureWidget(context, item.Widget, FixedWidthConstraints(acrossSize)).Y
 			}
 		}
 	}
@@ -327,15 +334,15 @@ func (l LinearLayout) Measure(context *Context, constraints Constraints) image.P
 			switch l.Direction {
 			case LayoutDirectionHorizontal:
 				if s <= 0 {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, Constraints{}).Y)
+					autoAcrossSize = max(autoAcrossSize, measureWidget(context, item.Widget, Constraints{}).Y)
 				} else {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, FixedWidthConstraints(s)).Y)
+					autoAcrossSize = max(autoAcrossSize, measureWidget(context, item.Widget, FixedWidthConstraints(s)).Y)
 				}
 			case LayoutDirectionVertical:
 				if s <= 0 {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, Constraints{}).X)
+					autoAcrossSize = max(autoAcrossSize, measureWidget(context, item.Widget, Constraints{}).X)
 				} else {
-					autoAcrossSize = max(autoAcrossSize, item.Widget.Measure(context, FixedHeightConstraints(s)).X)
+					autoAcrossSize = max(autoAcrossSize, measureWidget(context, item.Widget, FixedHeightConstraints(s)).X)
 				}
 			}
 		} else if item.Layout != nil {
@@ -394,9 +401,16 @@ func (l *LinearLayout) appendWidgetBounds(boundsArr []image.Rectangle, context *
 		theLinearLayoutSizesPool.Put(tmpSizes)
 	}()
 	*tmpSizes = l.appendSizesInPixels((*tmpSizes)[:0], context, alongSize, acrossSize, false)
+	// In a right-to-left reading direction, the bounds are computed from the left and then mirrored,
+	// so that both the order of the items and the paddings are mirrored.
+	rtl := context.callingWidgetReadingDirection() == ReadingDirectionRightToLeft
 	var progress int
 	for i := range l.Items {
-		boundsArr = append(boundsArr, l.positionAndSizeToBounds(bounds, progress, (*tmpSizes)[i]))
+		b := l.positionAndSizeToBounds(bounds, progress, (*tmpSizes)[i])
+		if rtl {
+			b = mirrorBounds(b, bounds)
+		}
+		boundsArr = append(boundsArr, b)
 		progress += (*tmpSizes)[i] + l.Gap
 	}
 	return boundsArr
@@ -5,6 +5,7 @@ package guigui_test
 
 import (
 	"image"
+	"slices"
 	"testing"
 
 	"github.com/guigui-gui/guigui"
@@ -194,3 +195,108 @@ func TestLinearLayoutMeasureFlexibleSizeWithWidgetWithoutConstraints(t *testing.
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestLinearLayoutRightToLeft(t *testing.T) {
+	l := guigui.LinearLayout{
+		Direction: guigui.LayoutDirectionHorizontal,
+		Items: []guigui.LinearLayoutItem{
+			{
+				Size: guigui.FixedSize(10),
+			},
+			{
+				Size: guigui.FixedSize(20),
+			},
+		},
+		Gap: 5,
+		Padding: guigui.Padding{
+			Start: 1,
+			End:   2,
+		},
+	}
+	bounds := image.Rect(100, 0, 200, 10)
+
+	var context guigui.Context
+	var parent, child dummyWidget
+	guigui.SetParentForTesting(&child, &parent)
+	guigui.SetCallingWidgetForTesting(&context, &child)
+
+	testCases := []struct {
+		direction guigui.ReadingDirection
+		want      []image.Rectangle
+	}{
+		{
+			direction: guigui.ReadingDirectionLeftToRight,
+			want:      []image.Rectangle{image.Rect(101, 0, 111, 10), image.Rect(116, 0, 136, 10)},
+		},
+		{
+			direction: guigui.ReadingDirectionRightToLeft,
+			want:      []image.Rectangle{image.Rect(189, 0, 199, 10), image.Rect(164, 0, 184, 10)},
+		},
+	}
+	for _, tc := range testCases {
+		// The direction is inherited from the parent.
+		context.SetReadingDirection(&parent, tc.direction)
+		if got, want := context.ReadingDirection(&child), tc.direction; got != want {
+			t.Errorf("ReadingDirection: got: %v, want: %v", got, want)
+		}
+		got := l.AppendItemBounds(nil, &context, bounds)
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("direction: %v, got: %v, want: %v", tc.direction, got, tc.want)
+		}
+	}
+}
+
+// readingDirectionProbe records its reading direction in Layout.
+type readingDirectionProbe struct {
+	guigui.DefaultWidget
+
+	direction guigui.ReadingDirection
+}
+
+func (r *readingDirectionProbe) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	r.direction = context.ReadingDirection(r)
+}
+
+type readingDirectionRoot struct {
+	guigui.DefaultWidget
+
+	probe readingDirectionProbe
+}
+
+func (r *readingDirectionRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&r.probe)
+	return nil
+}
+
+func TestSetReadingDirection(t *testing.T) {
+	var root readingDirectionRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	updateApp(t, app)
+	context := app.Context()
+	context.SetReadingDirection(&root, guigui.ReadingDirectionLeftToRight)
+	updateApp(t, app)
+
+	for _, direction := range []guigui.ReadingDirection{
+		guigui.ReadingDirectionRightToLeft,
+		guigui.ReadingDirectionLeftToRight,
+	} {
+		// Changing the direction rebuilds and lays out the tree, and the descendants follow the new direction.
+		buildCount := app.BuildCount()
+		context.SetReadingDirection(&root, direction)
+		updateApp(t, app)
+		if got := app.BuildCount(); got <= buildCount {
+			t.Errorf("BuildCount after SetReadingDirection(%v): got: %d, want: > %d", direction, got, buildCount)
+		}
+		if got := root.probe.direction; got != direction {
+			t.Errorf("the direction in Layout after SetReadingDirection(%v): got: %v, want: %v", direction, got, direction)
+		}
+	}
+
+	// Setting the same direction does not rebuild the tree.
+	buildCount := app.BuildCount()
+	context.SetReadingDirection(&root, guigui.ReadingDirectionLeftToRight)
+	updateApp(t, app)
+	if got := app.BuildCount(); got != buildCount {
+		t.Errorf("BuildCount after setting the same direction: got: %d, want: %d", got, buildCount)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+// observable is the interface implemented by [Observable].
+type observable interface {
+	observableVersion() uint64
+}
+
+// observedValue is a snapshot of an observable's version read during a widget's [Widget.Build].
+type observedValue struct {
+	observable observable
+	version    uint64
+}
+
+// Observable is a value whose reads during [Widget.Build] are tracked per widget.
+// When the value is changed by [Observable.Set], the widgets that read the value in their last Build
+// are marked for rebuild automatically, so the writer does not have to call [RequestRebuild] or
+// mirror the value into [Widget.WriteStateKey].
+//
+// The zero value of Observable holds the zero value of T and is ready to use.
+//
+// An Observable must be used only on the UI goroutine. To change a value from another goroutine, use [Post].
+// An Observable must not be copied after first use.
+type Observable[T any] struct {
+	value   T
+	version uint64
+
+	_ noCopy
+}
+
+// Get returns the current value.
+//
+// When Get is called in a widget's [Widget.Build], the widget is recorded as a dependent of the value.
+// Reads in other methods such as [Widget.Layout] and [Widget.Draw] are not tracked.
+func (o *Observable[T]) Get() T {
+	currentApp().observe(o)
+	return o.value
+}
+
+// Set sets the value and marks the dependent widgets for rebuild.
+//
+// Set always marks the dependents even if the new value equals the current value.
+func (o *Observable[T]) Set(value T) {
+	o.value = value
+	o.version++
+	theApp.stateKeyCheckPending = true
+	if a := renderingApp.Load(); a != nil {
+		a.stateKeyCheckPending = true
+	}
+}
+
+// Update sets the value to the result of f applied to the current value, as [Observable.Set] does.
+func (o *Observable[T]) Update(f func(value T) T) {
+	o.Set(f(o.value))
+}
+
+func (o *Observable[T]) observableVersion() uint64 {
+	return o.version
+}
+
+// observe records o as a value read by the widget being built, if any.
+func (a *app) observe(o observable) {
+	if !a.context.inBuild || a.buildingWidget == nil {
+		return
+	}
+	ws := a.buildingWidget.widgetState()
+	for i := range ws.observedValues {
+		if ws.observedValues[i].observable == o {
+			// The version might be changed in the same Build. Keep the latest one.
+			ws.observedValues[i].version = o.observableVersion()
+			return
+		}
+	}
+	ws.observedValues = append(ws.observedValues, observedValue{
+		observable: o,
+		version:    o.observableVersion(),
+	})
+}
+
+// observedValuesChanged reports whether any value read in the last [Widget.Build] has been changed since.
+func (w *widgetState) observedValuesChanged() bool {
+	for _, v := range w.observedValues {
+		if v.observable.observableVersion() != v.version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type observableReader struct {
+	guigui.DefaultWidget
+
+	observable *guigui.Observable[int]
+
+	// readInLayout reports whether the value is read in Layout instead of Build.
+	readInLayout bool
+
+	// setInBuild is the value set between two reads in Build, if not zero.
+	setInBuild int
+
+	value      int
+	buildCount int
+}
+
+func (o *observableReader) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	o.buildCount++
+	if o.observable == nil || o.readInLayout {
+		return nil
+	}
+	o.value = o.observable.Get()
+	if o.setInBuild != 0 {
+		o.observable.Set(o.setInBuild)
+		o.value = o.observable.Get()
+	}
+	return nil
+}
+
+func (o *observableReader) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	if o.observable != nil && o.readInLayout {
+		o.value = o.observable.Get()
+	}
+}
+
+type observableRoot struct {
+	guigui.DefaultWidget
+
+	reader1 observableReader
+	reader2 observableReader
+}
+
+func (o *observableRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddWidget(&o.reader1)
+	adder.AddWidget(&o.reader2)
+	return nil
+}
+
+func (o *observableRoot) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	b := widgetBounds.Bounds()
+	layouter.LayoutWidget(&o.reader1, image.Rect(b.Min.X, b.Min.Y, b.Max.X, b.Min.Y+b.Dy()/2))
+	layouter.LayoutWidget(&o.reader2, image.Rect(b.Min.X, b.Min.Y+b.Dy()/2, b.Max.X, b.Max.Y))
+}
+
+func updateApp(t *testing.T, app *guigui.AppForTesting) {
+	t.Helper()
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+}
+
+func TestObservableGetOutsideBuild(t *testing.T) {
+	var o guigui.Observable[int]
+	var root observableRoot
+	root.reader1.observable = &o
+	root.reader1.readInLayout = true
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	updateApp(t, app)
+
+	// A read outside Build, such as in Layout or in a test, is not tracked.
+	_ = o.Get()
+	o.Set(1)
+	if guigui.ObservedValuesChangedForTesting(&root.reader1) {
+		t.Errorf("observedValuesChanged of the widget reading in Layout: got: true, want: false")
+	}
+	buildCount := app.BuildCount()
+	updateApp(t, app)
+	if got := app.BuildCount(); got != buildCount {
+		t.Errorf("build count: got: %d, want: %d", got, buildCount)
+	}
+}
+
+func TestObservableSetRebuildsReaders(t *testing.T) {
+	var o1, o2 guigui.Observable[int]
+	var root observableRoot
+	root.reader1.observable = &o1
+	root.reader2.observable = &o2
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	updateApp(t, app)
+
+	o1.Set(1)
+	if !guigui.ObservedValuesChangedForTesting(&root.reader1) {
+		t.Errorf("observedValuesChanged of the reader of the set value: got: false, want: true")
+	}
+	if guigui.ObservedValuesChangedForTesting(&root.reader2) {
+		t.Errorf("observedValuesChanged of the reader of another value: got: true, want: false")
+	}
+	if guigui.ObservedValuesChangedForTesting(&root) {
+		t.Errorf("observedValuesChanged of the parent: got: true, want: false")
+	}
+
+	buildCount := app.BuildCount()
+	// The first update detects the change, and the second one rebuilds.
+	updateApp(t, app)
+	updateApp(t, app)
+	if got, want := app.BuildCount(), buildCount+1; got != want {
+		t.Errorf("build count: got: %d, want: %d", got, want)
+	}
+	if got, want := root.reader1.value, 1; got != want {
+		t.Errorf("the value read in Build: got: %d, want: %d", got, want)
+	}
+	if guigui.ObservedValuesChangedForTesting(&root.reader1) {
+		t.Errorf("observedValuesChanged after the rebuild: got: true, want: false")
+	}
+}
+
+func TestObservableLaterReadReplacesVersion(t *testing.T) {
+	var o guigui.Observable[int]
+	var root observableRoot
+	root.reader1.observable = &o
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	updateApp(t, app)
+
+	// The version read in the rebuild replaces the stale one, so the tree settles after one rebuild.
+	o.Set(1)
+	for range 3 {
+		updateApp(t, app)
+	}
+	buildCount := app.BuildCount()
+	for range 3 {
+		updateApp(t, app)
+	}
+	if got := app.BuildCount(); got != buildCount {
+		t.Errorf("build count after the rebuild: got: %d, want: %d", got, buildCount)
+	}
+
+	// A value set and read again in the same Build keeps the latest version.
+	guigui.Post(func(context *guigui.Context) {
+		root.reader1.setInBuild = 2
+	})
+	for range 3 {
+		updateApp(t, app)
+	}
+	if guigui.ObservedValuesChangedForTesting(&root.reader1) {
+		t.Errorf("observedValuesChanged after a set in Build: got: true, want: false")
+	}
+	if got, want := root.reader1.value, 2; got != want {
+		t.Errorf("the value read in Build: got: %d, want: %d", got, want)
+	}
+}
+
+func TestObservableUnobserved(t *testing.T) {
+	var o guigui.Observable[int]
+	var root observableRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	updateApp(t, app)
+
+	buildCount := app.BuildCount()
+	o.Set(1)
+	for range 3 {
+		updateApp(t, app)
+	}
+	if got := app.BuildCount(); got != buildCount {
+		t.Errorf("build count: got: %d, want: %d", got, buildCount)
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"slices"
+	"sync"
+)
+
+// postedFuncs is a queue of functions posted by [Post] from arbitrary goroutines.
+type postedFuncs struct {
+	m     sync.Mutex
+	funcs []func(context *Context)
+
+	// tmpFuncs is reused by run to avoid allocating a new slice every tick.
+	tmpFuncs []func(context *Context)
+}
+
+func (p *postedFuncs) add(f func(context *Context)) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.funcs = append(p.funcs, f)
+}
+
+// moveTo moves the queued functions to the end of dst.
+func (p *postedFuncs) moveTo(dst *postedFuncs) {
+	p.m.Lock()
+	funcs := p.funcs
+	p.funcs = nil
+	p.m.Unlock()
+
+	if len(funcs) == 0 {
+		return
+	}
+	dst.m.Lock()
+	defer dst.m.Unlock()
+	dst.funcs = append(dst.funcs, funcs...)
+}
+
+// run runs all the queued functions in the order they were posted, and reports whether any function ran.
+// Functions posted while running are kept for the next run.
+//
+// run must be called on the UI goroutine.
+func (p *postedFuncs) run(context *Context) bool {
+	p.m.Lock()
+	p.funcs, p.tmpFuncs = p.tmpFuncs, p.funcs
+	p.funcs = slices.Delete(p.funcs, 0, len(p.funcs))
+	p.m.Unlock()
+
+	if len(p.tmpFuncs) == 0 {
+		return false
+	}
+	for _, f := range p.tmpFuncs {
+		f(context)
+	}
+	clear(p.tmpFuncs)
+	p.tmpFuncs = p.tmpFuncs[:0]
+	return true
+}
+
+// Post queues f to run on the UI goroutine in the next tick. Functions are run in the order they were posted.
+//
+// Posted functions run after the tree is built and before input is handled, so events dispatched by f reach
+// the handlers set in the tick's [Widget.Build], as events dispatched by input handlers do.
+//
+// Post is safe to call from any goroutine. Use Post to deliver the results of background work,
+// such as file loading or network requests, to widgets.
+//
+// After posted functions run, the widget tree is rebuilt in the same tick, and state changes reflected in
+// [Widget.WriteStateKey] are detected as usual. Thus, f does not have to call [RequestRebuild].
+//
+// While [Context.RenderWidget] runs, f is queued for the rendered tree, and runs before the widget is rendered.
+func Post(f func(context *Context)) {
+	currentApp().postedFuncs.add(f)
+}
+
+// RunAsync runs task on a new goroutine and calls callback with task's results on the UI goroutine,
+// as [Post] does.
+//
+// RunAsync is safe to call from any goroutine.
+func RunAsync[T any](task func() (T, error), callback func(context *Context, value T, err error)) {
+	go func() {
+		v, err := task()
+		Post(func(context *Context) {
+			callback(context, v, err)
+		})
+	}()
+}
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"errors"
+	"image"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/guigui-gui/guigui"
+)
+
+var postTestEvent = guigui.GenerateEventKey1[int]()
+
+// postChild sets a handler of postTestEvent in Build, which records the generation of the build
+// that set it.
+type postChild struct {
+	guigui.DefaultWidget
+
+	generation int
+	handled    []int
+}
+
+func (p *postChild) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	p.generation++
+	generation := p.generation
+	postTestEvent.SetHandler(p, func(context *guigui.Context, value int) {
+		p.handled = append(p.handled, generation)
+	})
+	return nil
+}
+
+type postRoot struct {
+	guigui.DefaultWidget
+
+	child        postChild
+	value        int
+	buildCount   int
+	valueInBuild int
+}
+
+func (p *postRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	p.buildCount++
+	p.valueInBuild = p.value
+	adder.AddWidget(&p.child)
+	return nil
+}
+
+func (p *postRoot) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	w.WriteInt(p.value)
+}
+
+func newPostApp(t *testing.T) (*guigui.AppForTesting, *postRoot) {
+	t.Helper()
+	var root postRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	updateApp(t, app)
+	return app, &root
+}
+
+func TestPostAfterBuild(t *testing.T) {
+	app, root := newPostApp(t)
+
+	// The tree is built at the start of the tick, and then the posted function runs.
+	guigui.RequestRebuild()
+	var buildCountInPost int
+	guigui.Post(func(context *guigui.Context) {
+		buildCountInPost = root.buildCount
+		postTestEvent.Dispatch(&root.child, 1)
+	})
+	updateApp(t, app)
+	if got, want := buildCountInPost, 2; got != want {
+		t.Errorf("the build count in the posted function: got: %d, want: %d", got, want)
+	}
+	// The event reaches the handler set in the build of the same tick.
+	if got, want := root.child.handled, []int{2}; !slices.Equal(got, want) {
+		t.Errorf("the generations of the handlers called: got: %v, want: %v", got, want)
+	}
+}
+
+func TestPostRebuilds(t *testing.T) {
+	app, root := newPostApp(t)
+
+	// A posted function rebuilds the tree in the same tick, even without a state change.
+	buildCount := app.BuildCount()
+	guigui.Post(func(context *guigui.Context) {})
+	updateApp(t, app)
+	if got, want := app.BuildCount(), buildCount+1; got != want {
+		t.Errorf("BuildCount after an empty posted function: got: %d, want: %d", got, want)
+	}
+
+	// The build in the same tick observes the state changed by a posted function.
+	guigui.Post(func(context *guigui.Context) {
+		root.value = 1
+	})
+	updateApp(t, app)
+	if got, want := root.valueInBuild, 1; got != want {
+		t.Errorf("the value in Build after a posted state change: got: %d, want: %d", got, want)
+	}
+
+	// Without a posted function, the tree is not rebuilt.
+	buildCount = app.BuildCount()
+	updateApp(t, app)
+	if got := app.BuildCount(); got != buildCount {
+		t.Errorf("BuildCount without a posted function: got: %d, want: %d", got, buildCount)
+	}
+}
+
+func TestPostFromGoroutines(t *testing.T) {
+	app, _ := newPostApp(t)
+
+	const (
+		goroutines = 4
+		posts      = 100
+	)
+	var got [goroutines][]int
+	var wg sync.WaitGroup
+	for i := range goroutines {
+		wg.Go(func() {
+			for j := range posts {
+				guigui.Post(func(context *guigui.Context) {
+					got[i] = append(got[i], j)
+				})
+			}
+		})
+	}
+	wg.Wait()
+
+	updateApp(t, app)
+	for i := range goroutines {
+		// The functions posted from one goroutine run in the order they were posted.
+		if len(got[i]) != posts {
+			t.Fatalf("the number of functions run from the goroutine %d: got: %d, want: %d", i, len(got[i]), posts)
+		}
+		for j, v := range got[i] {
+			if v != j {
+				t.Errorf("the function run at %d from the goroutine %d: got: %d, want: %d", j, i, v, j)
+			}
+		}
+	}
+}
+
+func TestPostFromPostedFunction(t *testing.T) {
+	app, _ := newPostApp(t)
+
+	// A function posted while posted functions run is kept for the next tick.
+	var ran []int
+	guigui.Post(func(context *guigui.Context) {
+		ran = append(ran, 1)
+		guigui.Post(func(context *guigui.Context) {
+			ran = append(ran, 2)
+		})
+	})
+	updateApp(t, app)
+	if got, want := ran, []int{1}; !slices.Equal(got, want) {
+		t.Errorf("the functions run in the first tick: got: %v, want: %v", got, want)
+	}
+	updateApp(t, app)
+	if got, want := ran, []int{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("the functions run in the second tick: got: %v, want: %v", got, want)
+	}
+}
+
+// updateUntil calls app.Update until done reports true, and fails the test after a timeout.
+func updateUntil(t *testing.T, app *guigui.AppForTesting, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out")
+		}
+		updateApp(t, app)
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunAsync(t *testing.T) {
+	app, root := newPostApp(t)
+
+	var called bool
+	var gotValue int
+	var gotErr error
+	guigui.RunAsync(func() (int, error) {
+		return 42, nil
+	}, func(context *guigui.Context, value int, err error) {
+		called = true
+		gotValue = value
+		gotErr = err
+		root.value = value
+	})
+	updateUntil(t, app, func() bool { return called })
+	if gotValue != 42 || gotErr != nil {
+		t.Errorf("RunAsync callback: got: (%d, %v), want: (42, nil)", gotValue, gotErr)
+	}
+	if got, want := root.value, 42; got != want {
+		t.Errorf("the value set by the callback: got: %d, want: %d", got, want)
+	}
+}
+
+func TestRunAsyncError(t *testing.T) {
+	app, _ := newPostApp(t)
+
+	errTest := errors.New("test")
+	var called bool
+	var gotValue string
+	var gotErr error
+	guigui.RunAsync(func() (string, error) {
+		return "partial", errTest
+	}, func(context *guigui.Context, value string, err error) {
+		called = true
+		gotValue = value
+		gotErr = err
+	})
+	updateUntil(t, app, func() bool { return called })
+	if !errors.Is(gotErr, errTest) {
+		t.Errorf("RunAsync callback error: got: %v, want: %v", gotErr, errTest)
+	}
+	// The value is passed along with the error.
+	if got, want := gotValue, "partial"; got != want {
+		t.Errorf("RunAsync callback value: got: %q, want: %q", got, want)
+	}
+}
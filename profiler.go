@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"image"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/guigui-gui/guigui/internal/debugmode"
+)
+
+// profiler records the timings of the phases per widget, and writes them as a Chrome trace event JSON file.
+// The file can be viewed in Perfetto (https://ui.perfetto.dev) or chrome://tracing.
+//
+// The profiler is enabled by GUIGUI_DEBUG=profile=<file>.
+// A nil profiler is valid and records nothing, so the call sites need no checks.
+//
+// The file is written in the JSON array format. Events are flushed every frame,
+// and the closing bracket is written when the application ends.
+// The trace viewers accept a file without the closing bracket, so the file is usable even if the application is killed.
+type profiler struct {
+	file *os.File
+	w    *bufio.Writer
+
+	start       time.Time
+	eventExists bool
+	err         error
+
+	rebuildCount int64
+}
+
+// traceEvent is an event in the Chrome trace event format.
+type traceEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat,omitempty"`
+	Phase     string         `json:"ph"`
+	Timestamp float64        `json:"ts"`
+	Duration  float64        `json:"dur,omitempty"`
+	Scope     string         `json:"s,omitempty"`
+	PID       int            `json:"pid"`
+	TID       int            `json:"tid"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+const (
+	profilerCategoryApp    = "app"
+	profilerCategoryWidget = "widget"
+	profilerCategoryRedraw = "redraw"
+)
+
+// newProfiler creates a profiler if the profiler is enabled by GUIGUI_DEBUG.
+// newProfiler returns nil if the profiler is disabled or the file cannot be created.
+func newProfiler() *profiler {
+	path := debugmode.ProfileFile()
+	if path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		slog.Error("failed to create a profile file", "error", err)
+		return nil
+	}
+	p := &profiler{
+		file:  f,
+		w:     bufio.NewWriter(f),
+		start: time.Now(),
+	}
+	p.write("[")
+	p.addEvent(&traceEvent{
+		Name:  "process_name",
+		Phase: "M",
+		Args: map[string]any{
+			"name": "guigui",
+		},
+	})
+	return p
+}
+
+// now returns the current time, or the zero time if p is nil.
+func (p *profiler) now() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// timestamp returns the time in microseconds since the profiler started.
+func (p *profiler) timestamp(t time.Time) float64 {
+	return float64(t.Sub(p.start).Nanoseconds()) / 1e3
+}
+
+// span records an app-level span from start to now.
+func (p *profiler) span(name string, start time.Time) {
+	if p == nil {
+		return
+	}
+	p.addEvent(&traceEvent{
+		Name:      name,
+		Category:  profilerCategoryApp,
+		Phase:     "X",
+		Timestamp: p.timestamp(start),
+		Duration:  p.timestamp(time.Now()) - p.timestamp(start),
+	})
+}
+
+// widgetSpan records a span of the widget's phase, such as "Build", from start to now.
+func (p *profiler) widgetSpan(phase string, widget Widget, start time.Time) {
+	if p == nil {
+		return
+	}
+	p.addEvent(&traceEvent{
+		Name:      fmt.Sprintf("%T.%s", widget, phase),
+		Category:  profilerCategoryWidget,
+		Phase:     "X",
+		Timestamp: p.timestamp(start),
+		Duration:  p.timestamp(time.Now()) - p.timestamp(start),
+		Args: map[string]any{
+			"widget": fmt.Sprintf("%T", widget),
+			"phase":  phase,
+			"id":     widget.widgetState().identifier(),
+		},
+	})
+}
+
+// rebuilt records that the tree is rebuilt.
+func (p *profiler) rebuilt() {
+	if p == nil {
+		return
+	}
+	p.rebuildCount++
+	p.addEvent(&traceEvent{
+		Name:      "rebuilds",
+		Phase:     "C",
+		Timestamp: p.timestamp(time.Now()),
+		Args: map[string]any{
+			"count": p.rebuildCount,
+		},
+	})
+}
+
+// redrawRequested records a redraw request of the region for the reasons.
+// widget is the widget requesting the redraw, or nil.
+func (p *profiler) redrawRequested(region image.Rectangle, reasons requestRedrawReasons, widget Widget) {
+	if p == nil {
+		return
+	}
+	ts := p.timestamp(time.Now())
+	for reason := range reasons.all() {
+		args := map[string]any{
+			"region": region.String(),
+		}
+		if widget != nil {
+			args["widget"] = fmt.Sprintf("%T", widget)
+		}
+		p.addEvent(&traceEvent{
+			Name:      reason.String(),
+			Category:  profilerCategoryRedraw,
+			Phase:     "i",
+			Timestamp: ts,
+			Scope:     "t",
+			Args:      args,
+		})
+	}
+}
+
+func (p *profiler) addEvent(e *traceEvent) {
+	if p.err != nil {
+		return
+	}
+	e.PID = 1
+	e.TID = 1
+	bs, err := json.Marshal(e)
+	if err != nil {
+		p.fail(err)
+		return
+	}
+	if p.eventExists {
+		p.write(",")
+	}
+	p.eventExists = true
+	p.write("\n")
+	p.write(string(bs))
+}
+
+func (p *profiler) write(str string) {
+	if p.err != nil {
+		return
+	}
+	if _, err := p.w.WriteString(str); err != nil {
+		p.fail(err)
+	}
+}
+
+// flush writes the buffered events to the file. flush is called every frame.
+func (p *profiler) flush() {
+	if p == nil || p.err != nil {
+		return
+	}
+	if err := p.w.Flush(); err != nil {
+		p.fail(err)
+	}
+}
+
+// close finishes the file.
+func (p *profiler) close() {
+	if p == nil {
+		return
+	}
+	p.write("\n]\n")
+	p.flush()
+	if err := p.file.Close(); err != nil && p.err == nil {
+		p.fail(err)
+	}
+}
+
+func (p *profiler) fail(err error) {
+	p.err = err
+	slog.Error("failed to write a profile", "error", err)
+}
+
+// measureWidget calls widget.Measure, and records the timing if the profiler is enabled.
+//
+// The framework calls Measure of other widgets only through measureWidget: in [LinearLayout] and in
+// the wrapper widgets like [WidgetWithSize]. A widget calling Measure of its child directly is
+// recorded in its own phase, such as Layout.
+func measureWidget(context *Context, widget Widget, constraints Constraints) image.Point {
+	var p *profiler
+	if context.app != nil {
+		p = context.app.profiler
+	}
+	start := p.now()
+	s := widget.Measure(context, constraints)
+	p.widgetSpan("Measure", widget, start)
+	return s
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+
+	"golang.org/x/text/language"
+)
+
+// ReadingDirection represents the horizontal direction in which a user interface is read.
+//
+// In a right-to-left direction, the layouts are mirrored:
+// the items of a horizontal [LinearLayout] are arranged from right to left,
+// and [Padding.Start] is at the right.
+type ReadingDirection int
+
+const (
+	// ReadingDirectionAuto means that the direction is not specified.
+	// The direction is inherited from the ancestors, or determined by the first locale.
+	ReadingDirectionAuto ReadingDirection = iota
+
+	// ReadingDirectionLeftToRight is the direction of scripts like Latin, Cyrillic and CJK.
+	ReadingDirectionLeftToRight
+
+	// ReadingDirectionRightToLeft is the direction of scripts like Arabic and Hebrew.
+	ReadingDirectionRightToLeft
+)
+
+// rightToLeftScripts are the scripts written from right to left.
+var rightToLeftScripts = map[string]struct{}{
+	"Adlm": {},
+	"Arab": {},
+	"Hebr": {},
+	"Mand": {},
+	"Mend": {},
+	"Nkoo": {},
+	"Rohg": {},
+	"Samr": {},
+	"Syrc": {},
+	"Thaa": {},
+	"Yezi": {},
+}
+
+// readingDirectionForLocale returns the reading direction of the locale's script.
+// The script is the most likely one if the locale does not specify it, such as Arabic for "ar".
+func readingDirectionForLocale(locale language.Tag) ReadingDirection {
+	script, conf := locale.Script()
+	if conf == language.No {
+		return ReadingDirectionLeftToRight
+	}
+	if _, ok := rightToLeftScripts[script.String()]; ok {
+		return ReadingDirectionRightToLeft
+	}
+	return ReadingDirectionLeftToRight
+}
+
+// SetReadingDirection sets the reading direction of the widget and its descendants.
+// A descendant can override the direction for its own subtree.
+//
+// If direction is [ReadingDirectionAuto], the widget follows its parent.
+func (c *Context) SetReadingDirection(widget Widget, direction ReadingDirection) {
+	widgetState := widget.widgetState()
+	if widgetState.readingDirection == direction {
+		return
+	}
+	widgetState.readingDirection = direction
+	_ = traverseWidget(widget, func(w Widget) error {
+		w.widgetState().readingDirectionCacheValid = false
+		w.widgetState().readingDirectionCache = ReadingDirectionAuto
+		return nil
+	})
+	// The layouts and the drawings depending on the direction change.
+	RequestRebuild()
+	RequestRedraw(widget)
+}
+
+// ReadingDirection returns the reading direction of the widget.
+//
+// The direction is the one set by [Context.SetReadingDirection] on the widget or the nearest ancestor.
+// If none is set, the direction is determined by the script of the first locale (see [Context.FirstLocale]):
+// right-to-left for locales like Arabic and Hebrew, and left-to-right otherwise.
+//
+// ReadingDirection never returns [ReadingDirectionAuto].
+func (c *Context) ReadingDirection(widget Widget) ReadingDirection {
+	if d := widget.widgetState().inheritedReadingDirection(); d != ReadingDirectionAuto {
+		return d
+	}
+	return c.localeReadingDirection()
+}
+
+// IsRightToLeft reports whether the reading direction of the widget is right-to-left.
+func (c *Context) IsRightToLeft(widget Widget) bool {
+	return c.ReadingDirection(widget) == ReadingDirectionRightToLeft
+}
+
+func (c *Context) localeReadingDirection() ReadingDirection {
+	c.ensureAllLocales()
+	if c.localeReadingDirectionCache == ReadingDirectionAuto {
+		c.localeReadingDirectionCache = ReadingDirectionLeftToRight
+		if len(c.allLocales) > 0 {
+			c.localeReadingDirectionCache = readingDirectionForLocale(c.allLocales[0])
+		}
+	}
+	return c.localeReadingDirectionCache
+}
+
+// callingWidgetReadingDirection returns the reading direction of the widget whose method is being called:
+// [Widget.Build], [Widget.Layout], [Widget.Draw], [Widget.HandlePointingInput], [Widget.HandleButtonInput]
+// or [Widget.Tick].
+// Outside these methods, the direction of the first locale is returned.
+func (c *Context) callingWidgetReadingDirection() ReadingDirection {
+	if c.callingWidget != nil {
+		return c.ReadingDirection(c.callingWidget)
+	}
+	if c.app != nil && c.app.buildingWidget != nil {
+		return c.ReadingDirection(c.app.buildingWidget)
+	}
+	return c.localeReadingDirection()
+}
+
+// MirrorBounds returns the bounds mirrored horizontally in the container if the widget's reading direction is
+// right-to-left, or the bounds as they are otherwise.
+//
+// MirrorBounds is useful to lay out children or draw parts at positions computed from the left edge,
+// such as an icon at the start of a widget.
+func (c *Context) MirrorBounds(widget Widget, bounds, container image.Rectangle) image.Rectangle {
+	if !c.IsRightToLeft(widget) {
+		return bounds
+	}
+	return mirrorBounds(bounds, container)
+}
+
+func mirrorBounds(bounds, container image.Rectangle) image.Rectangle {
+	x0 := container.Min.X + container.Max.X - bounds.Max.X
+	x1 := container.Min.X + container.Max.X - bounds.Min.X
+	bounds.Min.X = x0
+	bounds.Max.X = x1
+	return bounds
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui/inputrecord"
+	"github.com/guigui-gui/guigui/internal/debugmode"
+	"github.com/guigui-gui/guigui/internal/imecommit"
+	"github.com/guigui-gui/guigui/internal/inputsource"
+)
+
+// inputRecorder records the input of every tick to a file.
+//
+// The recorder is enabled by GUIGUI_DEBUG=record=<file>.
+// A nil inputRecorder is valid and records nothing.
+type inputRecorder struct {
+	file     *os.File
+	recorder *inputrecord.Recorder
+	state    inputrecord.State
+
+	touchIDs []ebiten.TouchID
+
+	// imeCommits are the texts committed by an IME since the last record.
+	imeCommits []string
+}
+
+// newInputRecorder creates an inputRecorder if the recorder is enabled by GUIGUI_DEBUG.
+// newInputRecorder returns nil if the recorder is disabled or the file cannot be created.
+func newInputRecorder() *inputRecorder {
+	path := debugmode.RecordFile()
+	if path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		slog.Error("failed to create an input recording file", "error", err)
+		return nil
+	}
+	r := &inputRecorder{
+		file:     f,
+		recorder: inputrecord.NewRecorder(f),
+	}
+	imecommit.SetHandler(func(text string) {
+		r.imeCommits = append(r.imeCommits, text)
+	})
+	return r
+}
+
+// record records the current input at the tick.
+// Positions and sizes are divided by deviceScale to be in device-independent pixels.
+func (r *inputRecorder) record(tick int64, screenWidth, screenHeight float64, deviceScale float64) {
+	if r == nil || r.file == nil {
+		return
+	}
+
+	s := &r.state
+	s.Reset()
+	s.Width = screenWidth / deviceScale
+	s.Height = screenHeight / deviceScale
+	x, y := inputsource.CursorPosition()
+	s.CursorX = float64(x) / deviceScale
+	s.CursorY = float64(y) / deviceScale
+	s.Keys = inputsource.AppendPressedKeys(s.Keys)
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if inputsource.IsMouseButtonPressed(b) {
+			s.MouseButtons = append(s.MouseButtons, b)
+		}
+	}
+	s.WheelX, s.WheelY = inputsource.Wheel()
+	r.touchIDs = inputsource.AppendTouchIDs(r.touchIDs[:0])
+	for _, id := range r.touchIDs {
+		x, y := inputsource.TouchPosition(id)
+		s.Touches = append(s.Touches, inputrecord.Touch{
+			ID: id,
+			X:  float64(x) / deviceScale,
+			Y:  float64(y) / deviceScale,
+		})
+	}
+	s.Chars = inputsource.AppendInputChars(s.Chars)
+	s.IMECommits = append(s.IMECommits, r.imeCommits...)
+	r.imeCommits = r.imeCommits[:0]
+
+	if err := r.recorder.Record(tick, s); err != nil {
+		r.fail(err)
+		return
+	}
+	// Flush every tick so that the recording survives a crash, which is often what is being reported.
+	if err := r.recorder.Flush(); err != nil {
+		r.fail(err)
+	}
+}
+
+// close closes the file.
+func (r *inputRecorder) close() {
+	if r == nil || r.file == nil {
+		return
+	}
+	imecommit.SetHandler(nil)
+	if err := r.recorder.Flush(); err != nil {
+		slog.Error("failed to write an input recording", "error", err)
+	}
+	if err := r.file.Close(); err != nil {
+		slog.Error("failed to close an input recording file", "error", err)
+	}
+	r.file = nil
+}
+
+// fail stops recording after an error.
+func (r *inputRecorder) fail(err error) {
+	slog.Error("failed to write an input recording", "error", err)
+	imecommit.SetHandler(nil)
+	_ = r.file.Close()
+	r.file = nil
+}
@@ -4,13 +4,9 @@
 package guigui
 
 import (
-	"fmt"
 	"image"
 	"iter"
-	"log/slog"
 	"math/bits"
-
-	"github.com/guigui-gui/guigui/internal/debugmode"
 )
 
 type redrawRequests struct {
@@ -25,6 +21,10 @@ func (r *redrawRequests) empty() bool {
 	return r.region.Empty()
 }
 
+func (r *redrawRequests) add(region image.Rectangle) {
+	r.region = r.region.Union(region)
+}
+
 func (r *redrawRequests) union(region image.Rectangle) image.Rectangle {
 	return r.region.Union(region)
 }
@@ -39,6 +39,7 @@ const (
 
 	// The remaining reasons require a rebuild in addition to a redraw.
 	requestRedrawReasonStateKeyChangedForBuild
+	requestRedrawReasonObservableChanged
 	requestRedrawReasonWidgetFocus
 	requestRedrawReasonAppFocus
 	requestRedrawReasonScreenSize
@@ -59,6 +60,8 @@ func (r requestRedrawReason) String() string {
 		return "tree changed"
 	case requestRedrawReasonStateKeyChangedForBuild:
 		return "state key changed for build"
+	case requestRedrawReasonObservableChanged:
+		return "observable changed"
 	case requestRedrawReasonWidgetFocus:
 		return "widget focus"
 	case requestRedrawReasonAppFocus:
@@ -127,20 +130,3 @@ func (r *requestRedrawReasons) add(reason requestRedrawReason) {
 func (r *requestRedrawReasons) clear() {
 	*r = 0
 }
-
-func (r *redrawRequests) add(region image.Rectangle, reasons requestRedrawReasons, widget Widget) {
-	r.region = r.region.Union(region)
-	if !debugmode.ShowRenderingRegions() {
-		return
-	}
-	for reason := range reasons.all() {
-		switch reason {
-		case requestRedrawReasonExplicitRequest:
-			slog.Info("request redrawing", "reason", reason.String(), "requester", fmt.Sprintf("%T", widget), "at", widget.widgetState().redrawRequestedAt, "region", region)
-		case requestRedrawReasonStateKeyChangedForDraw, requestRedrawReasonTreeChanged, requestRedrawReasonStateKeyChangedForBuild:
-			slog.Info("request redrawing", "reason", reason.String(), "requester", fmt.Sprintf("%T", widget), "region", region)
-		default:
-			slog.Info("request redrawing", "reason", reason.String(), "region", region)
-		}
-	}
-}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"sync/atomic"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/text/language"
+)
+
+// RenderWidgetOptions represents options for [Context.RenderWidget].
+type RenderWidgetOptions struct {
+	// DeviceScale is the device scale factor to render the widget at.
+	// If DeviceScale is 0, the current device scale factor is used.
+	DeviceScale float64
+
+	// AppScale is the application scale factor to render the widget at.
+	// If AppScale is 0, the current application scale factor is used.
+	AppScale float64
+
+	// ColorMode is the color mode to render the widget in.
+	// If ColorMode is [ebiten.ColorModeUnknown], the current color mode is used.
+	ColorMode ebiten.ColorMode
+
+	// Locales are the application locales to render the widget with, in the same way as [Context.SetAppLocales].
+	// If Locales is nil, the current application locales are used.
+	Locales []language.Tag
+}
+
+// RenderWidget builds the widget and its descendants, lays out the widget at the bounds (0, 0)-size,
+// and renders them into a new image of the size.
+// This is useful for thumbnails, print previews, and golden-image tests of individual widgets.
+//
+// The widget must not be in the widget tree. Use a separate widget instance to render a widget shown on the screen.
+// The widget is rendered as the root of its own tree: the widget has no parent, no widget is focused,
+// and the layers of the descendants like popups are rendered in order.
+// After RenderWidget returns, the widget can be added to the widget tree.
+// The [Observable] reads, the event handlers, the redraw requests, the timers and the functions posted by [Post]
+// of the rendered widgets belong to their own tree, and do not affect the application's tree.
+// The posted functions run before the widget is rendered, and the timers never fire.
+//
+// The settings not specified by options are the same as the application's.
+//
+// RenderWidget must not be called in [Widget.Build].
+// As the returned image is an Ebitengine image, RenderWidget must be called while the application is running.
+func (c *Context) RenderWidget(widget Widget, size image.Point, options *RenderWidgetOptions) (*ebiten.Image, error) {
+	if options == nil {
+		options = &RenderWidgetOptions{}
+	}
+	if c.inBuild {
+		return nil, errors.New("guigui: RenderWidget must not be called in Build")
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return nil, fmt.Errorf("guigui: size must be positive: %v", size)
+	}
+	widget.copyCheck()
+	widgetState := widget.widgetState()
+	if widgetState.isInTree(c.app.buildCount) {
+		return nil, fmt.Errorf("guigui: %T is in the widget tree", widget)
+	}
+
+	a := &app{
+		root:         widget,
+		deviceScale:  c.app.deviceScale,
+		screenWidth:  float64(size.X),
+		screenHeight: float64(size.Y),
+		// Start from a build count that the application's tree never reaches,
+		// so that the widgets in this tree are never regarded as in the application's tree.
+		buildCount: math.MinInt64,
+	}
+	if options.DeviceScale > 0 {
+		a.deviceScale = options.DeviceScale
+	}
+	a.context.app = a
+	a.context.appScaleMinus1 = c.appScaleMinus1
+	if options.AppScale > 0 {
+		a.context.appScaleMinus1 = options.AppScale - 1
+	}
+	a.context.locales = slices.Clone(c.locales)
+	if options.Locales != nil {
+		a.context.locales = slices.Clone(options.Locales)
+	}
+	a.context.preferredKeyBindingMode = c.preferredKeyBindingMode
+	a.context.colorMode = c.colorMode
+	if options.ColorMode != ebiten.ColorModeUnknown {
+		a.context.colorMode = options.ColorMode
+	}
+
+	// Observable reads, event handlers, redraw requests and posted functions during rendering go to the rendering app.
+	origRenderingApp := renderingApp.Load()
+	renderingApp.Store(a)
+	defer func() {
+		renderingApp.Store(origRenderingApp)
+		// The functions posted after the rendering app ran them, e.g. from other goroutines, are not lost.
+		a.postedFuncs.moveTo(&currentApp().postedFuncs)
+	}()
+
+	widgetState.parent = nil
+	widgetState.bounds = a.bounds()
+	defer a.detachWidgets()
+
+	a.requiredPhases = a.requiredPhases.addBuild()
+	if _, err := a.buildAndLayoutWidgets(); err != nil {
+		return nil, err
+	}
+	// Run the functions posted while building, and rebuild the tree as the application does in a tick.
+	a.runPostedFuncs()
+	a.settleRebuildAndRedrawState(nil)
+	if _, err := a.buildAndLayoutWidgets(); err != nil {
+		return nil, err
+	}
+
+	img := ebiten.NewImage(size.X, size.Y)
+	a.regionsToDraw = a.bounds()
+	a.drawWidget(img)
+	return img, nil
+}
+
+// renderingApp is the app rendering a widget by [Context.RenderWidget], or nil.
+//
+// renderingApp is atomic as [Post] reads it from arbitrary goroutines.
+var renderingApp atomic.Pointer[app]
+
+// currentApp returns the app whose widgets are being processed:
+// the rendering app while [Context.RenderWidget] runs, or the application otherwise.
+func currentApp() *app {
+	if a := renderingApp.Load(); a != nil {
+		return a
+	}
+	return &theApp
+}
+
+// detachWidgets makes the widgets built by a for [Context.RenderWidget] out of any tree,
+// so that they can be added to the application's tree later.
+func (a *app) detachWidgets() {
+	a.root.widgetState().builtAt = 0
+	for _, w := range a.widgetList {
+		ws := w.widgetState()
+		ws.builtAt = 0
+		ws.eventHandlers = slices.Delete(ws.eventHandlers, 0, len(ws.eventHandlers))
+		ws.focusDelegate = nil
+		ws.observedValues = nil
+		ws.errorBoundary = nil
+		ws.redrawReasons.clear()
+	}
+}
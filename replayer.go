@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui/inputrecord"
+	"github.com/guigui-gui/guigui/internal/debugmode"
+	"github.com/guigui-gui/guigui/internal/inputsource"
+)
+
+// inputReplayer replays the input recorded by an inputRecorder.
+//
+// The replayer is enabled by GUIGUI_DEBUG=replay=<file>.
+// A nil inputReplayer is valid and replays nothing.
+type inputReplayer struct {
+	// width and height are the recorded window size applied last.
+	width  float64
+	height float64
+
+	finished bool
+}
+
+// newInputReplayer creates an inputReplayer and starts the replay if the replayer is enabled by GUIGUI_DEBUG.
+// newInputReplayer returns nil if the replayer is disabled or the file cannot be read.
+func newInputReplayer() *inputReplayer {
+	path := debugmode.ReplayFile()
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		slog.Error("failed to open an input recording file", "error", err)
+		return nil
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	p, err := inputrecord.NewPlayer(f)
+	if err != nil {
+		slog.Error("failed to read an input recording", "error", err)
+		return nil
+	}
+	inputsource.StartReplay(p)
+	return &inputReplayer{}
+}
+
+// step replays the input of the next tick. step must be called at the start of every tick.
+func (r *inputReplayer) step(deviceScale float64) {
+	if r == nil || r.finished {
+		return
+	}
+	w, h, ok := inputsource.Step(deviceScale)
+	if !ok {
+		r.finished = true
+		slog.Info("finished replaying the input recording")
+		return
+	}
+	if w <= 0 || h <= 0 || (w == r.width && h == r.height) {
+		return
+	}
+	r.width = w
+	r.height = h
+	ebiten.SetWindowSize(int(w), int(h))
+}
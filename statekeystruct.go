@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"fmt"
+	"image/color"
+	"math/big"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// StateKeyWriterTo is implemented by values that write their own state into a [StateKeyWriter].
+//
+// [StateKeyWriter.WriteStruct] calls WriteStateKeyTo instead of walking the fields of such a value.
+// Implement this for a type whose state lives in unexported fields, or whose fields cannot be walked.
+type StateKeyWriterTo interface {
+	WriteStateKeyTo(w *StateKeyWriter)
+}
+
+// WriteStruct writes the fields of a struct into the writer via reflection.
+// v must be a struct or a non-nil pointer to a struct.
+//
+// Exported fields are written, and unexported fields are written only when tagged with `guigui:"key"`.
+// A field tagged with `guigui:"-"` is skipped. An embedded [DefaultWidget] is always skipped.
+//
+// Each field is written according to its type:
+//
+//   - A [StateKeyWriterTo] writes itself by WriteStateKeyTo.
+//   - A [Widget], or a struct whose pointer is a [Widget], is written by its identity as [StateKeyWriter.WriteWidget] does.
+//     Its contents are not walked.
+//   - A [color.Color] is written by its RGBA components.
+//   - A *[big.Int] is written as [StateKeyWriter.WriteBigInt] does.
+//   - Booleans, numbers and strings are written as the corresponding Write* methods do.
+//   - Structs are walked recursively with the same rules.
+//   - Slices and arrays are written with their lengths and elements.
+//   - Pointers and interfaces are written with whether they are nil, and then their referents.
+//
+// WriteStruct panics if a field's type cannot be written, such as a map, a func or a chan,
+// or a struct that has only unexported fields that are not written.
+// Tag such a field with `guigui:"-"`, or implement [StateKeyWriterTo].
+//
+// The plan to walk a type is computed at the first use and cached, so later calls do not inspect the type again.
+func (w *StateKeyWriter) WriteStruct(v any) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Pointer && rv.Type().Elem().Kind() == reflect.Struct:
+		if rv.IsNil() {
+			panic("guigui: WriteStruct: nil pointer")
+		}
+		rv = rv.Elem()
+	case rv.Kind() == reflect.Struct:
+		// Make the value addressable so that unexported fields and pointer-receiver methods are accessible.
+		addressable := reflect.New(rv.Type()).Elem()
+		addressable.Set(rv)
+		rv = addressable
+	default:
+		panic(fmt.Sprintf("guigui: WriteStruct: a struct or a pointer to a struct is expected but got %T", v))
+	}
+	structStateKeyPlanOf(rv.Type()).write(w, rv)
+}
+
+// stateKeyPlan is a cached way to write a value of a type into a [StateKeyWriter].
+// The value given to write must be addressable.
+type stateKeyPlan struct {
+	write func(w *StateKeyWriter, v reflect.Value)
+}
+
+type stateKeyPlanKey struct {
+	typ reflect.Type
+
+	// fields reports whether the plan walks the struct fields regardless of the type's own hooks.
+	fields bool
+}
+
+var (
+	// stateKeyPlans holds the filled plans, so that a lookup of a known type takes no lock.
+	stateKeyPlans sync.Map // map[stateKeyPlanKey]*stateKeyPlan
+
+	// stateKeyPlansM serializes building plans.
+	stateKeyPlansM sync.Mutex
+)
+
+var (
+	defaultWidgetType    = reflect.TypeFor[DefaultWidget]()
+	widgetType           = reflect.TypeFor[Widget]()
+	colorType            = reflect.TypeFor[color.Color]()
+	bigIntPtrType        = reflect.TypeFor[*big.Int]()
+	stateKeyWriterToType = reflect.TypeFor[StateKeyWriterTo]()
+)
+
+// structStateKeyPlanOf returns the plan that walks the fields of the struct type t.
+func structStateKeyPlanOf(t reflect.Type) *stateKeyPlan {
+	return filledStateKeyPlanOf(stateKeyPlanKey{typ: t, fields: true})
+}
+
+// stateKeyPlanOf returns the plan that writes a value of the type t.
+func stateKeyPlanOf(t reflect.Type) *stateKeyPlan {
+	return filledStateKeyPlanOf(stateKeyPlanKey{typ: t})
+}
+
+// filledStateKeyPlanOf returns the filled plan for key.
+// Only the first lookup of a key takes the lock.
+func filledStateKeyPlanOf(key stateKeyPlanKey) *stateKeyPlan {
+	if p, ok := stateKeyPlans.Load(key); ok {
+		return p.(*stateKeyPlan)
+	}
+
+	stateKeyPlansM.Lock()
+	defer stateKeyPlansM.Unlock()
+	if p, ok := stateKeyPlans.Load(key); ok {
+		return p.(*stateKeyPlan)
+	}
+	b := stateKeyPlanBuilder{
+		plans: map[stateKeyPlanKey]*stateKeyPlan{},
+	}
+	// If the type turns out to be unsupported, planOf panics and none of the plans built so far is published.
+	p := b.planOf(key)
+	for k, p := range b.plans {
+		stateKeyPlans.Store(k, p)
+	}
+	return p
+}
+
+// stateKeyPlanBuilder builds the plans for a type and the types it refers to.
+type stateKeyPlanBuilder struct {
+	// plans holds the plans being built, including the ones not filled yet.
+	plans map[stateKeyPlanKey]*stateKeyPlan
+}
+
+func (b *stateKeyPlanBuilder) planOf(key stateKeyPlanKey) *stateKeyPlan {
+	if p, ok := stateKeyPlans.Load(key); ok {
+		return p.(*stateKeyPlan)
+	}
+	if p, ok := b.plans[key]; ok {
+		return p
+	}
+	// Register the plan before filling it so that a recursive type refers to the same plan.
+	p := &stateKeyPlan{}
+	b.plans[key] = p
+	if key.fields {
+		p.write = b.newStructWriteFunc(key.typ)
+	} else {
+		p.write = b.newWriteFunc(key.typ)
+	}
+	return p
+}
+
+func (b *stateKeyPlanBuilder) newWriteFunc(t reflect.Type) func(w *StateKeyWriter, v reflect.Value) {
+	switch {
+	case t.Implements(stateKeyWriterToType):
+		nilable := isNilableKind(t.Kind())
+		return func(w *StateKeyWriter, v reflect.Value) {
+			if nilable {
+				if v.IsNil() {
+					w.WriteBool(false)
+					return
+				}
+				w.WriteBool(true)
+			}
+			v.Interface().(StateKeyWriterTo).WriteStateKeyTo(w)
+		}
+	case reflect.PointerTo(t).Implements(stateKeyWriterToType):
+		return func(w *StateKeyWriter, v reflect.Value) {
+			v.Addr().Interface().(StateKeyWriterTo).WriteStateKeyTo(w)
+		}
+	case t.Implements(widgetType):
+		return func(w *StateKeyWriter, v reflect.Value) {
+			if isNilableKind(v.Kind()) && v.IsNil() {
+				w.WriteWidget(nil)
+				return
+			}
+			w.WriteWidget(v.Interface().(Widget))
+		}
+	case t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(widgetType):
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteWidget(v.Addr().Interface().(Widget))
+		}
+	case t == bigIntPtrType:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			if v.IsNil() {
+				w.WriteBool(false)
+				return
+			}
+			w.WriteBool(true)
+			w.WriteBigInt(v.Interface().(*big.Int))
+		}
+	case t.Implements(colorType):
+		return func(w *StateKeyWriter, v reflect.Value) {
+			if isNilableKind(v.Kind()) && v.IsNil() {
+				w.WriteBool(false)
+				return
+			}
+			w.WriteBool(true)
+			r, g, b, a := v.Interface().(color.Color).RGBA()
+			w.WriteUint16(uint16(r))
+			w.WriteUint16(uint16(g))
+			w.WriteUint16(uint16(b))
+			w.WriteUint16(uint16(a))
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteBool(v.Bool())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteInt64(v.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteUint64(v.Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteFloat64(v.Float())
+		}
+	case reflect.Complex64, reflect.Complex128:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			c := v.Complex()
+			w.WriteFloat64(real(c))
+			w.WriteFloat64(imag(c))
+		}
+	case reflect.String:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteString(v.String())
+		}
+	case reflect.Struct:
+		// Do not take the write func here, as it is not filled yet for a recursive type.
+		fields := b.planOf(stateKeyPlanKey{typ: t, fields: true})
+		return func(w *StateKeyWriter, v reflect.Value) {
+			fields.write(w, v)
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(t.Elem()).Implements(stateKeyWriterToType) {
+			return func(w *StateKeyWriter, v reflect.Value) {
+				w.WriteInt(v.Len())
+				_, _ = w.Write(v.Bytes())
+			}
+		}
+		elem := b.planOf(stateKeyPlanKey{typ: t.Elem()})
+		return func(w *StateKeyWriter, v reflect.Value) {
+			w.WriteInt(v.Len())
+			for i := range v.Len() {
+				elem.write(w, v.Index(i))
+			}
+		}
+	case reflect.Array:
+		elem := b.planOf(stateKeyPlanKey{typ: t.Elem()})
+		return func(w *StateKeyWriter, v reflect.Value) {
+			for i := range v.Len() {
+				elem.write(w, v.Index(i))
+			}
+		}
+	case reflect.Pointer:
+		elem := b.planOf(stateKeyPlanKey{typ: t.Elem()})
+		return func(w *StateKeyWriter, v reflect.Value) {
+			if v.IsNil() {
+				w.WriteBool(false)
+				return
+			}
+			w.WriteBool(true)
+			elem.write(w, v.Elem())
+		}
+	case reflect.Interface:
+		return func(w *StateKeyWriter, v reflect.Value) {
+			if v.IsNil() {
+				w.WriteBool(false)
+				return
+			}
+			w.WriteBool(true)
+			// The dynamic value is not addressable. Copy it so that the plan can take its address.
+			e := v.Elem()
+			addressable := reflect.New(e.Type()).Elem()
+			addressable.Set(e)
+			// The plan for the dynamic type is looked up at each call, as it is unknown beforehand.
+			stateKeyPlanOf(e.Type()).write(w, addressable)
+		}
+	}
+
+	panic(fmt.Sprintf("guigui: WriteStruct: unsupported type %s", t))
+}
+
+func (b *stateKeyPlanBuilder) newStructWriteFunc(t reflect.Type) func(w *StateKeyWriter, v reflect.Value) {
+	type fieldPlan struct {
+		index    int
+		exported bool
+		plan     *stateKeyPlan
+	}
+
+	var fields []fieldPlan
+	var hasSkippedUnexportedFields bool
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("guigui")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && f.Type == defaultWidgetType {
+			continue
+		}
+		if !f.IsExported() && tag != "key" {
+			hasSkippedUnexportedFields = true
+			continue
+		}
+		if f.Type.Size() == 0 && f.Type.Kind() == reflect.Struct {
+			// Skip markers like noCopy.
+			continue
+		}
+		fields = append(fields, fieldPlan{
+			index:    i,
+			exported: f.IsExported(),
+			plan:     b.planOf(stateKeyPlanKey{typ: f.Type}),
+		})
+	}
+	if len(fields) == 0 && hasSkippedUnexportedFields {
+		panic(fmt.Sprintf("guigui: WriteStruct: %s has no fields to write; implement StateKeyWriterTo or tag the field with `guigui:\"-\"`", t))
+	}
+
+	return func(w *StateKeyWriter, v reflect.Value) {
+		for _, f := range fields {
+			fv := v.Field(f.index)
+			if !f.exported {
+				// Unexported fields cannot be read via Interface. Reinterpret the field at its address instead.
+				fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+			}
+			f.plan.write(w, fv)
+		}
+	}
+}
+
+// isNilableKind reports whether a value of the kind k can be nil.
+func isNilableKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image/color"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type stateKeyInner struct {
+	Values []int
+	Color  color.Color
+}
+
+type stateKeyOuter struct {
+	Name    string
+	Inner   stateKeyInner
+	Next    *stateKeyOuter
+	Number  *big.Int
+	Widget  guigui.Widget
+	Ignored int `guigui:"-"`
+
+	tagged   int `guigui:"key"`
+	untagged int
+}
+
+func stateKeyOfStruct(v any) uint64 {
+	return guigui.StateKeyForTesting(func(w *guigui.StateKeyWriter) {
+		w.WriteStruct(v)
+	})
+}
+
+func TestWriteStruct(t *testing.T) {
+	var w1, w2 dummyWidget
+	base := func() *stateKeyOuter {
+		return &stateKeyOuter{
+			Name: "foo",
+			Inner: stateKeyInner{
+				Values: []int{1, 2},
+				Color:  color.RGBA{R: 0xff, A: 0xff},
+			},
+			Next:   &stateKeyOuter{Name: "bar"},
+			Number: big.NewInt(1),
+			Widget: &w1,
+		}
+	}
+
+	if got, want := stateKeyOfStruct(base()), stateKeyOfStruct(base()); got != want {
+		t.Errorf("equal structs: got: %d, want: %d", got, want)
+	}
+	if got, want := stateKeyOfStruct(*base()), stateKeyOfStruct(base()); got != want {
+		t.Errorf("struct value and pointer: got: %d, want: %d", got, want)
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(v *stateKeyOuter)
+		changed bool
+	}{
+		{"string", func(v *stateKeyOuter) { v.Name = "baz" }, true},
+		{"slice", func(v *stateKeyOuter) { v.Inner.Values = append(v.Inner.Values, 3) }, true},
+		{"color", func(v *stateKeyOuter) { v.Inner.Color = color.RGBA{G: 0xff, A: 0xff} }, true},
+		{"nil color", func(v *stateKeyOuter) { v.Inner.Color = nil }, true},
+		{"pointer", func(v *stateKeyOuter) { v.Next.Name = "baz" }, true},
+		{"nil pointer", func(v *stateKeyOuter) { v.Next = nil }, true},
+		{"big.Int", func(v *stateKeyOuter) { v.Number = big.NewInt(2) }, true},
+		{"widget", func(v *stateKeyOuter) { v.Widget = &w2 }, true},
+		{"tagged unexported", func(v *stateKeyOuter) { v.tagged = 1 }, true},
+		{"excluded", func(v *stateKeyOuter) { v.Ignored = 1 }, false},
+		{"untagged unexported", func(v *stateKeyOuter) { v.untagged = 1 }, false},
+		{"widget content", func(v *stateKeyOuter) { w1.size.X = 1 }, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := base()
+			before := stateKeyOfStruct(v)
+			tc.modify(v)
+			after := stateKeyOfStruct(v)
+			if got := before != after; got != tc.changed {
+				t.Errorf("changed: got: %t, want: %t", got, tc.changed)
+			}
+		})
+	}
+}
+
+type stateKeyOpaque struct {
+	value int
+}
+
+type stateKeyWithOpaque struct {
+	Opaque stateKeyOpaque
+}
+
+type stateKeyWithMap struct {
+	Map map[string]int
+}
+
+func TestWriteStructUnsupported(t *testing.T) {
+	for _, v := range []any{
+		&stateKeyWithOpaque{},
+		&stateKeyWithMap{},
+		1,
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("WriteStruct(%T) must panic", v)
+				}
+			}()
+			stateKeyOfStruct(v)
+		}()
+	}
+}
+
+type stateKeyCyclicUnsupported struct {
+	Back   *stateKeyCyclicBack
+	Opaque stateKeyOpaque
+}
+
+type stateKeyCyclicBack struct {
+	Next *stateKeyCyclicUnsupported
+}
+
+func TestWriteStructUnsupportedCycle(t *testing.T) {
+	// The plan of stateKeyCyclicBack refers to the one of stateKeyCyclicUnsupported, which fails to build.
+	// Neither must be cached, so that writing stateKeyCyclicBack panics in the same way instead of
+	// calling an unfilled plan.
+	for _, v := range []any{
+		&stateKeyCyclicUnsupported{},
+		&stateKeyCyclicBack{Next: &stateKeyCyclicUnsupported{}},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				msg, ok := r.(string)
+				if !ok || !strings.HasPrefix(msg, "guigui: WriteStruct:") {
+					t.Errorf("WriteStruct(%T): got panic: %v, want: a WriteStruct panic", v, r)
+				}
+			}()
+			stateKeyOfStruct(v)
+		}()
+	}
+}
@@ -18,14 +18,23 @@ import (
 //
 // StateKeyWriter implements [io.Writer] as an escape hatch for variable-length
 // byte content.
+//
+// To test or benchmark a WriteStateKey method outside the framework, create a writer by [NewStateKeyWriter].
 type StateKeyWriter struct {
-	h      hash.Hash64 // FNV-1a 64-bit, lazily created.
+	h      hash.Hash64 // FNV-1a 64-bit
 	buf    [8]byte
 	strbuf []byte
 }
 
 var _ io.Writer = (*StateKeyWriter)(nil)
 
+// NewStateKeyWriter creates a new StateKeyWriter.
+func NewStateKeyWriter() *StateKeyWriter {
+	return &StateKeyWriter{
+		h: fnv.New64a(),
+	}
+}
+
 func (w *StateKeyWriter) reset() {
 	if w.h == nil {
 		w.h = fnv.New64a()
@@ -33,7 +42,8 @@ func (w *StateKeyWriter) reset() {
 	w.h.Reset()
 }
 
-func (w *StateKeyWriter) sum64() uint64 {
+// Sum64 returns the hash of what has been written.
+func (w *StateKeyWriter) Sum64() uint64 {
 	return w.h.Sum64()
 }
 
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Timer is a callback scheduled by [Context.AfterFunc] or [Context.Every].
+//
+// A Timer is bound to a widget's lifetime: it is stopped automatically when the widget leaves the tree.
+type Timer struct {
+	widgetState   *widgetState
+	dueTick       int64
+	intervalTicks int64
+	f             func(context *Context)
+	stopped       bool
+}
+
+// Stop prevents the timer from firing.
+// Stop reports whether the call stops the timer, and returns false if the timer has already expired
+// or been stopped.
+//
+// Stop must be called on the UI goroutine.
+func (t *Timer) Stop() bool {
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
+
+// durationToTicks converts d to the number of ticks at the current TPS, rounding up.
+// The result is at least 1, so a callback never runs in the same tick it is scheduled.
+func durationToTicks(d time.Duration) int64 {
+	tps := ebiten.TPS()
+	if tps <= 0 {
+		// Guard against a non-positive rate (e.g. SyncWithFPS).
+		tps = ebiten.DefaultTPS
+	}
+	return max(int64(math.Ceil(d.Seconds()*float64(tps))), 1)
+}
+
+// AfterFunc schedules f to be called once on the UI goroutine after the duration d elapses.
+//
+// The duration is measured in ticks at the current TPS, so a timer advances with the application loop.
+// The timer is stopped automatically when widget leaves the tree, so widget must be in the tree when
+// the timer fires, e.g., AfterFunc can be called in [Widget.Build], [Widget.Tick], or an event handler.
+//
+// f does not rebuild the widget tree by itself. After f is called, the widgets whose
+// [Widget.WriteStateKey] or observed [Observable] values changed are rebuilt, as after [Post].
+// Thus, a frequent timer that changes nothing costs no rebuild.
+func (c *Context) AfterFunc(widget Widget, d time.Duration, f func(context *Context)) *Timer {
+	return c.app.addTimer(widget, durationToTicks(d), 0, f)
+}
+
+// Every schedules f to be called repeatedly on the UI goroutine with the interval d,
+// until the returned timer is stopped or widget leaves the tree.
+//
+// See [Context.AfterFunc] for details.
+func (c *Context) Every(widget Widget, d time.Duration, f func(context *Context)) *Timer {
+	ticks := durationToTicks(d)
+	return c.app.addTimer(widget, ticks, ticks, f)
+}
+
+func (a *app) addTimer(widget Widget, ticks int64, intervalTicks int64, f func(context *Context)) *Timer {
+	t := &Timer{
+		widgetState:   widget.widgetState(),
+		dueTick:       a.tickCount + ticks,
+		intervalTicks: intervalTicks,
+		f:             f,
+	}
+	a.timers = append(a.timers, t)
+	return t
+}
+
+// runTimers calls the callbacks of the expired timers.
+// Timers whose widgets are no longer in the tree are stopped and removed.
+func (a *app) runTimers() {
+	if len(a.timers) == 0 {
+		return
+	}
+	// A callback might add a new timer. Iterate only the timers existing at this point.
+	n := len(a.timers)
+	for i := 0; i < n; i++ {
+		t := a.timers[i]
+		if t.stopped {
+			continue
+		}
+		if !t.widgetState.isInTree(a.buildCount) {
+			t.stopped = true
+			continue
+		}
+		if a.tickCount < t.dueTick {
+			continue
+		}
+		if t.intervalTicks > 0 {
+			t.dueTick += t.intervalTicks
+			// Skip the missed intervals, e.g. when the interval is shorter than a tick.
+			if t.dueTick <= a.tickCount {
+				t.dueTick = a.tickCount + t.intervalTicks
+			}
+		} else {
+			t.stopped = true
+		}
+		a.stateKeyCheckPending = true
+		t.f(&a.context)
+	}
+
+	// Remove the stopped timers so they do not keep their widgets reachable for the GC.
+	a.timers = slices.DeleteFunc(a.timers, func(t *Timer) bool {
+		return t.stopped
+	})
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"image"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type timerRoot struct {
+	guigui.DefaultWidget
+
+	child     countingWidget
+	withChild bool
+	value     int
+}
+
+func (r *timerRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	if r.withChild {
+		adder.AddWidget(&r.child)
+	}
+	return nil
+}
+
+func (r *timerRoot) WriteStateKey(context *guigui.Context, w *guigui.StateKeyWriter) {
+	w.WriteBool(r.withChild)
+	w.WriteInt(r.value)
+}
+
+// updateTimes calls app.Update n times, and returns the update counts, starting at 1, when
+// the fired count increased.
+func updateTimes(t *testing.T, app *guigui.AppForTesting, n int, fired *int) []int {
+	t.Helper()
+	var counts []int
+	for i := range n {
+		before := *fired
+		if err := app.Update(); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if *fired > before {
+			counts = append(counts, i+1)
+		}
+	}
+	return counts
+}
+
+func TestDurationToTicks(t *testing.T) {
+	testCases := []struct {
+		d    time.Duration
+		want int64
+	}{
+		{d: 0, want: 1},
+		{d: -time.Second, want: 1},
+		{d: time.Millisecond, want: 1},
+		{d: 16 * time.Millisecond, want: 1},
+		{d: 17 * time.Millisecond, want: 2},
+		{d: 100 * time.Millisecond, want: 6},
+		{d: time.Second, want: 60},
+	}
+	for _, tc := range testCases {
+		if got := guigui.DurationToTicksForTesting(tc.d); got != tc.want {
+			t.Errorf("durationToTicks(%v): got: %d, want: %d", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestAfterFunc(t *testing.T) {
+	var root timerRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	var fired int
+	app.Context().AfterFunc(&root, 17*time.Millisecond, func(context *guigui.Context) {
+		fired++
+	})
+	// The timer is scheduled after the first update, and fires 2 ticks later.
+	if got, want := updateTimes(t, app, 10, &fired), []int{2}; !slices.Equal(got, want) {
+		t.Errorf("fired updates: got: %v, want: %v", got, want)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	var root timerRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	var fired int
+	app.Context().Every(&root, 17*time.Millisecond, func(context *guigui.Context) {
+		fired++
+	})
+	if got, want := updateTimes(t, app, 8, &fired), []int{2, 4, 6, 8}; !slices.Equal(got, want) {
+		t.Errorf("fired updates: got: %v, want: %v", got, want)
+	}
+}
+
+func TestTimerStop(t *testing.T) {
+	var root timerRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	var fired int
+	timer := app.Context().Every(&root, 17*time.Millisecond, func(context *guigui.Context) {
+		fired++
+	})
+	if got, want := updateTimes(t, app, 2, &fired), []int{2}; !slices.Equal(got, want) {
+		t.Errorf("fired updates before Stop: got: %v, want: %v", got, want)
+	}
+	if !timer.Stop() {
+		t.Errorf("the first Stop: got: false, want: true")
+	}
+	if timer.Stop() {
+		t.Errorf("the second Stop: got: true, want: false")
+	}
+	if got := updateTimes(t, app, 8, &fired); len(got) != 0 {
+		t.Errorf("fired updates after Stop: got: %v, want: none", got)
+	}
+
+	// Stop on an expired one-shot timer returns false.
+	oneShot := app.Context().AfterFunc(&root, 0, func(context *guigui.Context) {
+		fired++
+	})
+	if got, want := updateTimes(t, app, 2, &fired), []int{1}; !slices.Equal(got, want) {
+		t.Errorf("fired updates of the one-shot timer: got: %v, want: %v", got, want)
+	}
+	if oneShot.Stop() {
+		t.Errorf("Stop after the timer expired: got: true, want: false")
+	}
+}
+
+func TestTimerStopsWhenWidgetLeavesTree(t *testing.T) {
+	var root timerRoot
+	root.withChild = true
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if !app.IsInTree(&root.child) {
+		t.Fatalf("the child is not in the tree")
+	}
+
+	var fired int
+	timer := app.Context().Every(&root.child, 17*time.Millisecond, func(context *guigui.Context) {
+		fired++
+	})
+	if got, want := updateTimes(t, app, 2, &fired), []int{2}; !slices.Equal(got, want) {
+		t.Errorf("fired updates before removal: got: %v, want: %v", got, want)
+	}
+
+	guigui.Post(func(context *guigui.Context) {
+		root.withChild = false
+	})
+	if got := updateTimes(t, app, 8, &fired); len(got) != 0 {
+		t.Errorf("fired updates after removal: got: %v, want: none", got)
+	}
+	if app.IsInTree(&root.child) {
+		t.Errorf("the child is in the tree after removal")
+	}
+	if timer.Stop() {
+		t.Errorf("Stop after removal: got: true, want: false")
+	}
+}
+
+func TestTimerRebuildsOnlyOnStateChange(t *testing.T) {
+	var root timerRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	if err := app.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	// A timer that changes nothing does not rebuild the tree.
+	var fired int
+	timer := app.Context().Every(&root, 0, func(context *guigui.Context) {
+		fired++
+	})
+	buildCount := app.BuildCount()
+	if got, want := updateTimes(t, app, 3, &fired), []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("fired updates: got: %v, want: %v", got, want)
+	}
+	if got := app.BuildCount(); got != buildCount {
+		t.Errorf("build count after no-op timers: got: %d, want: %d", got, buildCount)
+	}
+	timer.Stop()
+
+	// A timer that changes the state key rebuilds the tree.
+	app.Context().AfterFunc(&root, 0, func(context *guigui.Context) {
+		fired++
+		root.value++
+	})
+	if got, want := updateTimes(t, app, 2, &fired), []int{1}; !slices.Equal(got, want) {
+		t.Errorf("fired updates: got: %v, want: %v", got, want)
+	}
+	if got := app.BuildCount(); got <= buildCount {
+		t.Errorf("build count after a state change: got: %d, want: > %d", got, buildCount)
+	}
+}
@@ -179,15 +179,15 @@ func (w *WidgetWithSize[T]) Measure(context *Context, constraints Constraints) i
 	}
 	if w.fixedSizePlus1.X > 0 {
 		// TODO: Consider constraints.
-		s := w.Widget().Measure(context, FixedWidthConstraints(w.fixedSizePlus1.X-1))
+		s := measureWidget(context, w.Widget(), FixedWidthConstraints(w.fixedSizePlus1.X-1))
 		return image.Pt(w.fixedSizePlus1.X-1, s.Y)
 	}
 	if w.fixedSizePlus1.Y > 0 {
 		// TODO: Consider constraints.
-		s := w.Widget().Measure(context, FixedHeightConstraints(w.fixedSizePlus1.Y-1))
+		s := measureWidget(context, w.Widget(), FixedHeightConstraints(w.fixedSizePlus1.Y-1))
 		return image.Pt(s.X, w.fixedSizePlus1.Y-1)
 	}
-	return w.Widget().Measure(context, constraints)
+	return measureWidget(context, w.Widget(), constraints)
 }
 
 type WidgetWithPadding[T Widget] struct {
@@ -228,7 +228,7 @@ func (w *WidgetWithPadding[T]) Measure(context *Context, constraints Constraints
 	if fixedHeight, ok := constraints.FixedHeight(); ok {
 		constraints = FixedHeightConstraints(fixedHeight - w.padding.Top - w.padding.Bottom)
 	}
-	s := w.Widget().Measure(context, constraints)
+	s := measureWidget(context, w.Widget(), constraints)
 	s.X += w.padding.Start + w.padding.End
 	s.Y += w.padding.Top + w.padding.Bottom
 	return s
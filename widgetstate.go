@@ -131,6 +131,7 @@ type widgetState struct {
 	buttonInputReceptive bool
 	layer                int64
 	transparency         float64
+	readingDirection     ReadingDirection
 
 	// eventHandlers is a collection of event handlers.
 	// eventHandlers is reset whenever the widget is rebuilt.
@@ -151,6 +152,11 @@ type widgetState struct {
 	passthroughCache      bool
 	passthroughCacheValid bool
 
+	// readingDirectionCache is the direction set on the widget or the nearest ancestor,
+	// or ReadingDirectionAuto if none is set.
+	readingDirectionCache      ReadingDirection
+	readingDirectionCacheValid bool
+
 	focusedOrHasFocusedDescendant                bool
 	buttonInputReceptiveOrHasReceptiveDescendant bool
 
@@ -163,6 +169,12 @@ type widgetState struct {
 	capturedStateKey         uint64
 	capturedInternalStateKey widgetInternalStateKey
 
+	// observedValues are the [Observable] values read in the last [Widget.Build].
+	observedValues []observedValue
+
+	// errorBoundary is the nearest [ErrorBoundary] enclosing the widget, or nil.
+	errorBoundary errorBoundary
+
 	// redrawRequestedAt records the call site of the last RequestRedraw, for debug logging only.
 	redrawRequestedAt string
 
@@ -182,6 +194,7 @@ type widgetState struct {
 
 type eventHandler struct {
 	key     EventKey
+	phase   EventPhase
 	handler any
 }
 
@@ -253,6 +266,21 @@ func (w *widgetState) isEnabled() bool {
 	return w.enabledCache
 }
 
+func (w *widgetState) inheritedReadingDirection() ReadingDirection {
+	if w.readingDirectionCacheValid {
+		return w.readingDirectionCache
+	}
+	w.readingDirectionCacheValid = true
+	if w.readingDirection != ReadingDirectionAuto {
+		w.readingDirectionCache = w.readingDirection
+	} else if w.parent != nil {
+		w.readingDirectionCache = w.parent.widgetState().inheritedReadingDirection()
+	} else {
+		w.readingDirectionCache = ReadingDirectionAuto
+	}
+	return w.readingDirectionCache
+}
+
 func (w *widgetState) isPassthrough() bool {
 	if w.passthroughCacheValid {
 		return w.passthroughCache
@@ -341,15 +369,10 @@ func traverseWidget(widget Widget, f func(widget Widget) error) error {
 // If a handler is already registered for the same key, it is replaced.
 // All event handlers are reset before the build phase starts,
 // so SetEventHandler must be called during every Build to keep the handler active.
+//
+// For compile-time type checking of handlers, use type-safe event keys such as [EventKey1] instead.
 func SetEventHandler(widget Widget, eventKey EventKey, handler any) {
-	widgetState := widget.widgetState()
-	widgetState.eventHandlers = slices.DeleteFunc(widgetState.eventHandlers, func(h eventHandler) bool {
-		return h.key == eventKey
-	})
-	widgetState.eventHandlers = append(widgetState.eventHandlers, eventHandler{
-		key:     eventKey,
-		handler: handler,
-	})
+	setEventHandler(widget, eventKey, EventPhaseTarget, handler)
 }
 
 // DispatchEvent invokes the event handler registered for the given event key on the widget.
@@ -357,19 +380,21 @@ func SetEventHandler(widget Widget, eventKey EventKey, handler any) {
 // as all handlers are reset before each build.
 // args are passed to the handler after the *Context argument.
 // It returns the handler's return values and true if a handler was found, or nil and false otherwise.
+//
+// DispatchEvent calls the handler via reflection, and a mismatched handler signature fails only at runtime.
+// The event does not propagate to the widget's ancestors.
+// For compile-time type checking, use type-safe event keys such as [EventKey1] instead.
 func DispatchEvent(widget Widget, eventKey EventKey, args ...any) ([]any, bool) {
 	widgetState := widget.widgetState()
-	for _, h := range widgetState.eventHandlers {
-		if h.key != eventKey {
-			continue
-		}
-		argValues := make([]reflect.Value, len(args))
-		for i, a := range args {
-			argValues[i] = reflect.ValueOf(a)
-		}
-		return invokeEventHandler(widgetState, h.handler, argValues), true
+	h, ok := findEventHandler(widgetState, eventKey, EventPhaseTarget)
+	if !ok {
+		return nil, false
+	}
+	argValues := make([]reflect.Value, len(args))
+	for i, a := range args {
+		argValues[i] = reflect.ValueOf(a)
 	}
-	return nil, false
+	return invokeEventHandler(widgetState, h, argValues), true
 }
 
 // DispatchEventLazy is like [DispatchEvent] but defers argument construction.
@@ -379,25 +404,22 @@ func DispatchEvent(widget Widget, eventKey EventKey, args ...any) ([]any, bool)
 // materializing a large string) when no listener is attached.
 func DispatchEventLazy(widget Widget, eventKey EventKey, argsFunc any) ([]any, bool) {
 	widgetState := widget.widgetState()
-	for _, h := range widgetState.eventHandlers {
-		if h.key != eventKey {
-			continue
-		}
-		args := reflect.ValueOf(argsFunc).Call(nil)
-		return invokeEventHandler(widgetState, h.handler, args), true
+	h, ok := findEventHandler(widgetState, eventKey, EventPhaseTarget)
+	if !ok {
+		return nil, false
 	}
-	return nil, false
+	args := reflect.ValueOf(argsFunc).Call(nil)
+	return invokeEventHandler(widgetState, h, args), true
 }
 
 func invokeEventHandler(widgetState *widgetState, handler any, args []reflect.Value) []any {
 	f := reflect.ValueOf(handler)
 	widgetState.tmpArgs = slices.Delete(widgetState.tmpArgs, 0, len(widgetState.tmpArgs))
-	widgetState.tmpArgs = append(widgetState.tmpArgs, reflect.ValueOf(&theApp.context))
+	widgetState.tmpArgs = append(widgetState.tmpArgs, reflect.ValueOf(&currentApp().context))
 	widgetState.tmpArgs = append(widgetState.tmpArgs, args...)
 	results := f.Call(widgetState.tmpArgs)
 	widgetState.tmpArgs = slices.Delete(widgetState.tmpArgs, 0, len(widgetState.tmpArgs))
-	widgetState.eventDispatched = true
-	theApp.hasDirtyWidgets = true
+	markEventDispatched(widgetState)
 	if len(results) == 0 {
 		return nil
 	}
@@ -408,11 +430,18 @@ func invokeEventHandler(widgetState *widgetState, handler any, args []reflect.Va
 	return ret
 }
 
-var widgetEventFocusChanged EventKey = GenerateEventKey()
+// markEventDispatched marks that an event handler on the widget was invoked,
+// so the tree is rebuilt next time.
+func markEventDispatched(widgetState *widgetState) {
+	widgetState.eventDispatched = true
+	currentApp().hasDirtyWidgets = true
+}
+
+var widgetEventFocusChanged = GenerateEventKey1[bool]()
 
 // TODO: For focus delegation, create a new function (#340).
 func OnFocusChanged(widget Widget, onfocus func(context *Context, focused bool)) {
-	SetEventHandler(widget, widgetEventFocusChanged, onfocus)
+	widgetEventFocusChanged.SetHandler(widget, onfocus)
 }
 
 // noCopy is a struct to warn that the struct should not be copied.
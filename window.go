@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// WindowState is the placement of the window.
+//
+// An application can save the window state on exit and restore it on the next launch
+// by [RunOptions.WindowState].
+type WindowState struct {
+	// Position is the position of the window's upper-left corner in the desktop, in device-independent pixels.
+	Position image.Point
+
+	// Size is the window size in device-independent pixels.
+	//
+	// Position and Size are the last ones while the window was neither maximized, minimized nor fullscreen,
+	// so that restoring the window state restores the original geometry under a maximized window.
+	Size image.Point
+
+	// Maximized reports whether the window is maximized.
+	Maximized bool
+
+	// Fullscreen reports whether the window is fullscreen.
+	Fullscreen bool
+}
+
+// windowSystem is the window operations of Ebitengine that the window events and options depend on.
+type windowSystem interface {
+	IsFocused() bool
+	IsWindowBeingClosed() bool
+	SetWindowClosingHandled(handled bool)
+	WindowPosition() (x, y int)
+	SetWindowPosition(x, y int)
+	WindowSize() (width, height int)
+	SetWindowSize(width, height int)
+	IsFullscreen() bool
+	SetFullscreen(fullscreen bool)
+	IsWindowMaximized() bool
+	MaximizeWindow()
+	IsWindowMinimized() bool
+	MinimizeWindow()
+	RestoreWindow()
+	SetWindowIcon(images []image.Image)
+}
+
+// ebitenWindowSystem is the windowSystem of Ebitengine's window.
+type ebitenWindowSystem struct{}
+
+func (ebitenWindowSystem) IsFocused() bool {
+	return ebiten.IsFocused()
+}
+
+func (ebitenWindowSystem) IsWindowBeingClosed() bool {
+	return ebiten.IsWindowBeingClosed()
+}
+
+func (ebitenWindowSystem) SetWindowClosingHandled(handled bool) {
+	ebiten.SetWindowClosingHandled(handled)
+}
+
+func (ebitenWindowSystem) WindowPosition() (x, y int) {
+	return ebiten.WindowPosition()
+}
+
+func (ebitenWindowSystem) SetWindowPosition(x, y int) {
+	ebiten.SetWindowPosition(x, y)
+}
+
+func (ebitenWindowSystem) WindowSize() (width, height int) {
+	return ebiten.WindowSize()
+}
+
+func (ebitenWindowSystem) SetWindowSize(width, height int) {
+	ebiten.SetWindowSize(width, height)
+}
+
+func (ebitenWindowSystem) IsFullscreen() bool {
+	return ebiten.IsFullscreen()
+}
+
+func (ebitenWindowSystem) SetFullscreen(fullscreen bool) {
+	ebiten.SetFullscreen(fullscreen)
+}
+
+func (ebitenWindowSystem) IsWindowMaximized() bool {
+	return ebiten.IsWindowMaximized()
+}
+
+func (ebitenWindowSystem) MaximizeWindow() {
+	ebiten.MaximizeWindow()
+}
+
+func (ebitenWindowSystem) IsWindowMinimized() bool {
+	return ebiten.IsWindowMinimized()
+}
+
+func (ebitenWindowSystem) MinimizeWindow() {
+	ebiten.MinimizeWindow()
+}
+
+func (ebitenWindowSystem) RestoreWindow() {
+	ebiten.RestoreWindow()
+}
+
+func (ebitenWindowSystem) SetWindowIcon(images []image.Image) {
+	ebiten.SetWindowIcon(images)
+}
+
+// theWindowSystem is the window of the application. Tests replace it with a fake window.
+var theWindowSystem windowSystem = ebitenWindowSystem{}
+
+var (
+	windowEventCloseRequested = GenerateEventKey0R[bool]()
+	windowEventFocusChanged   = GenerateEventKey1[bool]()
+	windowEventStateChanged   = GenerateEventKey1[WindowState]()
+)
+
+// OnWindowCloseRequested sets the handler invoked when the user tries to close the window,
+// e.g. by the window's close button.
+// The handler returns true to close the window, or false to keep it open.
+// Return false to ask the user to save changes first, and call [Context.CloseWindow] after the user answers.
+//
+// root must be the root widget passed to [Run]. The handler is usually set in the root widget's Build.
+// As long as a handler is set, the window is not closed without the handler's consent.
+// Set a handler only when it is needed, e.g. only when there are unsaved changes,
+// as intercepting closing affects the window's appearance on some platforms,
+// such as the edited-document indicator on macOS.
+func OnWindowCloseRequested(root Widget, f func(context *Context) bool) {
+	windowEventCloseRequested.SetHandler(root, f)
+}
+
+// OnWindowFocusChanged sets the handler invoked when the window gains or loses the focus.
+//
+// root must be the root widget passed to [Run].
+func OnWindowFocusChanged(root Widget, f func(context *Context, focused bool)) {
+	windowEventFocusChanged.SetHandler(root, f)
+}
+
+// OnWindowStateChanged sets the handler invoked when the window is moved, resized, maximized, restored,
+// or switched to or from fullscreen.
+// The handler is invoked every tick while the window is being moved or resized.
+// To persist the window state, save the latest [WindowState] when the application exits rather than in the handler.
+//
+// root must be the root widget passed to [Run].
+func OnWindowStateChanged(root Widget, f func(context *Context, state WindowState)) {
+	windowEventStateChanged.SetHandler(root, f)
+}
+
+// CloseWindow closes the window and ends the application after the current tick.
+// The handler set by [OnWindowCloseRequested] is not invoked.
+func (c *Context) CloseWindow() {
+	c.app.closeWindowRequested = true
+}
+
+// IsWindowFocused reports whether the window has the focus.
+func (c *Context) IsWindowFocused() bool {
+	return theWindowSystem.IsFocused()
+}
+
+// WindowState returns the current window state.
+func (c *Context) WindowState() WindowState {
+	return c.app.windowState
+}
+
+// SetWindowPosition sets the position of the window's upper-left corner in the desktop, in device-independent pixels.
+func (c *Context) SetWindowPosition(x, y int) {
+	theWindowSystem.SetWindowPosition(x, y)
+}
+
+// IsFullscreen reports whether the window is fullscreen.
+func (c *Context) IsFullscreen() bool {
+	return theWindowSystem.IsFullscreen()
+}
+
+// SetFullscreen switches the window to or from fullscreen.
+func (c *Context) SetFullscreen(fullscreen bool) {
+	theWindowSystem.SetFullscreen(fullscreen)
+}
+
+// MaximizeWindow maximizes the window.
+func (c *Context) MaximizeWindow() {
+	theWindowSystem.MaximizeWindow()
+}
+
+// MinimizeWindow minimizes the window.
+func (c *Context) MinimizeWindow() {
+	theWindowSystem.MinimizeWindow()
+}
+
+// RestoreWindow restores the window from the maximized or minimized state.
+func (c *Context) RestoreWindow() {
+	theWindowSystem.RestoreWindow()
+}
+
+// IsWindowMaximized reports whether the window is maximized.
+func (c *Context) IsWindowMaximized() bool {
+	return theWindowSystem.IsWindowMaximized()
+}
+
+// IsWindowMinimized reports whether the window is minimized.
+func (c *Context) IsWindowMinimized() bool {
+	return theWindowSystem.IsWindowMinimized()
+}
+
+// SetWindowIcon sets the window icon.
+// Provide images of several sizes, e.g. 16x16, 32x32 and 48x48, and the most suitable one is used.
+// SetWindowIcon does nothing on macOS, where the application bundle's icon is used.
+func (c *Context) SetWindowIcon(images []image.Image) {
+	theWindowSystem.SetWindowIcon(images)
+}
+
+// applyWindowOptions applies the window options before the game starts.
+func applyWindowOptions(options *RunOptions) {
+	if options.WindowState != nil {
+		restoreWindowState(options.WindowState)
+	}
+	if options.Fullscreen {
+		theWindowSystem.SetFullscreen(true)
+	}
+	if len(options.WindowIcon) > 0 {
+		theWindowSystem.SetWindowIcon(options.WindowIcon)
+	}
+}
+
+// restoreWindowState applies the window state before the game starts.
+func restoreWindowState(state *WindowState) {
+	if state.Size.X > 0 && state.Size.Y > 0 {
+		theWindowSystem.SetWindowSize(state.Size.X, state.Size.Y)
+	}
+	theWindowSystem.SetWindowPosition(state.Position.X, state.Position.Y)
+	if state.Maximized {
+		theWindowSystem.MaximizeWindow()
+	}
+	if state.Fullscreen {
+		theWindowSystem.SetFullscreen(true)
+	}
+}
+
+// updateWindowState updates the window state and reports whether it is changed.
+func (a *app) updateWindowState() bool {
+	s := a.windowState
+	s.Maximized = theWindowSystem.IsWindowMaximized()
+	s.Fullscreen = theWindowSystem.IsFullscreen()
+	if !s.Maximized && !s.Fullscreen && !theWindowSystem.IsWindowMinimized() {
+		x, y := theWindowSystem.WindowPosition()
+		s.Position = image.Pt(x, y)
+		w, h := theWindowSystem.WindowSize()
+		s.Size = image.Pt(w, h)
+	}
+	if s == a.windowState {
+		return false
+	}
+	a.windowState = s
+	return true
+}
+
+// updateFocused updates whether the window has the focus and reports whether it is changed.
+func (a *app) updateFocused() bool {
+	focused := theWindowSystem.IsFocused()
+	if focused == a.lastFocused {
+		return false
+	}
+	a.lastFocused = focused
+	return true
+}
+
+// handleWindowEvents dispatches the window events on the root widget.
+// handleWindowEvents returns true if the window should be closed.
+func (a *app) handleWindowEvents(focusChanged bool) bool {
+	if focusChanged {
+		windowEventFocusChanged.Dispatch(a.root, a.lastFocused)
+	}
+	if a.updateWindowState() {
+		windowEventStateChanged.Dispatch(a.root, a.windowState)
+	}
+
+	// Leave the interception alone if there is no handler, as the application might call
+	// ebiten.SetWindowClosingHandled and ebiten.IsWindowBeingClosed by itself.
+	if !windowEventCloseRequested.HasHandler(a.root) {
+		if a.windowClosingHandled {
+			theWindowSystem.SetWindowClosingHandled(false)
+			a.windowClosingHandled = false
+		}
+		return false
+	}
+	if theWindowSystem.IsWindowBeingClosed() {
+		if close, _ := windowEventCloseRequested.Dispatch(a.root); close {
+			return true
+		}
+	}
+	if !a.windowClosingHandled {
+		theWindowSystem.SetWindowClosingHandled(true)
+		a.windowClosingHandled = true
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Guigui Authors
+
+package guigui_test
+
+import (
+	"errors"
+	"image"
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/guigui-gui/guigui"
+)
+
+type windowRoot struct {
+	guigui.DefaultWidget
+
+	closeRequested func(context *guigui.Context) bool
+
+	focusChanges []bool
+	states       []guigui.WindowState
+}
+
+func (w *windowRoot) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	if w.closeRequested != nil {
+		guigui.OnWindowCloseRequested(w, w.closeRequested)
+	}
+	guigui.OnWindowFocusChanged(w, func(context *guigui.Context, focused bool) {
+		w.focusChanges = append(w.focusChanges, focused)
+	})
+	guigui.OnWindowStateChanged(w, func(context *guigui.Context, state guigui.WindowState) {
+		w.states = append(w.states, state)
+	})
+	return nil
+}
+
+func TestWindowCloseRequested(t *testing.T) {
+	var root windowRoot
+	var canClose bool
+	var requests int
+	root.closeRequested = func(context *guigui.Context) bool {
+		requests++
+		return canClose
+	}
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	window := app.Window()
+
+	updateApp(t, app)
+	if !window.ClosingHandled {
+		t.Errorf("ClosingHandled with a handler: got: false, want: true")
+	}
+	if requests != 0 {
+		t.Errorf("requests without closing: got: %d, want: 0", requests)
+	}
+
+	// The handler vetoes closing.
+	window.BeingClosed = true
+	updateApp(t, app)
+	if requests != 1 {
+		t.Errorf("requests: got: %d, want: 1", requests)
+	}
+	if !window.ClosingHandled {
+		t.Errorf("ClosingHandled after the veto: got: false, want: true")
+	}
+
+	// The handler consents to closing.
+	canClose = true
+	if err := app.Update(); !errors.Is(err, ebiten.Termination) {
+		t.Errorf("Update: got: %v, want: %v", err, ebiten.Termination)
+	}
+	if requests != 2 {
+		t.Errorf("requests: got: %d, want: 2", requests)
+	}
+}
+
+func TestWindowCloseRequestedHandlerRemoved(t *testing.T) {
+	var root windowRoot
+	root.closeRequested = func(context *guigui.Context) bool {
+		return false
+	}
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	window := app.Window()
+
+	updateApp(t, app)
+	if !window.ClosingHandled {
+		t.Errorf("ClosingHandled with a handler: got: false, want: true")
+	}
+
+	root.closeRequested = nil
+	guigui.RequestRebuild()
+	updateApp(t, app)
+	if window.ClosingHandled {
+		t.Errorf("ClosingHandled after removing the handler: got: true, want: false")
+	}
+
+	// Without a handler, the interception is left to the application.
+	window.ClosingHandled = true
+	window.BeingClosed = true
+	updateApp(t, app)
+	if !window.ClosingHandled {
+		t.Errorf("ClosingHandled set by the application: got: false, want: true")
+	}
+}
+
+func TestWindowCloseWindow(t *testing.T) {
+	var root windowRoot
+	root.closeRequested = func(context *guigui.Context) bool {
+		t.Errorf("the handler must not be invoked by CloseWindow")
+		return false
+	}
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+
+	updateApp(t, app)
+	app.Context().CloseWindow()
+	if err := app.Update(); !errors.Is(err, ebiten.Termination) {
+		t.Errorf("Update: got: %v, want: %v", err, ebiten.Termination)
+	}
+}
+
+func TestWindowFocusChanged(t *testing.T) {
+	var root windowRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	window := app.Window()
+
+	updateApp(t, app)
+	if len(root.focusChanges) != 0 {
+		t.Errorf("focus changes without a change: got: %v, want: none", root.focusChanges)
+	}
+
+	window.Focused = true
+	updateApp(t, app)
+	updateApp(t, app)
+	window.Focused = false
+	updateApp(t, app)
+	if want := []bool{true, false}; !slices.Equal(root.focusChanges, want) {
+		t.Errorf("focus changes: got: %v, want: %v", root.focusChanges, want)
+	}
+}
+
+func TestWindowStateChanged(t *testing.T) {
+	var root windowRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	window := app.Window()
+	context := app.Context()
+
+	updateApp(t, app)
+	if len(root.states) != 0 {
+		t.Errorf("states without a change: got: %v, want: none", root.states)
+	}
+
+	window.Position = image.Pt(10, 20)
+	window.Size = image.Pt(640, 480)
+	updateApp(t, app)
+	updateApp(t, app)
+	normal := guigui.WindowState{
+		Position: image.Pt(10, 20),
+		Size:     image.Pt(640, 480),
+	}
+	if want := []guigui.WindowState{normal}; !slices.Equal(root.states, want) {
+		t.Errorf("states after moving: got: %v, want: %v", root.states, want)
+	}
+
+	// A maximized window keeps the geometry before maximizing.
+	root.states = root.states[:0]
+	context.MaximizeWindow()
+	window.Position = image.Pt(0, 0)
+	window.Size = image.Pt(1920, 1080)
+	updateApp(t, app)
+	maximized := normal
+	maximized.Maximized = true
+	if want := []guigui.WindowState{maximized}; !slices.Equal(root.states, want) {
+		t.Errorf("states after maximizing: got: %v, want: %v", root.states, want)
+	}
+
+	// A minimized window keeps the geometry too.
+	root.states = root.states[:0]
+	context.RestoreWindow()
+	window.Position = normal.Position
+	window.Size = normal.Size
+	updateApp(t, app)
+	context.MinimizeWindow()
+	window.Position = image.Pt(-32000, -32000)
+	updateApp(t, app)
+	if want := []guigui.WindowState{normal}; !slices.Equal(root.states, want) {
+		t.Errorf("states after restoring and minimizing: got: %v, want: %v", root.states, want)
+	}
+
+	root.states = root.states[:0]
+	context.RestoreWindow()
+	window.Position = normal.Position
+	context.SetFullscreen(true)
+	updateApp(t, app)
+	fullscreen := normal
+	fullscreen.Fullscreen = true
+	if want := []guigui.WindowState{fullscreen}; !slices.Equal(root.states, want) {
+		t.Errorf("states after switching to fullscreen: got: %v, want: %v", root.states, want)
+	}
+	if got := context.WindowState(); got != fullscreen {
+		t.Errorf("WindowState: got: %v, want: %v", got, fullscreen)
+	}
+}
+
+func TestWindowOptions(t *testing.T) {
+	var root windowRoot
+	app := guigui.NewAppForTesting(&root, image.Pt(100, 100))
+	window := app.Window()
+
+	icon := []image.Image{image.NewRGBA(image.Rect(0, 0, 16, 16))}
+	guigui.ApplyWindowOptionsForTesting(&guigui.RunOptions{
+		WindowState: &guigui.WindowState{
+			Position:  image.Pt(10, 20),
+			Size:      image.Pt(640, 480),
+			Maximized: true,
+		},
+		WindowIcon: icon,
+	})
+	if got, want := window.Position, image.Pt(10, 20); got != want {
+		t.Errorf("Position: got: %v, want: %v", got, want)
+	}
+	if got, want := window.Size, image.Pt(640, 480); got != want {
+		t.Errorf("Size: got: %v, want: %v", got, want)
+	}
+	if !window.Maximized {
+		t.Errorf("Maximized: got: false, want: true")
+	}
+	if window.Fullscreen {
+		t.Errorf("Fullscreen: got: true, want: false")
+	}
+	if !slices.Equal(window.Icon, icon) {
+		t.Errorf("Icon: got: %v, want: %v", window.Icon, icon)
+	}
+
+	// A window state without a size keeps the window size.
+	window.Size = image.Pt(800, 600)
+	guigui.ApplyWindowOptionsForTesting(&guigui.RunOptions{
+		WindowState: &guigui.WindowState{
+			Position: image.Pt(30, 40),
+		},
+		Fullscreen: true,
+	})
+	if got, want := window.Size, image.Pt(800, 600); got != want {
+		t.Errorf("Size: got: %v, want: %v", got, want)
+	}
+	if got, want := window.Position, image.Pt(30, 40); got != want {
+		t.Errorf("Position: got: %v, want: %v", got, want)
+	}
+	if !window.Fullscreen {
+		t.Errorf("Fullscreen: got: false, want: true")
+	}
+}